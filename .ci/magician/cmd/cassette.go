@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+	"magician/exec"
+	"magician/vcr"
+
+	"github.com/spf13/cobra"
+)
+
+var cassetteLintOptions vcr.LintOptions
+
+var cassetteCmd = &cobra.Command{
+	Use:   "cassette",
+	Short: "Lint and migrate VCR cassettes",
+}
+
+var cassetteLintCmd = &cobra.Command{
+	Use:   "lint CASSETTE_DIR",
+	Short: "Lint VCR cassettes",
+	Long: `This command checks the VCR cassettes of a directory, such as the fixtures
+	fetched from the cassette bucket.
+
+	It reports:
+	1. Secrets (tokens, keys and authorization headers) and emails that weren't redacted.
+	2. Requests to hosts that should have been mocked, such as token endpoints or hosts other than Google APIs.
+	3. Interactions that weren't matched during replay, given the reports written by acctest to
+	   VCR_REPLAY_REPORT_PATH with --replay-report-dir.
+	4. Cassettes and seed files of tests that don't exist anymore, given the provider with --provider-dir.
+
+	It fails if any problem is found.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := cassetteLintOptions
+		opts.CassetteDir = args[0]
+		return execCassetteLint(opts)
+	},
+}
+
+var cassetteMigrateCmd = &cobra.Command{
+	Use:   "migrate CASSETTE_DIR OLD_TEST_NAME NEW_TEST_NAME",
+	Short: "Rename the VCR cassettes of a renamed test",
+	Long: `This command renames the cassette and seed file of a test after it's renamed,
+	so that it keeps replaying its recorded interactions.
+
+	CASSETTE_DIR is a local directory or a GCS bucket path such as gs://ci-vcr-cassettes/fixtures.
+	Test names are full test names, including the names of subtests.`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rnr, err := exec.NewRunner()
+		if err != nil {
+			return fmt.Errorf("error creating Runner: %w", err)
+		}
+		return execCassetteMigrate(args[0], args[1], args[2], rnr)
+	},
+}
+
+func execCassetteLint(opts vcr.LintOptions) error {
+	findings, err := vcr.LintCassettes(opts)
+	if err != nil {
+		return fmt.Errorf("error linting cassettes: %w", err)
+	}
+	for _, f := range findings {
+		fmt.Println(f)
+	}
+	if len(findings) > 0 {
+		return fmt.Errorf("found %d problems in cassettes", len(findings))
+	}
+	fmt.Println("No problems found in cassettes")
+	return nil
+}
+
+func execCassetteMigrate(dir, oldTest, newTest string, rnr vcr.ExecRunner) error {
+	moved, err := vcr.MigrateCassettes(dir, oldTest, newTest, rnr)
+	for _, path := range moved {
+		fmt.Println("Moved cassette to", path)
+	}
+	if err != nil {
+		return fmt.Errorf("error migrating cassettes: %w", err)
+	}
+	return nil
+}
+
+func init() {
+	cassetteLintCmd.Flags().StringVar(&cassetteLintOptions.ReplayReportDir, "replay-report-dir", "", "Directory of the replay reports written by acctest, to find interactions that weren't matched")
+	cassetteLintCmd.Flags().StringVar(&cassetteLintOptions.ProviderDir, "provider-dir", "", "Directory of the provider, to find cassettes of deleted tests")
+	cassetteLintCmd.Flags().StringSliceVar(&cassetteLintOptions.MockedHosts, "mocked-host", nil, "Additional hosts that should be mocked")
+	cassetteLintCmd.Flags().StringSliceVar(&cassetteLintOptions.AllowedHosts, "allowed-host", nil, "Hosts other than Google APIs that don't need to be mocked")
+	cassetteLintCmd.Flags().StringSliceVar(&cassetteLintOptions.AllowedEmailDomains, "allowed-email-domain", nil, "Additional domains of emails that can be recorded, such as the domain of the test organization")
+
+	cassetteCmd.AddCommand(cassetteLintCmd)
+	cassetteCmd.AddCommand(cassetteMigrateCmd)
+	rootCmd.AddCommand(cassetteCmd)
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExecCassetteMigrate(t *testing.T) {
+	runner := &mockRunner{
+		cwd:           "cwd",
+		calledMethods: make(map[string][]ParameterList),
+		cmdResults:    make(map[string]string),
+	}
+	if err := execCassetteMigrate("gs://ci-vcr-cassettes/fixtures/", "TestAccPubsubTopic_old", "TestAccPubsubTopic_new", runner); err != nil {
+		t.Fatalf("execCassetteMigrate() = %v, want nil", err)
+	}
+
+	var want []ParameterList
+	for _, cmd := range []string{
+		"gsutil ls gs://ci-vcr-cassettes/fixtures/TestAccPubsubTopic_old.yaml",
+		"gsutil mv gs://ci-vcr-cassettes/fixtures/TestAccPubsubTopic_old.yaml gs://ci-vcr-cassettes/fixtures/TestAccPubsubTopic_new.yaml",
+		"gsutil ls gs://ci-vcr-cassettes/fixtures/TestAccPubsubTopic_old.seed",
+		"gsutil mv gs://ci-vcr-cassettes/fixtures/TestAccPubsubTopic_old.seed gs://ci-vcr-cassettes/fixtures/TestAccPubsubTopic_new.seed",
+	} {
+		words := strings.Split(cmd, " ")
+		want = append(want, []any{"cwd", words[0], words[1:], map[string]string(nil)})
+	}
+	got, _ := runner.Calls("Run")
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("execCassetteMigrate() executed commands diff = %s", diff)
+	}
+}
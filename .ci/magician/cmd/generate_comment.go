@@ -60,6 +60,7 @@ type Diff struct {
 type BreakingChange struct {
 	Message                string
 	DocumentationReference string
+	Severity               string
 }
 
 type MissingTestInfo struct {
@@ -86,6 +87,7 @@ type Errors struct {
 type diffCommentData struct {
 	Diffs                []Diff
 	BreakingChanges      []BreakingChange
+	BehaviorChanges      []BreakingChange
 	MissingServiceLabels []string
 	MissingTests         map[string]*MissingTestInfo
 	MissingDocs          *MissingDocsSummary
@@ -101,6 +103,15 @@ const allowBreakingChangesLabel = "override-breaking-change"
 const allowMissingServiceLabelsLabel = "override-missing-service-labels"
 const allowMultipleResourcesLabel = "override-multiple-resources"
 
+// breakingChangesAllowlist is the diff processor's list of acknowledged
+// breaking changes, relative to its directory.
+const breakingChangesAllowlist = "breaking-changes-allowlist.yaml"
+
+// breakingChangesAllowlistPath is the path of breakingChangesAllowlist in
+// magic-modules. PRs editing it are checked without it, so that they can't
+// acknowledge their own breaking changes without allowBreakingChangesLabel.
+const breakingChangesAllowlistPath = "tools/diff-processor/" + breakingChangesAllowlist
+
 var gcEnvironmentVariables = [...]string{
 	"BUILD_ID",
 	"BUILD_STEP",
@@ -300,6 +311,7 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 	uniqueAddedResources := map[string]struct{}{}
 	uniqueAffectedResources := map[string]struct{}{}
 	uniqueBreakingChanges := map[string]BreakingChange{}
+	uniqueBehaviorChanges := map[string]BreakingChange{}
 	diffProcessorPath := filepath.Join(mmLocalPath, "tools", "diff-processor")
 	diffProcessorEnv := map[string]string{
 		"OLD_REF": oldBranch,
@@ -309,6 +321,14 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 		"GOPATH": os.Getenv("GOPATH"),
 		"HOME":   os.Getenv("HOME"),
 	}
+	useAllowlist := true
+	if files, err := gh.GetPullRequestFiles(strconv.Itoa(prNumber)); err != nil {
+		fmt.Println("Error getting pull request files; checking breaking changes without the allowlist: ", err)
+		useAllowlist = false
+	} else if slices.Contains(files, breakingChangesAllowlistPath) {
+		fmt.Println("Checking breaking changes without the allowlist, which is edited by the PR")
+		useAllowlist = false
+	}
 	for _, repo := range []source.Repo{tpgRepo, tpgbRepo} {
 		if !repo.Cloned {
 			fmt.Println("Skipping diff processor; repo failed to clone: ", repo.Name)
@@ -325,12 +345,17 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 			continue
 		}
 
-		breakingChanges, err := computeBreakingChanges(diffProcessorPath, rnr)
+		breakingChanges, err := computeBreakingChanges(diffProcessorPath, useAllowlist, rnr)
 		if err != nil {
 			fmt.Println("computing breaking changes: ", err)
 			errors[repo.Title] = append(errors[repo.Title], "The diff processor crashed while computing breaking changes. This is usually due to the downstream provider failing to compile.")
 		}
 		for _, breakingChange := range breakingChanges {
+			// Warnings may break configs depending on the API, so they don't block merging.
+			if breakingChange.Severity == "warning" {
+				uniqueBehaviorChanges[breakingChange.Message] = breakingChange
+				continue
+			}
 			uniqueBreakingChanges[breakingChange.Message] = breakingChange
 		}
 
@@ -374,6 +399,11 @@ func execGenerateComment(prNumber int, ghTokenMagicModules, buildId, buildStep,
 		return breakingChangesSlice[i].Message < breakingChangesSlice[j].Message
 	})
 	data.BreakingChanges = breakingChangesSlice
+	behaviorChangesSlice := maps.Values(uniqueBehaviorChanges)
+	sort.Slice(behaviorChangesSlice, func(i, j int) bool {
+		return behaviorChangesSlice[i].Message < behaviorChangesSlice[j].Message
+	})
+	data.BehaviorChanges = behaviorChangesSlice
 
 	// Check if multiple resources were added.
 	multipleResourcesState := "success"
@@ -548,11 +578,15 @@ func buildDiffProcessor(diffProcessorPath, providerLocalPath string, env map[str
 	return rnr.PopDir()
 }
 
-func computeBreakingChanges(diffProcessorPath string, rnr ExecRunner) ([]BreakingChange, error) {
+func computeBreakingChanges(diffProcessorPath string, useAllowlist bool, rnr ExecRunner) ([]BreakingChange, error) {
 	if err := rnr.PushDir(diffProcessorPath); err != nil {
 		return nil, err
 	}
-	output, err := rnr.Run("bin/diff-processor", []string{"breaking-changes"}, nil)
+	args := []string{"breaking-changes"}
+	if useAllowlist {
+		args = append(args, "--allowlist", breakingChangesAllowlist)
+	}
+	output, err := rnr.Run("bin/diff-processor", args, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -106,10 +106,10 @@ func TestExecGenerateComment(t *testing.T) {
 			{"/mock/dir/tgc", "git", []string{"diff", "origin/auto-pr-123456-old", "origin/auto-pr-123456", "--name-only"}, map[string]string(nil)},
 			{"/mock/dir/tfoics", "git", []string{"diff", "origin/auto-pr-123456-old", "origin/auto-pr-123456", "--shortstat"}, map[string]string(nil)},
 			{"/mock/dir/magic-modules/tools/diff-processor", "make", []string{"build"}, diffProcessorEnv},
-			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"breaking-changes"}, map[string]string(nil)},
+			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"breaking-changes", "--allowlist", "breaking-changes-allowlist.yaml"}, map[string]string(nil)},
 			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"schema-diff"}, map[string]string(nil)},
 			{"/mock/dir/magic-modules/tools/diff-processor", "make", []string{"build"}, diffProcessorEnv},
-			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"breaking-changes"}, map[string]string(nil)},
+			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"breaking-changes", "--allowlist", "breaking-changes-allowlist.yaml"}, map[string]string(nil)},
 			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"detect-missing-tests", "/mock/dir/tpgb/google-beta/services"}, map[string]string(nil)},
 			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"detect-missing-docs", "/mock/dir/tpgb"}, map[string]string(nil)},
 			{"/mock/dir/magic-modules/tools/diff-processor", "bin/diff-processor", []string{"schema-diff"}, map[string]string(nil)},
@@ -151,6 +151,36 @@ func TestExecGenerateComment(t *testing.T) {
 	}
 }
 
+func TestComputeBreakingChanges(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		useAllowlist bool
+		expectedArgs []string
+	}{
+		{
+			name:         "allowlist",
+			useAllowlist: true,
+			expectedArgs: []string{"breaking-changes", "--allowlist", "breaking-changes-allowlist.yaml"},
+		},
+		{
+			name:         "allowlist edited by the PR",
+			useAllowlist: false,
+			expectedArgs: []string{"breaking-changes"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mr := NewMockRunner()
+			if _, err := computeBreakingChanges("/mock/dir/magic-modules/tools/diff-processor", tc.useAllowlist, mr); err != nil {
+				t.Fatal(err)
+			}
+			calls, _ := mr.Calls("Run")
+			if len(calls) != 1 || !reflect.DeepEqual(calls[0][2], tc.expectedArgs) {
+				t.Errorf("expected the diff processor to run with %v, got %v", tc.expectedArgs, calls)
+			}
+		})
+	}
+}
+
 func TestFormatDiffComment(t *testing.T) {
 	cases := map[string]struct {
 		data               diffCommentData
@@ -244,6 +274,27 @@ func TestFormatDiffComment(t *testing.T) {
 				"## Missing test report",
 			},
 		},
+		"behavior changes are displayed": {
+			data: diffCommentData{
+				BehaviorChanges: []BreakingChange{
+					{
+						Message:                "Behavior change 1",
+						DocumentationReference: "doc1",
+						Severity:               "warning",
+					},
+				},
+			},
+			expectedStrings: []string{
+				"## Diff report",
+				"## Potential behavior change(s) detected",
+				"- Behavior change 1 - [reference](doc1)\n",
+			},
+			notExpectedStrings: []string{
+				"generated some diffs",
+				"## Breaking Change(s) Detected",
+				"## Errors",
+			},
+		},
 		"multiple resources are displayed": {
 			data: diffCommentData{
 				AddedResources: []string{"google_redis_instance", "google_alloydb_cluster"},
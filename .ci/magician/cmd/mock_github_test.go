@@ -27,6 +27,7 @@ type mockGithub struct {
 	requestedReviewers  []github.User
 	previousReviewers   []github.User
 	pullRequestComments []github.PullRequestComment
+	pullRequestFiles    []string
 	teamMembers         map[string][]github.User
 	calledMethods       map[string][][]any
 	commitMessage       string
@@ -62,6 +63,11 @@ func (m *mockGithub) GetPullRequestComments(prNumber string) ([]github.PullReque
 	return m.pullRequestComments, nil
 }
 
+func (m *mockGithub) GetPullRequestFiles(prNumber string) ([]string, error) {
+	m.calledMethods["GetPullRequestFiles"] = append(m.calledMethods["GetPullRequestFiles"], []any{prNumber})
+	return m.pullRequestFiles, nil
+}
+
 func (m *mockGithub) GetCommitMessage(owner, repo, sha string) (string, error) {
 	m.calledMethods["GetCommitMessage"] = append(m.calledMethods["GetCommitMessage"], []any{owner, repo, sha})
 	return m.commitMessage, nil
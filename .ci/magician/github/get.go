@@ -184,6 +184,39 @@ func (c *Client) GetPullRequestComments(prNumber string) ([]PullRequestComment,
 	return convertGHComments(allComments), nil
 }
 
+// GetPullRequestFiles gets the paths of the files changed by a PR, handling pagination
+func (c *Client) GetPullRequestFiles(prNumber string) ([]string, error) {
+	num, err := strconv.Atoi(prNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var allFiles []string
+	opts := &gh.ListOptions{
+		PerPage: 100,
+	}
+
+	for {
+		files, resp, err := c.gh.PullRequests.ListFiles(c.ctx, defaultOwner, defaultRepo, num, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, file := range files {
+			allFiles = append(allFiles, file.GetFilename())
+		}
+
+		if resp.NextPage == 0 {
+			break // No more pages
+		}
+
+		// Set up for the next page
+		opts.Page = resp.NextPage
+	}
+
+	return allFiles, nil
+}
+
 // GetTeamMembers gets all members of a team, handling pagination
 func (c *Client) GetTeamMembers(organization, team string) ([]User, error) {
 	var allMembers []*gh.User
@@ -0,0 +1,397 @@
+package vcr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Cassette is a VCR cassette recorded by acctest, with the parts of its
+// interactions that are linted.
+type Cassette struct {
+	Interactions []Interaction `yaml:"interactions"`
+}
+
+type Interaction struct {
+	Request  CassetteRequest  `yaml:"request"`
+	Response CassetteResponse `yaml:"response"`
+}
+
+type CassetteRequest struct {
+	Body    string      `yaml:"body"`
+	Headers http.Header `yaml:"headers"`
+	URL     string      `yaml:"url"`
+	Method  string      `yaml:"method"`
+}
+
+type CassetteResponse struct {
+	Body    string      `yaml:"body"`
+	Headers http.Header `yaml:"headers"`
+	Code    int         `yaml:"code"`
+}
+
+// ReplayReport lists the interactions of a cassette that weren't matched when
+// replaying its test, as written by acctest to VCR_REPLAY_REPORT_PATH.
+type ReplayReport struct {
+	Interactions int   `json:"interactions"`
+	Unmatched    []int `json:"unmatched"`
+}
+
+// Checks run by LintCassettes.
+const (
+	CheckSecret       = "secret"
+	CheckEmail        = "email"
+	CheckUnmatched    = "unmatched"
+	CheckOrphaned     = "orphaned"
+	CheckUnmockedHost = "unmocked-host"
+)
+
+// Value of redacted fields and headers in cassettes.
+const redactedValue = "REDACTED"
+
+// Hosts that tests should never send requests to, as they're part of
+// authenticating rather than of the API under test.
+var DefaultMockedHosts = []string{
+	"accounts.google.com",
+	"metadata.google.internal",
+	"oauth2.googleapis.com",
+	"sts.googleapis.com",
+}
+
+// Domains of emails that can be recorded in cassettes, such as the emails of
+// service accounts created by tests.
+var DefaultAllowedEmailDomains = []string{
+	"example.com",
+	"gserviceaccount.com",
+}
+
+var secretRegexes = map[string]*regexp.Regexp{
+	"OAuth access token":  regexp.MustCompile(`ya29\.[0-9A-Za-z_-]{20,}`),
+	"OAuth refresh token": regexp.MustCompile(`1//[0-9A-Za-z_-]{20,}`),
+	"API key":             regexp.MustCompile(`AIza[0-9A-Za-z_-]{35}`),
+	"private key":         regexp.MustCompile(`-----BEGIN (RSA |EC )?PRIVATE KEY-----`),
+}
+
+var emailRegex = regexp.MustCompile(`[A-Za-z0-9._%+-]+@([A-Za-z0-9-]+\.)+[A-Za-z]{2,}`)
+
+var testFuncRegex = regexp.MustCompile(`(?m)^func (Test\w+)\(t \*testing\.T\)`)
+
+// subtestNameRegex matches the string literals of a test that may name its
+// subtests, either as arguments of t.Run or as keys of a table of test cases.
+var subtestNameRegex = regexp.MustCompile(`"([\w -]+)"`)
+
+// secretHeaders are headers whose values are always secrets.
+var secretHeaders = []string{"Authorization", "Proxy-Authorization", "X-Goog-Api-Key"}
+
+type LintOptions struct {
+	// Directory containing the cassettes to lint.
+	CassetteDir string
+	// Directory containing the replay reports of the cassettes, to find the
+	// interactions that aren't matched anymore. Optional.
+	ReplayReportDir string
+	// Directory containing the tests of the provider, to find the cassettes
+	// of deleted tests. Optional.
+	ProviderDir string
+	// Hosts that should be mocked, in addition to DefaultMockedHosts. Hosts
+	// other than Google APIs always should be.
+	MockedHosts []string
+	// Hosts other than Google APIs that don't need to be mocked.
+	AllowedHosts []string
+	// Domains of emails that can be recorded, in addition to
+	// DefaultAllowedEmailDomains.
+	AllowedEmailDomains []string
+}
+
+// LintFinding is a problem found in a cassette.
+type LintFinding struct {
+	// File name of the cassette, relative to the cassette directory.
+	Cassette string
+	// Index of the interaction in the cassette, or -1 for the whole
+	// cassette.
+	Interaction int
+	Check       string
+	Message     string
+}
+
+func (f LintFinding) String() string {
+	if f.Interaction < 0 {
+		return fmt.Sprintf("%s: [%s] %s", f.Cassette, f.Check, f.Message)
+	}
+	return fmt.Sprintf("%s: interaction %d: [%s] %s", f.Cassette, f.Interaction, f.Check, f.Message)
+}
+
+// LintCassettes checks the cassettes of a directory for secrets and emails
+// that weren't redacted, requests to hosts that should've been mocked,
+// interactions that aren't matched anymore and cassettes of deleted tests.
+func LintCassettes(opts LintOptions) ([]LintFinding, error) {
+	names, err := cassetteFileNames(opts.CassetteDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var tests map[string]bool
+	if opts.ProviderDir != "" {
+		tests, err = providerTestNames(opts.ProviderDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var findings []LintFinding
+	for _, name := range names {
+		if opts.ProviderDir != "" && !tests[strings.TrimSuffix(name, filepath.Ext(name))] {
+			findings = append(findings, LintFinding{
+				Cassette:    name,
+				Interaction: -1,
+				Check:       CheckOrphaned,
+				Message:     "no test records this cassette, it may have been deleted or renamed",
+			})
+		}
+		if filepath.Ext(name) != ".yaml" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(opts.CassetteDir, name))
+		if err != nil {
+			return nil, err
+		}
+		var c Cassette
+		if err := yaml.Unmarshal(data, &c); err != nil {
+			return nil, fmt.Errorf("error parsing cassette %s: %w", name, err)
+		}
+		for i, interaction := range c.Interactions {
+			findings = append(findings, lintInteraction(name, i, interaction, opts)...)
+		}
+
+		if opts.ReplayReportDir != "" {
+			unmatched, err := lintReplayReport(name, len(c.Interactions), opts.ReplayReportDir)
+			if err != nil {
+				return nil, err
+			}
+			findings = append(findings, unmatched...)
+		}
+	}
+	return findings, nil
+}
+
+// cassetteFileNames returns the sorted names of the cassettes and seed files
+// of a directory.
+func cassetteFileNames(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if ext := filepath.Ext(e.Name()); !e.IsDir() && (ext == ".yaml" || ext == ".seed") {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// providerTestNames returns the names of the cassettes that the tests of a
+// provider may record: the name of each test and, for tests with subtests, the
+// name of each subtest like `TestAccFoo_subtest`.
+func providerTestNames(dir string) (map[string]bool, error) {
+	names := make(map[string]bool)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		src := string(data)
+		matches := testFuncRegex.FindAllStringSubmatchIndex(src, -1)
+		for i, match := range matches {
+			test := src[match[2]:match[3]]
+			names[test] = true
+
+			end := len(src)
+			if i+1 < len(matches) {
+				end = matches[i+1][0]
+			}
+			body := src[match[1]:end]
+			if !strings.Contains(body, "t.Run(") {
+				continue
+			}
+			for _, sub := range subtestNameRegex.FindAllStringSubmatch(body, -1) {
+				// t.Name() rewrites spaces and the VCR file name rewrites slashes
+				names[test+"_"+strings.ReplaceAll(sub[1], " ", "_")] = true
+			}
+		}
+		return nil
+	})
+	return names, err
+}
+
+func lintInteraction(cassette string, i int, interaction Interaction, opts LintOptions) []LintFinding {
+	var findings []LintFinding
+	add := func(check, format string, a ...any) {
+		findings = append(findings, LintFinding{Cassette: cassette, Interaction: i, Check: check, Message: fmt.Sprintf(format, a...)})
+	}
+
+	for _, headers := range []http.Header{interaction.Request.Headers, interaction.Response.Headers} {
+		for _, name := range secretHeaders {
+			if v := headers.Get(name); v != "" && v != redactedValue {
+				add(CheckSecret, "header %s isn't redacted", name)
+			}
+		}
+	}
+
+	for _, part := range []struct{ name, body string }{
+		{"request", interaction.Request.Body},
+		{"response", interaction.Response.Body},
+	} {
+		for _, kind := range sortedKeys(secretRegexes) {
+			if secretRegexes[kind].MatchString(part.body) {
+				add(CheckSecret, "%s body contains an unredacted %s", part.name, kind)
+			}
+		}
+		for _, email := range unallowedEmails(part.body, opts.AllowedEmailDomains) {
+			add(CheckEmail, "%s body contains the email %s", part.name, email)
+		}
+	}
+
+	if u, err := url.Parse(interaction.Request.URL); err == nil && shouldBeMocked(u.Hostname(), opts) {
+		add(CheckUnmockedHost, "request to %s should be mocked", u.Hostname())
+	}
+	return findings
+}
+
+func unallowedEmails(body string, allowedDomains []string) []string {
+	allowed := append(append([]string{}, DefaultAllowedEmailDomains...), allowedDomains...)
+	seen := make(map[string]bool)
+	var emails []string
+	for _, email := range emailRegex.FindAllString(body, -1) {
+		domain := strings.ToLower(email[strings.LastIndex(email, "@")+1:])
+		if seen[email] || hasDomain(domain, allowed) {
+			continue
+		}
+		seen[email] = true
+		emails = append(emails, email)
+	}
+	return emails
+}
+
+func shouldBeMocked(host string, opts LintOptions) bool {
+	host = strings.ToLower(host)
+	if host == "" {
+		return false
+	}
+	for _, mocked := range append(append([]string{}, DefaultMockedHosts...), opts.MockedHosts...) {
+		if host == mocked {
+			return true
+		}
+	}
+	return !hasDomain(host, append([]string{"googleapis.com"}, opts.AllowedHosts...))
+}
+
+// hasDomain returns whether name is one of domains or a subdomain of one.
+func hasDomain(name string, domains []string) bool {
+	for _, d := range domains {
+		if name == d || strings.HasSuffix(name, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+func lintReplayReport(cassette string, interactions int, dir string) ([]LintFinding, error) {
+	data, err := os.ReadFile(filepath.Join(dir, strings.TrimSuffix(cassette, ".yaml")+".json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		// The test didn't replay, or failed
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var report ReplayReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("error parsing replay report of %s: %w", cassette, err)
+	}
+	if report.Interactions != interactions {
+		// The report is for another version of the cassette
+		return nil, nil
+	}
+
+	var findings []LintFinding
+	for _, i := range report.Unmatched {
+		findings = append(findings, LintFinding{
+			Cassette:    cassette,
+			Interaction: i,
+			Check:       CheckUnmatched,
+			Message:     "interaction wasn't matched by any request during replay",
+		})
+	}
+	return findings, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MigrateCassettes renames the cassette and seed file of a test to the name of
+// another test, for tests that were renamed. Cassettes are in a local
+// directory, or a GCS bucket when dir starts with gs://.
+func MigrateCassettes(dir, oldTest, newTest string, rnr ExecRunner) ([]string, error) {
+	var moved []string
+	for _, ext := range []string{".yaml", ".seed"} {
+		oldPath := cassettePath(dir, oldTest, ext)
+		newPath := cassettePath(dir, newTest, ext)
+		if strings.HasPrefix(dir, "gs://") {
+			if _, err := rnr.Run("gsutil", []string{"ls", oldPath}, nil); err != nil {
+				continue
+			}
+			if _, err := rnr.Run("gsutil", []string{"mv", oldPath, newPath}, nil); err != nil {
+				return moved, fmt.Errorf("error moving %s to %s: %w", oldPath, newPath, err)
+			}
+		} else {
+			if _, err := os.Stat(oldPath); errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if _, err := os.Stat(newPath); err == nil {
+				return moved, fmt.Errorf("%s already exists", newPath)
+			}
+			if err := os.Rename(oldPath, newPath); err != nil {
+				return moved, err
+			}
+		}
+		moved = append(moved, newPath)
+	}
+	if len(moved) == 0 {
+		return nil, fmt.Errorf("no cassette found for %s in %s", oldTest, dir)
+	}
+	return moved, nil
+}
+
+// cassettePath returns the path of the cassette or seed file of a test, named
+// like acctest's vcrFileName.
+func cassettePath(dir, test, ext string) string {
+	name := strings.ReplaceAll(test, "/", "_") + ext
+	if strings.HasPrefix(dir, "gs://") {
+		return strings.TrimSuffix(dir, "/") + "/" + name
+	}
+	return filepath.Join(dir, name)
+}
@@ -14,9 +14,9 @@
 package api
 
 import (
-	"log"
 	"strings"
 
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"golang.org/x/exp/slices"
 	"gopkg.in/yaml.v3"
 )
@@ -75,6 +75,12 @@ type OpAsync struct {
 	// If true, include project as an argument to OperationWaitTime.
 	// It is intended for resources that calculate project/region from a selflink field
 	IncludeProject bool `yaml:"include_project"`
+
+	// If true, a create whose operation isn't done when waiting for it times
+	// out or is interrupted keeps the resource in state with its pending
+	// `operation`, and the next refresh resumes waiting for the operation
+	// instead of the next apply creating the resource again.
+	Resumable bool `yaml:"resumable,omitempty"`
 }
 
 type OpAsyncOperation struct {
@@ -101,6 +107,16 @@ type PollAsync struct {
 	// deleting a resource
 	CheckResponseFuncAbsence string `yaml:"check_response_func_absence,omitempty"`
 
+	// Conditions on the Poll response for creating and updating a resource,
+	// instead of a function. Without either, polling waits until the
+	// resource is found.
+	ExistenceConditions *PollConditions `yaml:"existence_conditions,omitempty"`
+
+	// Conditions on the Poll response for deleting a resource, instead of a
+	// function. Without either, polling waits until the resource isn't
+	// found.
+	AbsenceConditions *PollConditions `yaml:"absence_conditions,omitempty"`
+
 	// If true, will suppress errors from polling and default to the
 	// result of the final Read()
 	SuppressError bool `yaml:"suppress_error,omitempty"`
@@ -110,12 +126,78 @@ type PollAsync struct {
 	TargetOccurrences int `yaml:"target_occurrences,omitempty"`
 }
 
+// Describes the state of a polled resource through conditions on the fields
+// of the Poll response.
+type PollConditions struct {
+	// Conditions that all hold when the resource reached the state polled
+	// for. For absence, a resource isn't required to be not found when they
+	// hold, such as when it's soft deleted.
+	Done []PollFieldCondition `yaml:"done,omitempty"`
+
+	// Conditions any of which holds when the resource won't reach the state
+	// polled for, which stops polling with an error.
+	Failed []PollFieldCondition `yaml:"failed,omitempty"`
+
+	// The error codes of a Poll read of a resource that doesn't exist.
+	// Defaults to [404].
+	NotFoundCodes []int `yaml:"not_found_codes,omitempty"`
+}
+
+// A condition on a field of the Poll response, which is either equal to a
+// value, one of several values, or absent.
+type PollFieldCondition struct {
+	// The dotted path of the field in the API response, e.g. "status.state"
+	Field string `yaml:"field"`
+
+	Equals string `yaml:"equals,omitempty"`
+
+	In []string `yaml:"in,omitempty"`
+
+	Absent bool `yaml:"absent,omitempty"`
+}
+
+// Values returns the values the field has to be one of.
+func (c PollFieldCondition) Values() []string {
+	if c.Equals != "" {
+		return []string{c.Equals}
+	}
+	return c.In
+}
+
+func (c *PollConditions) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
+	for _, cond := range c.Done {
+		errs.Extend("done", cond.Validate())
+	}
+	for _, cond := range c.Failed {
+		errs.Extend("failed", cond.Validate())
+	}
+	return errs
+}
+
+func (c PollFieldCondition) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
+	if c.Field == "" {
+		errs.Addf("field", "missing `field` for poll condition")
+	}
+	set := 0
+	for _, b := range []bool{c.Equals != "", len(c.In) > 0, c.Absent} {
+		if b {
+			set++
+		}
+	}
+	if set != 1 {
+		errs.Addf("", "exactly one of `equals`, `in` and `absent` must be set for poll condition on %q", c.Field)
+	}
+	return errs
+}
+
 func (a *Async) UnmarshalYAML(value *yaml.Node) error {
 	a.Actions = []string{"create", "delete", "update"}
 	type asyncAlias Async
 	aliasObj := (*asyncAlias)(a)
 
-	err := value.Decode(aliasObj)
+	err := google.DecodeKnownFields(value, aliasObj)
 	if err != nil {
 		return err
 	}
@@ -130,14 +212,34 @@ func (a *Async) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
-func (a *Async) Validate() {
+func (a *Async) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
 	if a.Type == "OpAsync" {
 		if a.Operation == nil {
-			log.Fatalf("Missing `Operation` for OpAsync")
+			errs.Addf("operation", "missing `operation` for OpAsync")
 		} else {
 			if a.Operation.BaseUrl != "" && a.Operation.FullUrl != "" {
-				log.Fatalf("`base_url` and `full_url` cannot be set at the same time in OpAsync operation.")
+				errs.Addf("operation.base_url", "`base_url` and `full_url` cannot be set at the same time in OpAsync operation")
 			}
 		}
 	}
+	if a.ExistenceConditions != nil {
+		if a.CheckResponseFuncExistence != "" {
+			errs.Addf("existence_conditions", "`check_response_func_existence` and `existence_conditions` cannot be set at the same time")
+		}
+		errs.Extend("existence_conditions", a.ExistenceConditions.Validate())
+	}
+	if a.AbsenceConditions != nil {
+		if a.CheckResponseFuncAbsence != "" {
+			errs.Addf("absence_conditions", "`check_response_func_absence` and `absence_conditions` cannot be set at the same time")
+		}
+		errs.Extend("absence_conditions", a.AbsenceConditions.Validate())
+	}
+	if (a.ExistenceConditions != nil || a.AbsenceConditions != nil) && a.Type != "PollAsync" {
+		errs.Addf("type", "poll conditions require a PollAsync")
+	}
+	if a.Resumable && (a.Type != "OpAsync" || !a.Allow("create")) {
+		errs.Addf("resumable", "`resumable` requires an OpAsync create")
+	}
+	return errs
 }
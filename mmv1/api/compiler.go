@@ -15,17 +15,18 @@ package api
 
 import (
 	"bytes"
-	"log"
 	"os"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
-func Compile(yamlPath string, obj interface{}, overrideDir string) {
+// Compile decodes the YAML file at yamlPath into obj. Problems in the file are
+// returned as google.ValidationErrors.
+func Compile(yamlPath string, obj interface{}, overrideDir string) error {
 	objYaml, err := os.ReadFile(yamlPath)
 
 	if err != nil {
-		log.Fatalf("Cannot open the file: %s", yamlPath)
+		return google.ValidationErrors{{File: yamlPath, Message: "cannot open the file"}}
 	}
 
 	if overrideDir != "" {
@@ -33,5 +34,5 @@ func Compile(yamlPath string, obj interface{}, overrideDir string) {
 	}
 
 	yamlValidator := google.YamlValidator{}
-	yamlValidator.Parse(objYaml, obj, yamlPath)
+	return yamlValidator.Parse(objYaml, obj, yamlPath)
 }
@@ -88,7 +88,7 @@ func (p *Product) UnmarshalYAML(value *yaml.Node) error {
 	type productAlias Product
 	aliasObj := (*productAlias)(p)
 
-	if err := value.Decode(aliasObj); err != nil {
+	if err := google.DecodeKnownFields(value, aliasObj); err != nil {
 		return err
 	}
 
@@ -98,15 +98,18 @@ func (p *Product) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
-func (p *Product) Validate() {
+// Validate checks the product configuration. The fields of the returned
+// errors are relative to the root of product.yaml.
+func (p *Product) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
 	if len(p.Name) == 0 {
-		log.Fatalf("Missing `name` for product")
+		errs.Addf("name", "missing `name` for product")
 	}
 
 	// product names must start with a capital
 	for i, ch := range p.Name {
 		if !unicode.IsUpper(ch) {
-			log.Fatalf("product name `%s` must start with a capital letter.", p.Name)
+			errs.Addf("name", "product name `%s` must start with a capital letter", p.Name)
 		}
 		if i == 0 {
 			break
@@ -114,20 +117,24 @@ func (p *Product) Validate() {
 	}
 
 	if len(p.Scopes) == 0 {
-		log.Fatalf("Missing `scopes` for product %s", p.Name)
+		errs.Addf("scopes", "missing `scopes` for product %s", p.Name)
 	}
 
 	if p.Versions == nil {
-		log.Fatalf("Missing `versions` for product %s", p.Name)
+		errs.Addf("versions", "missing `versions` for product %s", p.Name)
 	}
 
 	for _, v := range p.Versions {
-		v.Validate(p.Name)
+		errs.Extend(google.ItemField("versions", v.Name), v.Validate())
 	}
 
 	if p.Async != nil {
-		p.Async.Validate()
+		errs.Extend("async", p.Async.Validate())
+		if p.Async.Resumable {
+			errs.Addf("async.resumable", "`resumable` can only be set on resources")
+		}
 	}
+	return errs
 }
 
 // ====================
@@ -239,6 +246,17 @@ func (p *Product) SetPropertiesBasedOnVersion(version *product.Version) {
 	p.CaiBaseUrl = version.CaiBaseUrl
 }
 
+// HasBatching returns whether any resource of the product batches its
+// requests, and so needs a transport.RequestBatcher.
+func (p *Product) HasBatching() bool {
+	for _, r := range p.Objects {
+		if r.Batching != nil && !r.Exclude {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *Product) TerraformName() string {
 	if p.LegacyName != "" {
 		return google.Underscore(p.LegacyName)
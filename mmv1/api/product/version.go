@@ -14,9 +14,9 @@
 package product
 
 import (
-	"log"
-
 	"golang.org/x/exp/slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
 var ORDER = []string{"ga", "beta", "alpha", "private"}
@@ -32,13 +32,15 @@ type Version struct {
 	Name             string
 }
 
-func (v *Version) Validate(pName string) {
+func (v *Version) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
 	if v.Name == "" {
-		log.Fatalf("Missing `name` in `version` for product %s", pName)
+		errs.Addf("name", "missing `name` in `version`")
 	}
 	if v.BaseUrl == "" {
-		log.Fatalf("Missing `base_url` in `version` for product %s", pName)
+		errs.Addf("base_url", "missing `base_url` in `version`")
 	}
+	return errs
 }
 
 func (v *Version) CompareTo(other *Version) int {
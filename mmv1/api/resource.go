@@ -216,6 +216,21 @@ type Resource struct {
 	// resource.
 	Mutex string `yaml:"mutex,omitempty"`
 
+	// [Optional] (Api::Resource::Lock) Locks held by the resource's actions,
+	// each either exclusive or shared, to serialize conflicting API calls
+	// more finely than `mutex`.
+	Locks []*resource.Lock `yaml:"locks,omitempty"`
+
+	// [Optional] (Api::Resource::Batching) Batches the create and delete
+	// requests of the resource, to avoid running into quota limits when many
+	// resources are created or deleted at once.
+	Batching *resource.Batching `yaml:"batching,omitempty"`
+
+	// [Optional] (Api::Resource::VcrMatcher) Rules for matching the requests
+	// of the resource's generated tests with their VCR cassettes, for requests
+	// containing values that change between runs.
+	VcrMatcher *resource.VcrMatcher `yaml:"vcr_matcher,omitempty"`
+
 	// Examples in documentation. Backed by generated tests, and have
 	// corresponding OiCS walkthroughs.
 	Examples []*resource.Examples
@@ -232,6 +247,14 @@ type Resource struct {
 	// If true, resource should be autogenerated as a data source
 	Datasource *resource.Datasource `yaml:"datasource,omitempty"`
 
+	// If set, a plugin-framework list resource is generated so that
+	// existing instances can be enumerated with `terraform query`
+	ListResource *resource.ListResource `yaml:"list_resource,omitempty"`
+
+	// If set, a plugin-framework ephemeral resource is generated. This may be
+	// combined with `exclude_resource` for APIs that only return secret values.
+	Ephemeral *resource.Ephemeral `yaml:"ephemeral,omitempty"`
+
 	// If true, skip sweeper generation for this resource
 	ExcludeSweeper bool `yaml:"exclude_sweeper,omitempty"`
 
@@ -257,8 +280,15 @@ type Resource struct {
 	// Normally, it is not needed to be set.
 	StateUpgradeBaseSchemaVersion int `yaml:"state_upgrade_base_schema_version,omitempty"`
 
+	// Generates state_upgrader code. The upgrader from a schema version is
+	// generated from the recorded snapshots of that version and the next one,
+	// and otherwise included from mmv1/templates/terraform/state_migrations/.
 	StateUpgraders bool `yaml:"state_upgraders,omitempty"`
 
+	// The recorded snapshots of the resource's prior schemas, loaded from the
+	// schema_snapshots directory of its product.
+	SchemaSnapshots []*SchemaSnapshot `yaml:"-"`
+
 	// Do not apply the default attribution label
 	ExcludeAttributionLabel bool `yaml:"exclude_attribution_label,omitempty"`
 
@@ -286,6 +316,12 @@ type Resource struct {
 	// instead of a project field to use User Project Overrides
 	SupportsIndirectUserProjectOverride bool `yaml:"supports_indirect_user_project_override,omitempty"`
 
+	// If true, the create and update APIs of the resource accept
+	// `validateOnly=true`, and the resource sends its planned create or update
+	// with it during plan when the provider's `validate_on_plan` is set, so
+	// API-side validation errors are reported before apply.
+	SupportsValidateOnly bool `yaml:"supports_validate_only,omitempty"`
+
 	// If true, the resource's project field can be specified as either the short form project
 	// id or the long form projects/project-id. The extra projects/ string will be removed from
 	// urls and ids. This should only be used for resources that previously supported long form
@@ -311,10 +347,19 @@ type Resource struct {
 	// control if a resource is continuously generated from public OpenAPI docs
 	AutogenStatus string `yaml:"autogen_status"`
 
-	// WARNING: this is an incomplete feature and may have several build errors.
-	// If true, this resource generates with the new plugin framework resource template
+	// If true, this resource generates with the plugin framework resource
+	// template instead of the SDK one and is registered in the framework
+	// provider. custom_code snippets are inlined into the framework methods and
+	// must be written against the framework request and response types.
 	FrameworkResource bool `yaml:"plugin_framework,omitempty"`
 
+	// If true, this resource generates both its SDK implementation, which stays
+	// registered in the provider, and an unregistered plugin framework
+	// implementation, along with a unit test checking that their schemas match.
+	// This is used to verify a resource before switching it to
+	// `plugin_framework`, so custom code is not supported.
+	FrameworkParity bool `yaml:"plugin_framework_parity,omitempty"`
+
 	// The three groups of []*Type fields are expected to be strictly ordered within a yaml file
 	// in the sequence of Virtual Fields -> Parameters -> Properties
 
@@ -420,7 +465,7 @@ func (r *Resource) UnmarshalYAML(value *yaml.Node) error {
 	type resourceAlias Resource
 	aliasObj := (*resourceAlias)(r)
 
-	err := value.Decode(aliasObj)
+	err := google.DecodeKnownFields(value, aliasObj)
 	if err != nil {
 		return err
 	}
@@ -478,13 +523,16 @@ func (r *Resource) SetDefault(product *Product) {
 
 }
 
-func (r *Resource) Validate() {
+// Validate checks the resource configuration. The fields of the returned
+// errors are relative to the root of the resource's YAML file.
+func (r *Resource) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
 	if r.Name == "" {
-		log.Fatalf("Missing `name` for resource")
+		errs.Addf("name", "missing `name` for resource")
 	}
 
 	if r.NestedQuery != nil && r.NestedQuery.IsListOfIds && len(r.Identity) != 1 {
-		log.Fatalf("`is_list_of_ids: true` implies resource has exactly one `identity` property")
+		errs.Addf("identity", "`is_list_of_ids: true` implies resource has exactly one `identity` property")
 	}
 
 	// Ensures we have all properties defined
@@ -493,63 +541,152 @@ func (r *Resource) Validate() {
 			return p.Name == i
 		})
 		if !hasIdentify {
-			log.Fatalf("Missing property/parameter for identity %s", i)
+			errs.Addf("identity", "missing property/parameter for identity %s", i)
 		}
 	}
 
 	if r.Description == "" {
-		log.Fatalf("Missing `description` for resource %s", r.Name)
+		errs.Addf("description", "missing `description` for resource %s", r.Name)
 	}
 
 	if !r.Exclude {
 		if len(r.Properties) == 0 {
-			log.Fatalf("Missing `properties` for resource %s", r.Name)
+			errs.Addf("properties", "missing `properties` for resource %s", r.Name)
 		}
 	}
 
 	allowed := []string{"POST", "PUT", "PATCH"}
 	if !slices.Contains(allowed, r.CreateVerb) {
-		log.Fatalf("Value on `create_verb` should be one of %#v", allowed)
+		errs.Addf("create_verb", "value on `create_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"GET", "POST"}
 	if !slices.Contains(allowed, r.ReadVerb) {
-		log.Fatalf("Value on `read_verb` should be one of %#v", allowed)
+		errs.Addf("read_verb", "value on `read_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"POST", "PUT", "PATCH", "DELETE"}
 	if !slices.Contains(allowed, r.DeleteVerb) {
-		log.Fatalf("Value on `delete_verb` should be one of %#v", allowed)
+		errs.Addf("delete_verb", "value on `delete_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"POST", "PUT", "PATCH"}
 	if !slices.Contains(allowed, r.UpdateVerb) {
-		log.Fatalf("Value on `update_verb` should be one of %#v", allowed)
+		errs.Addf("update_verb", "value on `update_verb` should be one of %#v", allowed)
 	}
 
 	for _, property := range r.AllProperties() {
-		property.Validate(r.Name)
+		errs.Extend("", property.Validate())
 	}
 
 	if r.IamPolicy != nil {
-		r.IamPolicy.Validate(r.Name)
+		errs.Extend("iam_policy", r.IamPolicy.Validate())
 	}
 
 	if r.NestedQuery != nil {
-		r.NestedQuery.Validate(r.Name)
+		errs.Extend("nested_query", r.NestedQuery.Validate())
+	}
+
+	if r.Batching != nil {
+		errs.Extend("batching", r.Batching.Validate())
+	}
+
+	if r.VcrMatcher != nil {
+		errs.Extend("vcr_matcher", r.VcrMatcher.Validate())
+	}
+
+	errs.Extend("", r.validateStateUpgrades())
+
+	if r.Mutex != "" && len(r.Locks) > 0 {
+		errs.Addf("locks", "`mutex` and `locks` can't both be set")
+	}
+	for _, lock := range r.Locks {
+		errs.Extend(google.ItemField("locks", lock.Key), lock.Validate())
+	}
+	// Create and update read the resource while holding their locks, and
+	// MutexKV locks aren't reentrant.
+	for _, lock := range r.LocksForAction("read") {
+		for _, action := range []string{"create", "update"} {
+			for _, other := range r.LocksForAction(action) {
+				if other.Key == lock.Key {
+					errs.Addf(google.ItemField("locks", lock.Key), "key can't be locked by both read and %s", action)
+				}
+			}
+		}
 	}
 
 	for _, example := range r.Examples {
-		example.Validate(r.Name)
+		errs.Extend(google.ItemField("examples", example.Name), example.Validate())
 	}
 
 	for _, sample := range r.Samples {
-		sample.Validate(r.Name)
+		errs.Extend(google.ItemField("samples", sample.Name), sample.Validate())
 	}
 
 	if r.Async != nil {
-		r.Async.Validate()
+		errs.Extend("async", r.Async.Validate())
+		// Resumed creates only wait for the operation and read the resource.
+		if r.Async.Resumable {
+			if r.CustomCode.CustomCreate != "" {
+				errs.Addf("async.resumable", "`resumable` can't be used with `custom_code.custom_create`")
+			}
+			if r.CustomCode.PostCreate != "" {
+				errs.Addf("async.resumable", "`resumable` can't be used with `custom_code.post_create`")
+			}
+			if r.HasPostCreateComputedFields() {
+				errs.Addf("async.resumable", "`resumable` can't be used when the id is computed by the API")
+			}
+			if slices.ContainsFunc(r.AllUserProperties(), func(p *Type) bool { return google.Underscore(p.Name) == "operation" }) {
+				errs.Addf("async.resumable", "`resumable` adds an `operation` field, which is already a property")
+			}
+		}
+	}
+
+	// Requests are built from the plan, which encoders and handwritten
+	// create code can't read.
+	if r.SupportsValidateOnly {
+		if r.CustomCode.Encoder != "" {
+			errs.Addf("supports_validate_only", "`supports_validate_only` can't be used with `custom_code.encoder`")
+		}
+		if r.CustomCode.CustomCreate != "" {
+			errs.Addf("supports_validate_only", "`supports_validate_only` can't be used with `custom_code.custom_create`")
+		}
+		if r.CustomCode.PreCreate != "" {
+			errs.Addf("supports_validate_only", "`supports_validate_only` can't be used with `custom_code.pre_create`")
+		}
+		if r.NestedQuery != nil {
+			errs.Addf("supports_validate_only", "`supports_validate_only` can't be used with `nested_query`")
+		}
+		if r.BatchesAction("create") {
+			errs.Addf("supports_validate_only", "`supports_validate_only` can't be used with batched creates")
+		}
+	}
+
+	if r.ListResource != nil {
+		errs.Extend("list_resource", r.ListResource.Validate())
+		if r.ListResource.Generate && r.NestedQuery != nil {
+			errs.Addf("list_resource", "`list_resource` is not supported alongside `nested_query`")
+		}
+		if r.ListResource.Generate && r.ExcludeRead {
+			errs.Addf("list_resource", "`list_resource` requires a readable resource, but `exclude_read` is set")
+		}
+	}
+
+	if r.ShouldGenerateFrameworkResource() {
+		errs.Extend("", r.validateFrameworkResource())
 	}
+
+	if r.Ephemeral != nil {
+		errs.Extend("ephemeral", r.Ephemeral.Validate())
+		if r.Ephemeral.Generate {
+			for _, p := range r.AllUserProperties() {
+				if !p.IsFWPrimitiveOrCollection() {
+					errs.Addf(p.yamlPath(), "`ephemeral` only supports primitive, list of primitive and string map fields, but %s is a %s", p.Name, p.Type)
+				}
+			}
+		}
+	}
+	return errs
 }
 
 // ====================
@@ -1131,6 +1268,57 @@ func (r Resource) UpdateBodyProperties() []*Type {
 	return updateProp
 }
 
+// ValidatesUpdateOnPlan returns whether the resource sends its planned
+// updates with `validateOnly`, besides its creates. Only the update request
+// of the resource's body is validated, without field-specific updates.
+func (r Resource) ValidatesUpdateOnPlan() bool {
+	return r.SupportsValidateOnly && r.Updatable() && !r.Immutable &&
+		r.CustomCode.CustomUpdate == "" && r.CustomCode.PreUpdate == "" && r.CustomCode.UpdateEncoder == ""
+}
+
+// ValidateOnlyFields returns the top-level fields the planned requests sent
+// with `validateOnly` are built from, which have to be known during plan.
+func (r Resource) ValidateOnlyFields() []string {
+	fields := topLevelFields(r.SettableProperties(), nil)
+	fields = append(fields, r.ExtractIdentifiers(r.CreateUri())...)
+	if r.ValidatesUpdateOnPlan() {
+		fields = append(fields, r.ExtractIdentifiers(r.UpdateUri())...)
+	}
+	slices.Sort(fields)
+	return slices.Compact(fields)
+}
+
+// ValidateOnlyUpdateFields returns the top-level fields whose changes are
+// sent with `validateOnly` in planned updates.
+func (r Resource) ValidateOnlyUpdateFields() []string {
+	return topLevelFields(r.UpdateBodyProperties(), nil)
+}
+
+// ValidateOnlyReplaceFields returns the top-level fields whose changes
+// replace the resource, so its planned update isn't validated.
+func (r Resource) ValidateOnlyReplaceFields() []string {
+	return topLevelFields(r.SettableProperties(), func(p *Type) bool {
+		return p.Immutable
+	})
+}
+
+// topLevelFields returns the schema fields of properties, which are the
+// fields of flattened objects for them, keeping the ones matching keep if
+// it's set.
+func topLevelFields(props []*Type, keep func(*Type) bool) []string {
+	var fields []string
+	for _, p := range props {
+		if p.FlattenObject {
+			fields = append(fields, topLevelFields(p.Properties, keep)...)
+			continue
+		}
+		if keep == nil || keep(p) {
+			fields = append(fields, google.Underscore(p.Name))
+		}
+	}
+	return fields
+}
+
 // Handwritten TF Operation objects will be shaped like accessContextManager
 // while the Google Go Client will have a name like accesscontextmanager
 func (r Resource) ClientNamePascal() string {
@@ -1892,6 +2080,20 @@ func (r Resource) DeleteUrlTemplate() string {
 	return fmt.Sprintf("%s%s", r.ProductMetadata.BaseUrl, r.DeleteUri())
 }
 
+// BatchesAction returns whether the requests of an action, create or delete,
+// are batched.
+func (r Resource) BatchesAction(action string) bool {
+	return r.Batching != nil && slices.Contains(r.Batching.Actions, action)
+}
+
+// LocksForAction returns the locks held by an action, among create, read,
+// update and delete.
+func (r Resource) LocksForAction(action string) []*resource.Lock {
+	return google.Select(r.Locks, func(l *resource.Lock) bool {
+		return slices.Contains(l.Actions, action)
+	})
+}
+
 func (r Resource) LastNestedQueryKey() string {
 	if r.NestedQuery == nil {
 		return ""
@@ -2004,6 +2206,33 @@ func (r Resource) TestSamples() []*resource.Sample {
 	})
 }
 
+// StateUpgradeTestExample returns the example used to test that state from older provider
+// releases upgrades cleanly, or nil if the resource has no state upgraders to test.
+func (r Resource) StateUpgradeTestExample() *resource.Examples {
+	if r.SchemaVersion == 0 {
+		return nil
+	}
+	for _, e := range r.TestExamples() {
+		if e.SkipTest == "" && len(e.ExternalProviders) == 0 {
+			return e
+		}
+	}
+	return nil
+}
+
+// StateUpgradeTestSample is StateUpgradeTestExample for resources defining samples.
+func (r Resource) StateUpgradeTestSample() *resource.Sample {
+	if r.SchemaVersion == 0 {
+		return nil
+	}
+	for _, s := range r.TestSamples() {
+		if s.SkipTest == "" && len(s.ExternalProviders) == 0 && len(s.TestSteps()) > 0 {
+			return s
+		}
+	}
+	return nil
+}
+
 func (r Resource) TestSampleSetUp() {
 	res := make(map[string]string)
 	for _, sample := range r.Samples {
@@ -2373,6 +2602,173 @@ func (r Resource) DatasourceOptionalFields() []string {
 	return optionalFields
 }
 
+func (r Resource) ShouldGenerateListResource() bool {
+	if r.ListResource == nil {
+		return false
+	}
+
+	return r.ListResource.Generate
+}
+
+// ListResourceParameters returns the URL parameters of the collection URL,
+// which make up the arguments of a generated list resource. For example, for
+// the base_url "projects/{{project}}/locations/{{location}}/instances" the
+// parameters are "project", "location".
+func (r Resource) ListResourceParameters() []string {
+	return r.ExtractIdentifiers(strings.Split(r.BaseUrl, "?")[0])
+}
+
+// IdentityParameters returns the URL parameters of the resource id format,
+// which make up the resource identity schema of resources with a list resource.
+func (r Resource) IdentityParameters() []string {
+	return deduplicateSliceOfStrings(r.ExtractIdentifiers(r.GetIdFormat()))
+}
+
+// IsOptionalIdentityParameter returns whether an identity parameter can be
+// defaulted from the provider configuration when importing by identity.
+func (r Resource) IsOptionalIdentityParameter(param string) bool {
+	return param == "project" || param == "region" || param == "zone"
+}
+
+func (r Resource) ShouldGenerateEphemeralResource() bool {
+	if r.Ephemeral == nil {
+		return false
+	}
+
+	return r.Ephemeral.Generate
+}
+
+// ShouldGenerateFrameworkResource returns whether a plugin framework
+// implementation of the resource is generated.
+func (r Resource) ShouldGenerateFrameworkResource() bool {
+	return r.FrameworkResource || r.FrameworkParity
+}
+
+// FWFields returns the top-level fields of the framework resource, which are
+// the fields of its model.
+func (r Resource) FWFields() []*Type {
+	return google.Concat(r.OrderProperties(r.AllUserProperties()), r.UserVirtualFields())
+}
+
+// FWAttributes returns the top-level fields rendered as attributes in the
+// framework resource schema.
+func (r Resource) FWAttributes() []*Type {
+	return google.Reject(r.FWFields(), func(p *Type) bool {
+		return p.IsFWBlock()
+	})
+}
+
+// FWBlocks returns the top-level fields rendered as nested blocks in the
+// framework resource schema.
+func (r Resource) FWBlocks() []*Type {
+	return google.Select(r.FWFields(), func(p *Type) bool {
+		return p.IsFWBlock()
+	})
+}
+
+// FWApiFields returns every non-virtual field of the framework resource,
+// including nested ones, which are described by its fwresource.ApiFields.
+func (r Resource) FWApiFields() []*Type {
+	return r.AllNestedProperties(r.AllUserProperties())
+}
+
+// validateFrameworkResource checks that the resource only uses features that
+// the plugin framework resource template supports.
+func (r Resource) validateFrameworkResource() google.ValidationErrors {
+	var errs google.ValidationErrors
+	if r.FrameworkResource && r.FrameworkParity {
+		errs.Addf("plugin_framework_parity", "`plugin_framework` and `plugin_framework_parity` cannot both be set")
+	}
+	if r.NestedQuery != nil {
+		errs.Addf("nested_query", "`nested_query` is not supported by plugin framework resources, but is set")
+	}
+	if r.MigrateState != "" {
+		errs.Addf("migrate_state", "`migrate_state` is not supported by plugin framework resources, use `state_upgraders` instead")
+	}
+	if r.FieldSpecificUpdateMethods() {
+		errs.Addf("properties", "field-specific `update_url` is not supported by plugin framework resources, but is used")
+	}
+	if r.Async != nil && r.Async.Resumable {
+		errs.Addf("async.resumable", "`resumable` is not supported by plugin framework resources, but is set")
+	}
+	if r.FrameworkResource {
+		if r.SupportsValidateOnly {
+			errs.Addf("supports_validate_only", "`supports_validate_only` is not supported alongside `plugin_framework`")
+		}
+		if r.ShouldGenerateSingularDataSource() {
+			errs.Addf("datasource", "`datasource` is not supported alongside `plugin_framework`")
+		}
+		if r.ShouldGenerateListResource() {
+			errs.Addf("list_resource", "`list_resource` is not supported alongside `plugin_framework`")
+		}
+	}
+	if r.FrameworkParity && !utils.IsEmpty(r.CustomCode) {
+		cc := r.CustomCode
+		cc.TestCheckDestroy = ""
+		if !utils.IsEmpty(cc) {
+			errs.Addf("custom_code", "`custom_code` other than `test_check_destroy` is not supported alongside `plugin_framework_parity`")
+		}
+	}
+
+	if r.FrameworkResource {
+		for _, cdiff := range r.CustomDiff {
+			if !slices.Contains([]string{"tpgresource.SetLabelsDiff", "tpgresource.SetLabelsDiffWithoutAttributionLabel", "tpgresource.SetAnnotationsDiff"}, cdiff) {
+				errs.Addf("custom_diff", "`custom_diff` is not supported by plugin framework resources, but %s is set", cdiff)
+			}
+		}
+		if len(r.UnorderedListProperties()) > 0 {
+			errs.Addf("properties", "`unordered_list` is not supported by plugin framework resources, but is set")
+		}
+		if r.CustomCode.ValidateRawResourceConfigFuncs != "" {
+			errs.Addf("custom_code.raw_resource_config_validation", "`raw_resource_config_validation` is not supported by plugin framework resources, but is set")
+		}
+		if r.CustomCode.ExtraSchemaEntry != "" {
+			errs.Addf("custom_code.extra_schema_entry", "`extra_schema_entry` is not supported by plugin framework resources, but is set")
+		}
+	}
+
+	for _, p := range r.AllNestedProperties(google.Concat(r.AllUserProperties(), r.VirtualFields)) {
+		errs.Extend("", p.validateFrameworkField(r.FrameworkResource))
+	}
+	return errs
+}
+
+// EphemeralOpenUrl returns the URL called to open the ephemeral resource,
+// relative to the product base URL.
+func (r Resource) EphemeralOpenUrl() string {
+	if r.Ephemeral != nil && r.Ephemeral.OpenUrl != "" {
+		return r.Ephemeral.OpenUrl
+	}
+	return r.SelfLinkUri()
+}
+
+// EphemeralArguments returns the fields that are configurable on the
+// ephemeral resource.
+func (r Resource) EphemeralArguments() []*Type {
+	return google.Reject(r.AllUserProperties(), func(p *Type) bool {
+		return p.Output
+	})
+}
+
+// EphemeralAttributes returns the fields that are only computed on the
+// ephemeral resource.
+func (r Resource) EphemeralAttributes() []*Type {
+	return google.Select(r.AllUserProperties(), func(p *Type) bool {
+		return p.Output
+	})
+}
+
+// EphemeralBodyProperties returns the arguments that are sent in the body of
+// the request opening the ephemeral resource.
+func (r Resource) EphemeralBodyProperties() []*Type {
+	if r.Ephemeral == nil || !r.Ephemeral.SendsBody() {
+		return nil
+	}
+	return google.Reject(r.EphemeralArguments(), func(p *Type) bool {
+		return p.UrlParamOnly
+	})
+}
+
 func (r Resource) ShouldGenerateSweepers() bool {
 	if !r.ExcludeSweeper && !utils.IsEmpty(r.Sweeper) {
 		return true
@@ -0,0 +1,114 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"gopkg.in/yaml.v3"
+)
+
+// Metadata for resources whose create and delete requests are batched
+// together, for resources that are commonly created in large numbers and run
+// into quota limits otherwise.
+//
+// Requests are sent through the transport.RequestBatcher of the product,
+// which is configured by the `batching` block of the provider. Requests with
+// the same key that are made within `send_after` of each other are combined
+// and sent by a single call to the send function.
+type Batching struct {
+	// The template of the key grouping requests into a batch, using the same
+	// variables as URLs. Requests for different actions are never batched
+	// together.
+	// e.g. "projects/{{project}}/managedZones/{{managed_zone}}/rrsets"
+	KeyTemplate string `yaml:"key_template"`
+
+	// How the requests of a batch are combined before being passed to the
+	// send function, as a []transport_tpg.SendRequestOptions:
+	//   - append: all requests are kept
+	//   - dedupe: identical requests are only kept once
+	//   - custom: requests are combined by `combine_function`
+	CombineStrategy string `yaml:"combine_strategy,omitempty"`
+
+	// The transport_tpg.BatcherCombineFunc combining requests when
+	// `combine_strategy` is custom.
+	CombineFunction string `yaml:"combine_function,omitempty"`
+
+	// The transport_tpg.BatchedRequestsSendFunc sending a batch of requests,
+	// such as transport_tpg.SendBatchedRequestsInOrder for APIs without a
+	// batch method, or a function included in the resource's constants. It's
+	// called with the expanded key and the combined requests, and returns the
+	// response of each request, such as an operation to wait for.
+	SendFunction string `yaml:"send_function"`
+
+	// The actions whose requests are batched, defaults to create and delete.
+	Actions []string `yaml:"actions,omitempty"`
+}
+
+func (b *Batching) UnmarshalYAML(value *yaml.Node) error {
+	b.CombineStrategy = "append"
+	b.Actions = []string{"create", "delete"}
+
+	type batchingAlias Batching
+	aliasObj := (*batchingAlias)(b)
+
+	err := google.DecodeKnownFields(value, aliasObj)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (b *Batching) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
+	if b.KeyTemplate == "" {
+		errs.Addf("key_template", "missing `key_template` for `batching`")
+	}
+	if b.SendFunction == "" {
+		errs.Addf("send_function", "missing `send_function` for `batching`")
+	}
+
+	allowed := []string{"append", "dedupe", "custom"}
+	if !slices.Contains(allowed, b.CombineStrategy) {
+		errs.Addf("combine_strategy", "value on `combine_strategy` should be one of %#v", allowed)
+	}
+	if b.CombineStrategy == "custom" && b.CombineFunction == "" {
+		errs.Addf("combine_function", "missing `combine_function` for custom `combine_strategy`")
+	}
+	if b.CombineStrategy != "custom" && b.CombineFunction != "" {
+		errs.Addf("combine_function", "`combine_function` can only be set with custom `combine_strategy`")
+	}
+
+	allowed = []string{"create", "delete"}
+	for _, action := range b.Actions {
+		if !slices.Contains(allowed, action) {
+			errs.Addf("actions", "value on `actions` should be one of %#v", allowed)
+		}
+	}
+	return errs
+}
+
+// CombineFunc returns the transport_tpg.BatcherCombineFunc combining requests.
+func (b *Batching) CombineFunc() string {
+	switch b.CombineStrategy {
+	case "custom":
+		return b.CombineFunction
+	case "dedupe":
+		return "transport_tpg.DedupeBatchedRequests"
+	default:
+		return "transport_tpg.AppendBatchedRequests"
+	}
+}
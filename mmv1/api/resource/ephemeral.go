@@ -0,0 +1,118 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+var ephemeralVerbs = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// Ephemeral configures generation of a plugin-framework ephemeral resource.
+// Ephemeral resources are opened on every Terraform run and are never
+// persisted to plan or state, which makes them suitable for read-only APIs
+// that return credentials or other secret material.
+//
+// The non-output properties and parameters of the resource become the
+// arguments of the ephemeral resource and its output properties become its
+// computed attributes.
+type Ephemeral struct {
+	// boolean to determine whether the ephemeral resource file should be generated
+	Generate bool `yaml:"generate"`
+
+	// URL called when the ephemeral resource is opened, relative to the
+	// product base URL. Defaults to the self_link of the resource.
+	OpenUrl string `yaml:"open_url,omitempty"`
+
+	// HTTP verb used to open the ephemeral resource. Defaults to GET. For
+	// POST, PUT and PATCH the settable properties are sent as the request body.
+	OpenVerb string `yaml:"open_verb,omitempty"`
+
+	// URL called to extend the validity of an opened ephemeral resource. It may
+	// reference arguments and output attributes of the ephemeral resource.
+	// Must be set together with `expire_time_field`.
+	RenewUrl string `yaml:"renew_url,omitempty"`
+
+	// HTTP verb used to renew the ephemeral resource. Defaults to POST.
+	RenewVerb string `yaml:"renew_verb,omitempty"`
+
+	// URL called when Terraform is done with the ephemeral resource, such as
+	// an endpoint revoking an issued credential. It may reference arguments and
+	// output attributes of the ephemeral resource.
+	CloseUrl string `yaml:"close_url,omitempty"`
+
+	// HTTP verb used to close the ephemeral resource. Defaults to DELETE.
+	CloseVerb string `yaml:"close_verb,omitempty"`
+
+	// Path to a custom code snippet inserted before the open request is sent.
+	// It can modify the request body `obj`, a map keyed by API field names,
+	// and report errors through `resp.Diagnostics`. The custom_expand of
+	// individual fields is not applied to ephemeral resources.
+	Encoder string `yaml:"encoder,omitempty"`
+
+	// Path to a custom code snippet inserted after the open response is
+	// received. It can modify the response `res` before it is flattened. The
+	// custom_flatten of individual fields is not applied to ephemeral resources.
+	Decoder string `yaml:"decoder,omitempty"`
+
+	// API name of a top-level RFC 3339 timestamp in the open and renew
+	// responses that marks when the returned value expires. The ephemeral
+	// resource is renewed shortly before that time.
+	ExpireTimeField string `yaml:"expire_time_field,omitempty"`
+}
+
+func (e *Ephemeral) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
+	if (e.RenewUrl == "") != (e.ExpireTimeField == "") {
+		errs.Addf("renew_url", "`renew_url` and `expire_time_field` for `ephemeral` must be set together")
+	}
+
+	verbs := map[string]string{"open_verb": e.OpenVerb, "renew_verb": e.RenewVerb, "close_verb": e.CloseVerb}
+	for _, field := range []string{"open_verb", "renew_verb", "close_verb"} {
+		if verb := verbs[field]; verb != "" && !slices.Contains(ephemeralVerbs, verb) {
+			errs.Addf(field, "invalid verb %q for `ephemeral`, must be one of %v", verb, ephemeralVerbs)
+		}
+	}
+	return errs
+}
+
+func (e *Ephemeral) GetOpenVerb() string {
+	if e.OpenVerb == "" {
+		return "GET"
+	}
+	return e.OpenVerb
+}
+
+func (e *Ephemeral) GetRenewVerb() string {
+	if e.RenewVerb == "" {
+		return "POST"
+	}
+	return e.RenewVerb
+}
+
+func (e *Ephemeral) GetCloseVerb() string {
+	if e.CloseVerb == "" {
+		return "DELETE"
+	}
+	return e.CloseVerb
+}
+
+// SendsBody returns whether the settable properties are sent as the body of
+// the open request.
+func (e *Ephemeral) SendsBody() bool {
+	verb := e.GetOpenVerb()
+	return verb == "POST" || verb == "PUT" || verb == "PATCH"
+}
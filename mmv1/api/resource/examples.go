@@ -16,7 +16,6 @@ package resource
 import (
 	"bytes"
 	"fmt"
-	"log"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -184,7 +183,7 @@ func (e *Examples) UnmarshalYAML(value *yaml.Node) error {
 	type exampleAlias Examples
 	aliasObj := (*exampleAlias)(e)
 
-	err := value.Decode(aliasObj)
+	err := google.DecodeKnownFields(value, aliasObj)
 	if err != nil {
 		return err
 	}
@@ -232,14 +231,16 @@ func DefaultConfigPath(name string) string {
 	return fmt.Sprintf("templates/terraform/examples/%s.tf.tmpl", name)
 }
 
-func (e *Examples) Validate(rName string) {
+func (e *Examples) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
 	if e.Name == "" {
-		log.Fatalf("Missing `name` for one example in resource %s", rName)
+		errs.Addf("", "missing `name` for one example")
 	}
-	e.ValidateExternalProviders()
+	errs.Extend("", e.ValidateExternalProviders())
+	return errs
 }
 
-func (e *Examples) ValidateExternalProviders() {
+func (e *Examples) ValidateExternalProviders() google.ValidationErrors {
 	// Official providers supported by HashiCorp
 	// https://registry.terraform.io/search/providers?namespace=hashicorp&tier=official
 	HASHICORP_PROVIDERS := []string{"aws", "random", "null", "template", "azurerm", "kubernetes", "local",
@@ -254,9 +255,11 @@ func (e *Examples) ValidateExternalProviders() {
 		}
 	}
 
+	var errs google.ValidationErrors
 	if len(unallowedProviders) > 0 {
-		log.Fatalf("Providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders)
+		errs.Addf("external_providers", "providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders)
 	}
+	return errs
 }
 
 // Executes example templates for documentation and tests
@@ -14,10 +14,11 @@
 package resource
 
 import (
-	"log"
 	"slices"
 
 	"gopkg.in/yaml.v3"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
 // Information about the IAM policy for this resource
@@ -136,7 +137,7 @@ func (p *IamPolicy) UnmarshalYAML(value *yaml.Node) error {
 	type iamPolicyAlias IamPolicy
 	aliasObj := (*iamPolicyAlias)(p)
 
-	err := value.Decode(aliasObj)
+	err := google.DecodeKnownFields(value, aliasObj)
 	if err != nil {
 		return err
 	}
@@ -144,19 +145,21 @@ func (p *IamPolicy) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
-func (p *IamPolicy) Validate(rName string) {
+func (p *IamPolicy) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
 	allowed := []string{"GET", "POST"}
 	if !slices.Contains(allowed, p.FetchIamPolicyVerb) {
-		log.Fatalf("Value on `fetch_iam_policy_verb` should be one of %#v in resource %s", allowed, rName)
+		errs.Addf("fetch_iam_policy_verb", "value on `fetch_iam_policy_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"POST", "PUT"}
 	if !slices.Contains(allowed, p.SetIamPolicyVerb) {
-		log.Fatalf("Value on `set_iam_policy_verb` should be one of %#v in resource %s", allowed, rName)
+		errs.Addf("set_iam_policy_verb", "value on `set_iam_policy_verb` should be one of %#v", allowed)
 	}
 
 	allowed = []string{"REQUEST_BODY", "QUERY_PARAM", "QUERY_PARAM_NESTED"}
 	if p.IamConditionsRequestType != "" && !slices.Contains(allowed, p.IamConditionsRequestType) {
-		log.Fatalf("Value on `iam_conditions_request_type` should be one of %#v in resource %s", allowed, rName)
+		errs.Addf("iam_conditions_request_type", "value on `iam_conditions_request_type` should be one of %#v", allowed)
 	}
+	return errs
 }
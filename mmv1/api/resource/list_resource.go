@@ -0,0 +1,45 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import "github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+
+// ListResource configures generation of a plugin-framework list resource,
+// which lets `terraform query` enumerate existing instances of a resource
+// by paging over its collection URL.
+//
+// Enabling a list resource also adds a resource identity schema to the
+// generated SDKv2 resource, built from the parameters of its id_format, since
+// every result returned by a list resource must carry an identity.
+type ListResource struct {
+	// boolean to determine whether the list resource file should be generated
+	Generate bool `yaml:"generate"`
+
+	// FilterParam is the name of the List API query parameter used for
+	// server-side filtering, e.g. "filter". When set, the list resource
+	// exposes an optional `filter` argument that is passed through unchanged.
+	FilterParam string `yaml:"filter_param,omitempty"`
+
+	// PageSize is the number of results requested per List API call. When
+	// unset, the API default page size is used.
+	PageSize int `yaml:"page_size,omitempty"`
+}
+
+func (l *ListResource) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
+	if l.PageSize < 0 {
+		errs.Addf("page_size", "`page_size` for `list_resource` must not be negative")
+	}
+	return errs
+}
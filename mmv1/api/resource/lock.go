@@ -0,0 +1,83 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"gopkg.in/yaml.v3"
+)
+
+// A lock of transport_tpg.MutexStore held by the generated code of a
+// resource, to serialize API calls that conflict with each other, such as
+// changes to the same parent resource.
+//
+// All the locks of an action are acquired at once in a consistent order, so
+// resources locking overlapping keys don't deadlock.
+type Lock struct {
+	// The template of the lock key, using the same variables as URLs.
+	// e.g. "projects/{{project}}/global/networks/{{network}}"
+	Key string `yaml:"key"`
+
+	// Whether the lock is exclusive, or shared with other shared locks of the
+	// same key. Shared locks are only serialized with exclusive locks, such as
+	// the ones of the parent resource. Defaults to exclusive.
+	Mode string `yaml:"mode,omitempty"`
+
+	// The actions the lock is held for, among create, read, update and
+	// delete. Defaults to create, update and delete.
+	Actions []string `yaml:"actions,omitempty"`
+}
+
+func (l *Lock) UnmarshalYAML(value *yaml.Node) error {
+	l.Mode = "exclusive"
+	l.Actions = []string{"create", "update", "delete"}
+
+	type lockAlias Lock
+	aliasObj := (*lockAlias)(l)
+
+	err := google.DecodeKnownFields(value, aliasObj)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (l *Lock) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
+	if l.Key == "" {
+		errs.Addf("key", "missing `key` for lock")
+	}
+
+	allowed := []string{"exclusive", "shared"}
+	if !slices.Contains(allowed, l.Mode) {
+		errs.Addf("mode", "value on `mode` should be one of %#v", allowed)
+	}
+
+	allowed = []string{"create", "read", "update", "delete"}
+	for _, action := range l.Actions {
+		if !slices.Contains(allowed, action) {
+			errs.Addf("actions", "value on `actions` should be one of %#v", allowed)
+		}
+	}
+	return errs
+}
+
+// IsShared returns whether the lock is shared with other shared locks of the
+// same key.
+func (l *Lock) IsShared() bool {
+	return l.Mode == "shared"
+}
@@ -15,7 +15,6 @@ package resource
 
 import (
 	"fmt"
-	"log"
 	"slices"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
@@ -128,18 +127,20 @@ func (s *Sample) ResourceType(terraformName string) string {
 	return terraformName
 }
 
-func (s *Sample) Validate(rName string) {
+func (s *Sample) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
 	if s.Name == "" {
-		log.Fatalf("Missing `name` for one sample in resource %s", rName)
+		errs.Addf("", "missing `name` for one sample")
 	}
-	s.ValidateExternalProviders()
+	errs.Extend("", s.ValidateExternalProviders())
 
 	for _, step := range s.Steps {
-		step.Validate(rName, s.Name)
+		errs.Extend(google.ItemField("steps", step.Name), step.Validate())
 	}
+	return errs
 }
 
-func (s *Sample) ValidateExternalProviders() {
+func (s *Sample) ValidateExternalProviders() google.ValidationErrors {
 	// Official providers supported by HashiCorp
 	// https://registry.terraform.io/search/providers?namespace=hashicorp&tier=official
 	HASHICORP_PROVIDERS := []string{"aws", "random", "null", "template", "azurerm", "kubernetes", "local",
@@ -154,7 +155,9 @@ func (s *Sample) ValidateExternalProviders() {
 		}
 	}
 
+	var errs google.ValidationErrors
 	if len(unallowedProviders) > 0 {
-		log.Fatalf("Providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders)
+		errs.Addf("external_providers", "providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders)
 	}
+	return errs
 }
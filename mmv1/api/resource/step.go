@@ -130,12 +130,13 @@ func (s *Step) TestStepSlug(productName, resourceName string) string {
 	return ret
 }
 
-func (s *Step) Validate(rName, sName string) {
+func (s *Step) Validate() google.ValidationErrors {
 	// TODO: Add check identifier when it's implemented
+	var errs google.ValidationErrors
 	if s.Name == "" {
-		log.Fatalf("Missing `name` for one step in test sample %s in resource %s", sName, rName)
+		errs.Addf("", "missing `name` for one step")
 	}
-
+	return errs
 }
 
 func validateRegexForContents(r *regexp.Regexp, contents string, configPath string, objName string, vars map[string]string) {
@@ -0,0 +1,70 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// Rules for matching the requests of the resource's generated tests with the
+// interactions recorded in their VCR cassettes, for requests containing values
+// that change between runs. They're passed to acctest.SetVcrMatchConfig.
+//
+// Paths are JSON pointers into request bodies, where a `*` matches any object
+// key or array index.
+// e.g. "/rules/*/createTime"
+type VcrMatcher struct {
+	// Fields of request bodies that are ignored, such as generated request IDs
+	// or timestamps.
+	IgnorePaths []string `yaml:"ignore_paths,omitempty"`
+
+	// Repeated fields of request bodies compared as sets.
+	UnorderedPaths []string `yaml:"unordered_paths,omitempty"`
+
+	// Query parameters that are ignored. The order of query parameters is
+	// always ignored.
+	IgnoreQueryParams []string `yaml:"ignore_query_params,omitempty"`
+
+	// Fields of request and response bodies that are redacted when recording,
+	// such as secrets. They're ignored when matching.
+	RedactPaths []string `yaml:"redact_paths,omitempty"`
+
+	// Request and response headers that are redacted when recording.
+	RedactHeaders []string `yaml:"redact_headers,omitempty"`
+}
+
+func (m *VcrMatcher) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
+	validatePaths := func(field string, paths []string) {
+		for _, p := range paths {
+			if p != "" && !strings.HasPrefix(p, "/") {
+				errs.Addf(field, "value %q on `%s` should be a JSON pointer starting with \"/\"", p, field)
+			}
+		}
+	}
+	validatePaths("ignore_paths", m.IgnorePaths)
+	validatePaths("unordered_paths", m.UnorderedPaths)
+	validatePaths("redact_paths", m.RedactPaths)
+
+	if slices.Contains(m.IgnoreQueryParams, "") {
+		errs.Addf("ignore_query_params", "values on `ignore_query_params` should not be empty")
+	}
+	if slices.Contains(m.RedactHeaders, "") {
+		errs.Addf("redact_headers", "values on `redact_headers` should not be empty")
+	}
+	return errs
+}
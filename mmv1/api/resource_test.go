@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -9,6 +10,9 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"gopkg.in/yaml.v3"
 )
 
 func TestResourceMinVersionObj(t *testing.T) {
@@ -203,6 +207,195 @@ func TestResourceServiceVersion(t *testing.T) {
 	}
 }
 
+func TestResourceListResourceParameters(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Resource
+		expected    []string
+	}{
+		{
+			description: "project-level collection",
+			obj: Resource{
+				BaseUrl: "projects/{{project}}/topics",
+			},
+			expected: []string{"project"},
+		},
+		{
+			description: "location-level collection",
+			obj: Resource{
+				BaseUrl: "projects/{{project}}/locations/{{location}}/instances",
+			},
+			expected: []string{"project", "location"},
+		},
+		{
+			description: "collection with query parameters",
+			obj: Resource{
+				BaseUrl: "projects/{{project}}/locations/{{location}}/instances?instanceId={{name}}",
+			},
+			expected: []string{"project", "location"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := tc.obj.ListResourceParameters(), tc.expected; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected %v to be %v", got, want)
+			}
+		})
+	}
+}
+
+func TestResourceIdentityParameters(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Resource
+		expected    []string
+	}{
+		{
+			description: "id_format is set",
+			obj: Resource{
+				IdFormat: "projects/{{project}}/locations/{{location}}/instances/{{name}}",
+			},
+			expected: []string{"project", "location", "name"},
+		},
+		{
+			description: "id_format falls back to self_link",
+			obj: Resource{
+				SelfLink: "projects/{{project}}/topics/{{name}}",
+			},
+			expected: []string{"project", "name"},
+		},
+		{
+			description: "repeated parameters are deduplicated",
+			obj: Resource{
+				IdFormat: "{{project}}/{{name}}/{{project}}",
+			},
+			expected: []string{"project", "name"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := tc.obj.IdentityParameters(), tc.expected; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected %v to be %v", got, want)
+			}
+		})
+	}
+}
+
+func TestResourceEphemeralBodyProperties(t *testing.T) {
+	t.Parallel()
+
+	properties := []*Type{
+		{Name: "plaintext"},
+		{Name: "ciphertext", Output: true},
+	}
+	parameters := []*Type{
+		{Name: "cryptoKey", UrlParamOnly: true, Required: true},
+	}
+
+	cases := []struct {
+		description string
+		obj         Resource
+		expected    []string
+	}{
+		{
+			description: "ephemeral is not set",
+			obj: Resource{
+				Properties: properties,
+				Parameters: parameters,
+			},
+			expected: []string{},
+		},
+		{
+			description: "GET sends no body",
+			obj: Resource{
+				Properties: properties,
+				Parameters: parameters,
+				Ephemeral:  &resource.Ephemeral{Generate: true},
+			},
+			expected: []string{},
+		},
+		{
+			description: "POST sends non-output, non-url-param arguments",
+			obj: Resource{
+				Properties: properties,
+				Parameters: parameters,
+				Ephemeral:  &resource.Ephemeral{Generate: true, OpenVerb: "POST"},
+			},
+			expected: []string{"plaintext"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			got := []string{}
+			for _, p := range tc.obj.EphemeralBodyProperties() {
+				got = append(got, p.Name)
+			}
+			if want := tc.expected; !reflect.DeepEqual(got, want) {
+				t.Errorf("expected %v to be %v", got, want)
+			}
+		})
+	}
+}
+
+func TestResourceEphemeralOpenUrl(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Resource
+		expected    string
+	}{
+		{
+			description: "defaults to self_link",
+			obj: Resource{
+				BaseUrl:   "projects/{{project}}/secrets",
+				Ephemeral: &resource.Ephemeral{Generate: true},
+			},
+			expected: "projects/{{project}}/secrets/{{name}}",
+		},
+		{
+			description: "open_url is set",
+			obj: Resource{
+				BaseUrl:   "{{crypto_key}}",
+				SelfLink:  "{{crypto_key}}",
+				Ephemeral: &resource.Ephemeral{Generate: true, OpenUrl: "{{crypto_key}}:encrypt"},
+			},
+			expected: "{{crypto_key}}:encrypt",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got, want := tc.obj.EphemeralOpenUrl(), tc.expected; got != want {
+				t.Errorf("expected %q to be %q", got, want)
+			}
+		})
+	}
+}
+
 func TestLeafProperties(t *testing.T) {
 	t.Parallel()
 
@@ -838,3 +1031,482 @@ func TestResourceAddExtraFields(t *testing.T) {
 		}
 	})
 }
+
+func TestResourceUnknownFields(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		yaml        string
+		expected    []int
+	}{
+		{
+			description: "known fields",
+			yaml:        "name: 'Widget'\nasync:\n  operation:\n    base_url: '{{op_id}}'\nproperties:\n  - name: 'size'\n    type: Integer\n",
+		},
+		{
+			description: "resource field",
+			yaml:        "name: 'Widget'\nbase_urll: 'widgets'\n",
+			expected:    []int{2},
+		},
+		{
+			description: "nested fields",
+			yaml:        "name: 'Widget'\nasync:\n  operation:\n    base_urll: '{{op_id}}'\nproperties:\n  - name: 'size'\n    typ: Integer\n",
+			expected:    []int{4, 7},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var r Resource
+			err := (&google.YamlValidator{}).Parse([]byte(tc.yaml), &r, "Widget.yaml")
+			var lines []int
+			var errs google.ValidationErrors
+			if errors.As(err, &errs) {
+				for _, e := range errs {
+					lines = append(lines, e.Line)
+				}
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(lines, tc.expected) {
+				t.Errorf("expected unknown fields on lines %v, got %v", tc.expected, err)
+			}
+		})
+	}
+}
+
+func TestResourceBatchingValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		yaml        string
+		expected    []string
+	}{
+		{
+			description: "defaults",
+			yaml:        "key_template: 'projects/{{project}}/widgets'\nsend_function: 'sendWidgetsBatch'\n",
+		},
+		{
+			description: "missing fields",
+			yaml:        "combine_strategy: 'custom'\n",
+			expected:    []string{"key_template", "send_function", "combine_function"},
+		},
+		{
+			description: "invalid values",
+			yaml:        "key_template: 'k'\nsend_function: 'f'\ncombine_strategy: 'merge'\nactions: ['update']\n",
+			expected:    []string{"combine_strategy", "actions"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var b resource.Batching
+			if err := yaml.Unmarshal([]byte(tc.yaml), &b); err != nil {
+				t.Fatal(err)
+			}
+			var fields []string
+			for _, err := range b.Validate() {
+				fields = append(fields, err.Field)
+			}
+			if !reflect.DeepEqual(fields, tc.expected) {
+				t.Errorf("expected problems with %v, got %v", tc.expected, fields)
+			}
+
+			r := Resource{Batching: &b}
+			if tc.expected == nil && (!r.BatchesAction("create") || !r.BatchesAction("delete")) {
+				t.Errorf("expected create and delete to be batched by default, got %v", b.Actions)
+			}
+		})
+	}
+}
+
+func TestResourceVcrMatcherValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		yaml        string
+		expected    []string
+	}{
+		{
+			description: "valid",
+			yaml:        "ignore_paths: ['/requestId', '/rules/*/createTime']\nunordered_paths: ['']\nignore_query_params: ['requestId']\nredact_headers: ['Authorization']\n",
+		},
+		{
+			description: "invalid values",
+			yaml:        "ignore_paths: ['requestId']\nredact_paths: ['password']\nredact_headers: ['']\n",
+			expected:    []string{"ignore_paths", "redact_paths", "redact_headers"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var m resource.VcrMatcher
+			if err := yaml.Unmarshal([]byte(tc.yaml), &m); err != nil {
+				t.Fatal(err)
+			}
+			var fields []string
+			for _, err := range m.Validate() {
+				fields = append(fields, err.Field)
+			}
+			if !reflect.DeepEqual(fields, tc.expected) {
+				t.Errorf("expected problems with %v, got %v", tc.expected, fields)
+			}
+		})
+	}
+}
+
+func TestResourceLocksValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		yaml        string
+		expected    []string
+		shared      map[string][]bool
+	}{
+		{
+			description: "defaults",
+			yaml:        "- key: 'projects/{{project}}/global/networks/{{network}}'\n",
+			shared:      map[string][]bool{"create": {false}, "read": nil, "update": {false}, "delete": {false}},
+		},
+		{
+			description: "modes per action",
+			yaml:        "- key: 'projects/{{project}}/global/networks/{{network}}'\n  mode: 'shared'\n  actions: ['create', 'delete']\n- key: 'projects/{{project}}/regions/{{region}}'\n  actions: ['read', 'delete']\n",
+			shared:      map[string][]bool{"create": {true}, "read": {false}, "update": nil, "delete": {true, false}},
+		},
+		{
+			description: "invalid values",
+			yaml:        "- mode: 'read'\n  actions: ['import']\n",
+			expected:    []string{"key", "mode", "actions"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var r Resource
+			if err := yaml.Unmarshal([]byte(tc.yaml), &r.Locks); err != nil {
+				t.Fatal(err)
+			}
+			var fields []string
+			for _, lock := range r.Locks {
+				for _, err := range lock.Validate() {
+					fields = append(fields, err.Field)
+				}
+			}
+			if !reflect.DeepEqual(fields, tc.expected) {
+				t.Errorf("expected problems with %v, got %v", tc.expected, fields)
+			}
+
+			for action, expected := range tc.shared {
+				var shared []bool
+				for _, lock := range r.LocksForAction(action) {
+					shared = append(shared, lock.IsShared())
+				}
+				if !reflect.DeepEqual(shared, expected) {
+					t.Errorf("expected %s locks to be shared %v, got %v", action, expected, shared)
+				}
+			}
+		})
+	}
+}
+
+func TestAsyncPollConditionsValidate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		yaml        string
+		expected    []string
+	}{
+		{
+			description: "conditions",
+			yaml:        "type: 'PollAsync'\nexistence_conditions:\n  done:\n    - field: 'state'\n      equals: 'READY'\n  failed:\n    - field: 'state'\n      in: ['FAILED']\n  not_found_codes: [404, 403]\nabsence_conditions:\n  done:\n    - field: 'deleteTime'\n      absent: true\n",
+		},
+		{
+			description: "conditions and function",
+			yaml:        "type: 'PollAsync'\ncheck_response_func_existence: 'transport_tpg.PollCheckForExistence'\nexistence_conditions:\n  done:\n    - field: 'state'\n      equals: 'READY'\n",
+			expected:    []string{"existence_conditions"},
+		},
+		{
+			description: "invalid conditions",
+			yaml:        "type: 'PollAsync'\nabsence_conditions:\n  done:\n    - equals: 'DELETED'\n  failed:\n    - field: 'state'\n      equals: 'ACTIVE'\n      absent: true\n",
+			expected:    []string{"absence_conditions.done.field", "absence_conditions.failed"},
+		},
+		{
+			description: "conditions without PollAsync",
+			yaml:        "operation:\n  base_url: '{{op_id}}'\nexistence_conditions: {}\n",
+			expected:    []string{"type"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var a Async
+			if err := yaml.Unmarshal([]byte(tc.yaml), &a); err != nil {
+				t.Fatal(err)
+			}
+			var fields []string
+			for _, err := range a.Validate() {
+				fields = append(fields, err.Field)
+			}
+			if !reflect.DeepEqual(fields, tc.expected) {
+				t.Errorf("expected problems with %v, got %v", tc.expected, fields)
+			}
+		})
+	}
+}
+
+func TestResourceValidateOnlyFields(t *testing.T) {
+	t.Parallel()
+
+	r := Resource{
+		Name:                 "Instance",
+		BaseUrl:              "projects/{{project}}/locations/{{location}}/instances",
+		CreateUrl:            "projects/{{project}}/locations/{{location}}/instances?instanceId={{name}}",
+		SelfLink:             "projects/{{project}}/locations/{{location}}/instances/{{name}}",
+		UpdateVerb:           "PATCH",
+		SupportsValidateOnly: true,
+		Parameters: []*Type{
+			{Name: "location", Type: "String", UrlParamOnly: true, Immutable: true},
+		},
+		Properties: []*Type{
+			{Name: "name", Type: "String", Immutable: true},
+			{Name: "tier", Type: "String"},
+			{Name: "createTime", Type: "String", Output: true},
+			{
+				Name:          "settings",
+				Type:          "NestedObject",
+				FlattenObject: true,
+				Properties: []*Type{
+					{Name: "diskSizeGb", Type: "Integer"},
+					{Name: "network", Type: "String", Immutable: true},
+				},
+			},
+		},
+	}
+	r.SetDefault(&Product{Name: "Filestore"})
+
+	if !r.ValidatesUpdateOnPlan() {
+		t.Errorf("expected updates to be validated")
+	}
+	cases := []struct {
+		description string
+		got         []string
+		expected    []string
+	}{
+		{
+			description: "fields",
+			got:         r.ValidateOnlyFields(),
+			expected:    []string{"disk_size_gb", "location", "name", "network", "project", "tier"},
+		},
+		{
+			description: "update fields",
+			got:         r.ValidateOnlyUpdateFields(),
+			expected:    []string{"tier", "disk_size_gb", "network"},
+		},
+		{
+			description: "replace fields",
+			got:         r.ValidateOnlyReplaceFields(),
+			expected:    []string{"name", "network"},
+		},
+	}
+	for _, tc := range cases {
+		if !reflect.DeepEqual(tc.got, tc.expected) {
+			t.Errorf("%s: expected %v, got %v", tc.description, tc.expected, tc.got)
+		}
+	}
+
+	r.CustomCode.Encoder = "templates/terraform/encoders/instance.go.tmpl"
+	var fields []string
+	for _, err := range r.Validate() {
+		fields = append(fields, err.Field)
+	}
+	if !slices.Contains(fields, "supports_validate_only") {
+		t.Errorf("expected a problem with supports_validate_only, got %v", fields)
+	}
+}
+
+func TestResourceStateUpgradeTestExample(t *testing.T) {
+	t.Parallel()
+
+	examples := []*resource.Examples{
+		{Name: "excluded", ExcludeTest: true},
+		{Name: "skipped", SkipTest: "b/123"},
+		{Name: "external", ExternalProviders: []string{"random"}},
+		{Name: "beta", MinVersion: "beta"},
+		{Name: "basic"},
+		{Name: "full"},
+	}
+
+	cases := []struct {
+		description   string
+		schemaVersion int
+		examples      []*resource.Examples
+		expected      string
+	}{
+		{
+			description:   "first testable example",
+			schemaVersion: 1,
+			examples:      examples,
+			expected:      "basic",
+		},
+		{
+			description: "no schema version",
+			examples:    examples,
+		},
+		{
+			description:   "no testable examples",
+			schemaVersion: 2,
+			examples:      examples[:4],
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			r := Resource{
+				SchemaVersion:     tc.schemaVersion,
+				Examples:          tc.examples,
+				TargetVersionName: "ga",
+			}
+			var name string
+			if e := r.StateUpgradeTestExample(); e != nil {
+				name = e.Name
+			}
+			if name != tc.expected {
+				t.Errorf("expected example %q, got %q", tc.expected, name)
+			}
+		})
+	}
+}
+
+func TestDiffSchemaSnapshots(t *testing.T) {
+	t.Parallel()
+
+	prior := &SchemaSnapshot{
+		SchemaVersion: 0,
+		Fields: []*SnapshotField{
+			{Name: "name", ApiPath: "name", Type: "TypeString", Required: true},
+			{Name: "size", ApiPath: "capacityGb", Type: "TypeInt", Optional: true},
+			{Name: "settings", ApiPath: "settings", Type: "TypeList", Optional: true, MaxItems: 1, Fields: []*SnapshotField{
+				{Name: "tier", ApiPath: "settings.tier", Type: "TypeString", Optional: true},
+				{Name: "legacy", ApiPath: "settings.legacy", Type: "TypeBool", Optional: true},
+			}},
+			{Name: "net", ApiPath: "networks", Type: "TypeList", Optional: true, Fields: []*SnapshotField{
+				{Name: "modes", ApiPath: "networks.modes", Type: "TypeList", Elem: "TypeString", Optional: true},
+				{Name: "network", ApiPath: "networks.network", Type: "TypeString", Optional: true},
+			}},
+			{Name: "tags", ApiPath: "tags", Type: "TypeList", Elem: "TypeString", Optional: true},
+			{Name: "location", ApiPath: "location", Type: "TypeString", Optional: true},
+		},
+	}
+
+	cases := []struct {
+		description string
+		next        []*SnapshotField
+		moves       []StateFieldMove
+		removed     []string
+		err         bool
+	}{
+		{
+			description: "rename, flatten, block rename and list to set",
+			next: []*SnapshotField{
+				{Name: "name", ApiPath: "name", Type: "TypeString", Required: true},
+				{Name: "size_gb", ApiPath: "capacityGb", Type: "TypeInt", Optional: true},
+				{Name: "tier", ApiPath: "settings.tier", Type: "TypeString", Optional: true},
+				{Name: "networks", ApiPath: "networks", Type: "TypeList", Optional: true, Fields: []*SnapshotField{
+					{Name: "connect_modes", ApiPath: "networks.modes", Type: "TypeList", Elem: "TypeString", Optional: true},
+					{Name: "network", ApiPath: "networks.network", Type: "TypeString", Optional: true},
+				}},
+				{Name: "tags", ApiPath: "tags", Type: "TypeSet", Elem: "TypeString", Optional: true},
+				{Name: "location", ApiPath: "location", Type: "TypeString", Optional: true},
+			},
+			moves: []StateFieldMove{
+				{From: "size", To: "size_gb"},
+				{From: "settings.tier", To: "tier"},
+				{From: "net", To: "networks"},
+				{From: "networks.modes", To: "networks.connect_modes"},
+			},
+			removed: []string{"settings"},
+		},
+		{
+			description: "nest and remove nested field",
+			next: []*SnapshotField{
+				{Name: "name", ApiPath: "name", Type: "TypeString", Required: true},
+				{Name: "capacity", ApiPath: "capacity", Type: "TypeList", Optional: true, MaxItems: 1, Fields: []*SnapshotField{
+					{Name: "gb", ApiPath: "capacityGb", Type: "TypeInt", Optional: true},
+				}},
+				{Name: "settings", ApiPath: "settings", Type: "TypeList", Optional: true, MaxItems: 1, Fields: []*SnapshotField{
+					{Name: "tier", ApiPath: "settings.tier", Type: "TypeString", Optional: true},
+				}},
+				{Name: "net", ApiPath: "networks", Type: "TypeList", Optional: true, Fields: []*SnapshotField{
+					{Name: "modes", ApiPath: "networks.modes", Type: "TypeList", Elem: "TypeString", Optional: true},
+					{Name: "network", ApiPath: "networks.network", Type: "TypeString", Optional: true},
+				}},
+				{Name: "tags", ApiPath: "tags", Type: "TypeList", Elem: "TypeString", Optional: true},
+				{Name: "location", ApiPath: "location", Type: "TypeString", Optional: true},
+			},
+			moves:   []StateFieldMove{{From: "size", To: "capacity.gb"}},
+			removed: []string{"settings.legacy"},
+		},
+		{
+			description: "type change",
+			next: []*SnapshotField{
+				{Name: "name", ApiPath: "name", Type: "TypeString", Required: true},
+				{Name: "size", ApiPath: "capacityGb", Type: "TypeString", Optional: true},
+			},
+			err: true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			upgrade, err := diffSchemaSnapshots(prior, &SchemaSnapshot{SchemaVersion: 1, Fields: tc.next})
+			if tc.err {
+				if err == nil {
+					t.Fatal("expected an error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(upgrade.Moves, tc.moves) {
+				t.Errorf("expected moves %v, got %v", tc.moves, upgrade.Moves)
+			}
+			if !reflect.DeepEqual(upgrade.Removed, tc.removed) {
+				t.Errorf("expected removed %v, got %v", tc.removed, upgrade.Removed)
+			}
+		})
+	}
+}
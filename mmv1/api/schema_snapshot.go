@@ -0,0 +1,342 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// SchemaSnapshot records the Terraform schema of a resource at one of its
+// schema versions, so that the state upgrader from that version can be
+// generated once the schema changes. Snapshots are written by the generator's
+// --record-schema-snapshots flag before schema_version is bumped.
+type SchemaSnapshot struct {
+	SchemaVersion int `yaml:"schema_version"`
+
+	Fields []*SnapshotField `yaml:"fields"`
+}
+
+// SnapshotField is a field of a SchemaSnapshot. It holds the parts of the
+// field's schema that determine its type in state, and the path of the API
+// field it maps to, which identifies the field across renames and flattening.
+type SnapshotField struct {
+	Name string `yaml:"name"`
+
+	// Dot-separated path of the API field, empty for fields that aren't
+	// sent to the API.
+	ApiPath string `yaml:"api_path,omitempty"`
+
+	// The schema.ValueType of the field, such as TypeString or TypeList.
+	Type string `yaml:"type"`
+
+	// The schema.ValueType of the elements of a list, set or map of scalars.
+	Elem string `yaml:"elem,omitempty"`
+
+	Required bool `yaml:"required,omitempty"`
+	Optional bool `yaml:"optional,omitempty"`
+	Computed bool `yaml:"computed,omitempty"`
+	MaxItems int  `yaml:"max_items,omitempty"`
+
+	// The fields of the elements of a block.
+	Fields []*SnapshotField `yaml:"fields,omitempty"`
+}
+
+// StateUpgrade is the state upgrader from a schema version of a resource,
+// generated by comparing the snapshot of that version with the next one.
+type StateUpgrade struct {
+	// The schema the upgrader reads state of.
+	Schema *SchemaSnapshot
+
+	// The fields moved by renames, flattening or nesting, in order.
+	Moves []StateFieldMove
+
+	// The fields that aren't in the next schema.
+	Removed []string
+}
+
+// StateFieldMove moves the value of a field between Terraform paths, given as
+// dot-separated field names.
+type StateFieldMove struct {
+	From string
+	To   string
+}
+
+// SchemaSnapshotFile returns the path of the snapshot of a schema version of
+// the resource, relative to the mmv1 directory. GA and beta schemas differ, so
+// they're recorded separately.
+func (r Resource) SchemaSnapshotFile(version int) string {
+	return filepath.Join(r.ProductMetadata.PackagePath, "schema_snapshots", fmt.Sprintf("%s_v%d_%s.yaml", r.Name, version, r.TargetVersionName))
+}
+
+// SchemaSnapshot returns the snapshot of the resource's current schema.
+func (r Resource) SchemaSnapshot() *SchemaSnapshot {
+	var fields []*SnapshotField
+	for _, p := range r.AllUserProperties() {
+		fields = append(fields, snapshotFields(p)...)
+	}
+	for _, p := range r.VirtualFields {
+		f := snapshotFields(p)
+		for _, vf := range f {
+			vf.ApiPath = ""
+		}
+		fields = append(fields, f...)
+	}
+	if r.HasProject() {
+		fields = append(fields, &SnapshotField{Name: "project", Type: "TypeString", Optional: true, Computed: true})
+	}
+	if r.HasSelfLink {
+		fields = append(fields, &SnapshotField{Name: "self_link", Type: "TypeString", Computed: true})
+	}
+	if r.GetAsync() != nil && r.GetAsync().Resumable {
+		fields = append(fields, &SnapshotField{Name: "operation", Type: "TypeString", Computed: true})
+	}
+	sortSnapshotFields(fields)
+	return &SchemaSnapshot{SchemaVersion: r.SchemaVersion, Fields: fields}
+}
+
+// snapshotFields returns the fields a property adds to the schema: the
+// property itself, or its properties if it's flattened.
+func snapshotFields(p *Type) []*SnapshotField {
+	if p.FlattenObject {
+		var fields []*SnapshotField
+		for _, child := range p.UserProperties() {
+			fields = append(fields, snapshotFields(child)...)
+		}
+		return fields
+	}
+
+	f := &SnapshotField{
+		Name:    google.Underscore(p.Name),
+		ApiPath: p.MetadataApiLineage(),
+		Type:    strings.TrimPrefix(p.TFType(p.Type), "schema."),
+	}
+	if p.IsSet {
+		f.Type = "TypeSet"
+	}
+	switch {
+	case p.DefaultFromApi:
+		f.Optional, f.Computed = true, true
+	case p.Required:
+		f.Required = true
+	case p.Output:
+		f.Computed = true
+	default:
+		f.Optional = true
+	}
+
+	var children []*Type
+	switch {
+	case p.IsA("NestedObject"):
+		if !p.Output {
+			f.MaxItems = 1
+		}
+		children = p.UserProperties()
+	case p.IsA("Array"):
+		f.MaxItems, _ = strconv.Atoi(p.MaxSize)
+		if p.ItemType.IsA("NestedObject") {
+			children = p.ItemType.UserProperties()
+		} else {
+			f.Elem = strings.TrimPrefix(p.TFType(p.ItemType.Type), "schema.")
+		}
+	case p.IsA("Map"):
+		f.Fields = append(f.Fields, &SnapshotField{Name: p.KeyName, Type: "TypeString", Required: true})
+		children = p.ValueType.UserProperties()
+	case strings.HasPrefix(p.Type, "KeyValue"):
+		f.Elem = "TypeString"
+	}
+	for _, child := range children {
+		f.Fields = append(f.Fields, snapshotFields(child)...)
+	}
+	sortSnapshotFields(f.Fields)
+	return []*SnapshotField{f}
+}
+
+func sortSnapshotFields(fields []*SnapshotField) {
+	slices.SortFunc(fields, func(a, b *SnapshotField) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// schemaSnapshotAt returns the recorded snapshot of a schema version, or nil.
+func (r Resource) schemaSnapshotAt(version int) *SchemaSnapshot {
+	for _, s := range r.SchemaSnapshots {
+		if s.SchemaVersion == version {
+			return s
+		}
+	}
+	return nil
+}
+
+// GeneratedStateUpgrade returns the state upgrader from a schema version of
+// the resource, or nil if the snapshots to generate it from aren't recorded
+// and it's written in the resource's state migration template instead. The
+// schema the upgrader writes state of is the snapshot of the next version,
+// or the current schema from the last version.
+func (r Resource) GeneratedStateUpgrade(version int) *StateUpgrade {
+	upgrade, _ := r.stateUpgrade(version)
+	return upgrade
+}
+
+func (r Resource) stateUpgrade(version int) (*StateUpgrade, error) {
+	prior := r.schemaSnapshotAt(version)
+	if prior == nil {
+		return nil, nil
+	}
+	next := r.schemaSnapshotAt(version + 1)
+	if version+1 == r.SchemaVersion {
+		next = r.SchemaSnapshot()
+	}
+	if next == nil {
+		return nil, nil
+	}
+	return diffSchemaSnapshots(prior, next)
+}
+
+// HandwrittenStateUpgraders reports whether any of the resource's state
+// upgraders isn't generated from snapshots, so its state migration template
+// is needed.
+func (r Resource) HandwrittenStateUpgraders() bool {
+	for _, v := range r.StateUpgradersCount() {
+		if r.GeneratedStateUpgrade(v) == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshotPath is a field of a snapshot along with its Terraform path.
+type snapshotPath struct {
+	path  string
+	field *SnapshotField
+}
+
+func flattenSnapshotFields(prefix string, fields []*SnapshotField) []snapshotPath {
+	var paths []snapshotPath
+	for _, f := range fields {
+		path := f.Name
+		if prefix != "" {
+			path = prefix + "." + f.Name
+		}
+		paths = append(paths, snapshotPath{path, f})
+		paths = append(paths, flattenSnapshotFields(path, f.Fields)...)
+	}
+	return paths
+}
+
+// diffSchemaSnapshots compares the snapshots of consecutive schema versions.
+// A field that isn't at the same Terraform path in the next schema is moved
+// to the path of the field mapping to the same API field, if exactly one
+// field maps to it in each schema, and is removed otherwise. Changes between
+// lists and sets don't change the state, and other type changes can't be
+// upgraded generically.
+func diffSchemaSnapshots(prior, next *SchemaSnapshot) (*StateUpgrade, error) {
+	priorPaths := flattenSnapshotFields("", prior.Fields)
+	nextPaths := flattenSnapshotFields("", next.Fields)
+
+	nextByPath := make(map[string]*SnapshotField)
+	nextByApiPath := make(map[string][]snapshotPath)
+	for _, p := range nextPaths {
+		nextByPath[p.path] = p.field
+		if p.field.ApiPath != "" {
+			nextByApiPath[p.field.ApiPath] = append(nextByApiPath[p.field.ApiPath], p)
+		}
+	}
+	priorByPath := make(map[string]*SnapshotField)
+	priorApiPathCount := make(map[string]int)
+	for _, p := range priorPaths {
+		priorByPath[p.path] = p.field
+		priorApiPathCount[p.field.ApiPath]++
+	}
+
+	upgrade := &StateUpgrade{Schema: prior}
+	for _, p := range priorPaths {
+		if f, ok := nextByPath[p.path]; ok {
+			if !compatibleSnapshotTypes(p.field, f) {
+				return nil, fmt.Errorf("%s changed from %s to %s", p.path, p.field.Type, f.Type)
+			}
+			continue
+		}
+
+		to := ""
+		if matches := nextByApiPath[p.field.ApiPath]; p.field.ApiPath != "" && len(matches) == 1 && priorApiPathCount[p.field.ApiPath] == 1 {
+			if _, taken := priorByPath[matches[0].path]; !taken {
+				to = matches[0].path
+			}
+		}
+		if to == "" {
+			upgrade.Removed = append(upgrade.Removed, p.path)
+			continue
+		}
+		if !compatibleSnapshotTypes(p.field, nextByPath[to]) {
+			return nil, fmt.Errorf("%s moved to %s and changed from %s to %s", p.path, to, p.field.Type, nextByPath[to].Type)
+		}
+
+		// A field whose block was moved is moved along with it, and is then
+		// only moved again if it was also renamed.
+		from := movedStatePath(p.path, upgrade.Moves)
+		if from != to {
+			upgrade.Moves = append(upgrade.Moves, StateFieldMove{From: from, To: to})
+		}
+	}
+
+	// Fields in removed blocks are removed along with them.
+	var removed []string
+	for _, path := range upgrade.Removed {
+		if !slices.ContainsFunc(upgrade.Removed, func(r string) bool { return strings.HasPrefix(path, r+".") }) {
+			removed = append(removed, movedStatePath(path, upgrade.Moves))
+		}
+	}
+	upgrade.Removed = removed
+
+	return upgrade, nil
+}
+
+// movedStatePath returns where a field is after the moves of its blocks.
+func movedStatePath(path string, moves []StateFieldMove) string {
+	for _, m := range moves {
+		if strings.HasPrefix(path, m.From+".") {
+			path = m.To + strings.TrimPrefix(path, m.From)
+		}
+	}
+	return path
+}
+
+func compatibleSnapshotTypes(a, b *SnapshotField) bool {
+	collection := func(t string) bool { return t == "TypeList" || t == "TypeSet" }
+	if collection(a.Type) && collection(b.Type) {
+		return a.Elem == b.Elem
+	}
+	return a.Type == b.Type && a.Elem == b.Elem
+}
+
+// validateStateUpgrades checks that the state upgraders of versions with
+// recorded snapshots can be generated.
+func (r Resource) validateStateUpgrades() google.ValidationErrors {
+	var errs google.ValidationErrors
+	for _, s := range r.SchemaSnapshots {
+		if s.SchemaVersion >= r.SchemaVersion {
+			continue
+		}
+		if _, err := r.stateUpgrade(s.SchemaVersion); err != nil {
+			errs.Addf("schema_version", "can't generate the state upgrader from version %d: %s; remove %s and write the upgrader in %s", s.SchemaVersion, err, r.SchemaSnapshotFile(s.SchemaVersion), r.StateMigrationFile())
+		}
+	}
+	return errs
+}
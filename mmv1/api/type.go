@@ -16,6 +16,7 @@ package api
 import (
 	"fmt"
 	"log"
+	"strconv"
 	"strings"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
@@ -187,6 +188,12 @@ type Type struct {
 	// Adds a ValidateFunc to the schema
 	Validation resource.Validation `yaml:"validation,omitempty"`
 
+	// Go expressions of plugin framework validators added to the field in
+	// plugin framework resources, such as `stringvalidator.LengthAtMost(63)`.
+	// They must implement the validator interface of the field's framework
+	// type and are not used by SDK resources.
+	FrameworkValidators []string `yaml:"framework_validators,omitempty"`
+
 	// Indicates that this is an Array that should have Set diff semantics.
 	UnorderedList bool `yaml:"unordered_list,omitempty"`
 
@@ -391,40 +398,119 @@ func (t *Type) SetDefault(r *Resource) {
 	}
 }
 
-func (t *Type) Validate(rName string) {
+// Validate checks the field and its nested fields. The fields of the returned
+// errors are relative to the root of the resource's YAML file.
+func (t *Type) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
+	path := t.yamlPath()
 	if t.Name == "" {
-		log.Fatalf("Missing `name` for proprty with type %s in resource %s", t.Type, rName)
+		errs.Addf(path, "missing `name` for property with type %s", t.Type)
 	}
 
 	if t.Output && t.Required {
-		log.Fatalf("Property %s cannot be output and required at the same time in resource %s.", t.Name, rName)
+		errs.Addf(path, "property %s cannot be output and required at the same time", t.Name)
 	}
 
 	if t.DefaultFromApi && t.DefaultValue != nil {
-		log.Fatalf("'default_value' and 'default_from_api' cannot be both set in resource %s", rName)
+		errs.Addf(path, "'default_value' and 'default_from_api' cannot be both set")
 	}
 
 	if (t.WriteOnlyLegacy || t.WriteOnly) && (t.DefaultFromApi || t.Output) {
-		log.Fatalf("Property %s cannot be write_only and default_from_api or output at the same time in resource %s", t.Name, rName)
+		errs.Addf(path, "property %s cannot be write_only and default_from_api or output at the same time", t.Name)
 	}
 
 	if (t.WriteOnlyLegacy || t.WriteOnly) && t.Sensitive {
-		log.Fatalf("Property %s cannot be write_only and sensitive at the same time in resource %s", t.Name, rName)
+		errs.Addf(path, "property %s cannot be write_only and sensitive at the same time", t.Name)
 	}
 
-	t.validateLabelsField()
+	errs.Extend("", t.validateLabelsField())
 
 	switch {
 	case t.IsA("Array"):
-		t.ItemType.Validate(rName)
+		errs.Extend("", t.ItemType.Validate())
 	case t.IsA("Map"):
-		t.ValueType.Validate(rName)
+		if t.KeyName == "" {
+			errs.Addf(path, "missing `key_name` for map property %s", t.Name)
+		}
+		errs.Extend("", t.ValueType.Validate())
 	case t.IsA("NestedObject"):
 		for _, p := range t.Properties {
-			p.Validate(rName)
+			errs.Extend("", p.Validate())
 		}
 	default:
 	}
+	return errs
+}
+
+// yamlPath returns the path of the field in its resource's YAML file, such as
+// `properties.foo.item_type.properties.bar`. Fields added by the generator
+// have a path, but aren't found in the file.
+func (t Type) yamlPath() string {
+	if t.ParentMetadata == nil {
+		list := "properties"
+		if r := t.ResourceMetadata; r != nil {
+			named := func(p *Type) bool { return p.Name == t.Name }
+			if slices.ContainsFunc(r.Parameters, named) {
+				list = "parameters"
+			} else if slices.ContainsFunc(r.VirtualFields, named) {
+				list = "virtual_fields"
+			}
+		}
+		return google.ItemField(list, t.Name)
+	}
+
+	parent := t.ParentMetadata.yamlPath()
+	switch {
+	case t.ParentMetadata.IsA("Array"):
+		return parent + ".item_type"
+	case t.ParentMetadata.IsA("Map"):
+		return parent + ".value_type"
+	}
+	return google.ItemField(parent+".properties", t.Name)
+}
+
+// validateFrameworkField checks that the field can be represented in a plugin
+// framework resource. SDK-specific customizations are only rejected when the
+// framework implementation is the only one generated.
+func (t Type) validateFrameworkField(frameworkOnly bool) google.ValidationErrors {
+	var errs google.ValidationErrors
+	path := t.yamlPath()
+	if t.FlattenObject {
+		errs.Addf(path, "`flatten_object` is not supported by plugin framework resources, but is set")
+	}
+	if t.IsA("Map") {
+		errs.Addf(path, "Map fields are not supported by plugin framework resources, but it is a Map")
+	}
+	if t.IsA("Array") && t.ItemType.IsA("Array") {
+		errs.Addf(path, "nested arrays are not supported by plugin framework resources, but it is one")
+	}
+	if t.IsFWBlock() && t.DefaultFromApi {
+		errs.Addf(path, "nested blocks cannot be computed in plugin framework resources, but it sets `default_from_api`")
+	}
+	if t.ParentMetadata != nil && (t.IsA("KeyValueLabels") || t.IsA("KeyValueAnnotations")) {
+		errs.Addf(path, "nested labels and annotations are not supported by plugin framework resources, but it is nested")
+	}
+	if t.ParentMetadata != nil && (t.WriteOnly || t.WriteOnlyLegacy) {
+		errs.Addf(path, "nested write-only fields are not supported by plugin framework resources, but it is nested")
+	}
+	if t.ParentMetadata != nil && t.IgnoreRead {
+		errs.Addf(path, "nested `ignore_read` fields are not supported by plugin framework resources, but it sets it")
+	}
+	if t.Output && t.IgnoreRead {
+		errs.Addf(path, "output fields must be read in plugin framework resources, but it sets `ignore_read`")
+	}
+	if t.DefaultValue != nil && !t.IsA("String") && !t.IsA("Enum") && !t.IsA("Boolean") && !t.IsA("Integer") && !t.IsA("Double") {
+		errs.Addf(path, "`default_value` is only supported on primitive fields by plugin framework resources, but is set")
+	}
+	if !frameworkOnly {
+		return errs
+	}
+	for _, f := range []string{t.CustomExpand, t.CustomFlatten, t.DiffSuppressFunc, t.StateFunc, t.SetHashFunc, t.KeyDiffSuppressFunc, t.Validation.Function, t.ItemValidation.Function} {
+		if f != "" {
+			errs.Addf(path, "uses %s, which is specific to SDK resources and is not supported by plugin framework resources", f)
+		}
+	}
+	return errs
 }
 
 // TODO rewrite: add validations
@@ -497,6 +583,24 @@ func (t Type) MetadataApiLineage() string {
 	return fmt.Sprintf("%s.%s", t.ParentMetadata.MetadataApiLineage(), apiName)
 }
 
+// Returns the enum values accepted by the field, or by the items of an array
+// field, for resource metadata.
+func (t Type) MetadataEnumValues() []string {
+	if t.IsA("Array") && t.ItemType != nil {
+		return t.ItemType.EnumValues
+	}
+	return t.EnumValues
+}
+
+// Returns the regex that values of the field, or of the items of an array
+// field, are validated against, for resource metadata.
+func (t Type) MetadataValidationRegex() string {
+	if t.IsA("Array") {
+		return t.ItemValidation.Regex
+	}
+	return t.Validation.Regex
+}
+
 // Returns the lineage in snake case
 func (t Type) LineageAsSnakeCase() string {
 	if t.ParentMetadata == nil {
@@ -870,8 +974,13 @@ func (t Type) GetFWType() string {
 	case "ResourceRef":
 		return "String"
 	case "NestedObject":
-		return "Object"
+		// nested objects are represented as lists with a single element, as in
+		// the SDK schema
+		return "List"
 	case "Array":
+		if t.IsSet {
+			return "Set"
+		}
 		return "List"
 	case "KeyValuePairs":
 		return "Map"
@@ -892,6 +1001,195 @@ func (t Type) GetFWType() string {
 	return "String"
 }
 
+// IsFWPrimitiveOrCollection returns whether the field is a primitive, a list of
+// primitives or a string map, which are the fields that can be represented in
+// a framework schema without nested attributes.
+func (t Type) IsFWPrimitiveOrCollection() bool {
+	switch {
+	case t.IsA("NestedObject"):
+		return false
+	case t.IsA("Array"):
+		return t.ItemType != nil && t.ItemType.IsFWPrimitiveOrCollection() && !t.ItemType.IsA("Array")
+	case t.IsA("Map"):
+		return false
+	}
+	return true
+}
+
+// IsFWBlock returns whether the field is rendered as a nested block in a
+// framework resource schema. Configurable nested objects are blocks so that
+// the configuration syntax matches the SDK resource, while output-only ones
+// are computed attributes of an object type.
+func (t Type) IsFWBlock() bool {
+	if t.Output {
+		return false
+	}
+	return t.IsA("NestedObject") || (t.IsA("Array") && t.ItemType.IsA("NestedObject"))
+}
+
+// GetFWAttrType returns the framework attr.Type expression of a field.
+func (t Type) GetFWAttrType() string {
+	switch t.GetFWType() {
+	case "List", "Set", "Map":
+		return fmt.Sprintf("types.%sType{ElemType: %s}", t.GetFWType(), t.GetFWElemAttrType())
+	}
+	return fmt.Sprintf("types.%sType", t.GetFWType())
+}
+
+// GetFWElemAttrType returns the framework attr.Type expression of the elements
+// of a list, set or map field.
+func (t Type) GetFWElemAttrType() string {
+	switch {
+	case t.IsA("NestedObject"):
+		return t.fwObjectAttrType()
+	case t.IsA("Array"):
+		if t.ItemType.IsA("NestedObject") {
+			return t.ItemType.fwObjectAttrType()
+		}
+		return t.ItemType.GetFWAttrType()
+	}
+	return "types.StringType"
+}
+
+func (t Type) fwObjectAttrType() string {
+	var attrs []string
+	for _, p := range t.UserProperties() {
+		attrs = append(attrs, fmt.Sprintf("%q: %s,", google.Underscore(p.Name), p.GetFWAttrType()))
+	}
+	return fmt.Sprintf("types.ObjectType{AttrTypes: map[string]attr.Type{\n%s\n}}", strings.Join(attrs, "\n"))
+}
+
+// FWNestedAttributes returns the nested fields of a block that are rendered as
+// attributes in a framework resource schema.
+func (t Type) FWNestedAttributes() []*Type {
+	return google.Reject(t.ResourceMetadata.OrderProperties(t.NestedProperties()), func(p *Type) bool {
+		return p.IsFWBlock()
+	})
+}
+
+// FWNestedBlocks returns the nested fields of a block that are rendered as
+// blocks in a framework resource schema.
+func (t Type) FWNestedBlocks() []*Type {
+	return google.Select(t.ResourceMetadata.OrderProperties(t.NestedProperties()), func(p *Type) bool {
+		return p.IsFWBlock()
+	})
+}
+
+// FWFieldPath returns the path of the field used as key in the
+// fwresource.ApiFields of a framework resource, such as `foo.bar`. Unlike
+// TerraformLineage, list indices are omitted.
+func (t Type) FWFieldPath() string {
+	return t.MetadataLineage()
+}
+
+// GetFWValidators returns the Go expressions of the framework validators of a
+// field, which match the validation of its SDK schema followed by its
+// framework_validators.
+func (t Type) GetFWValidators() []string {
+	if t.Output {
+		return nil
+	}
+
+	pkg := fmt.Sprintf("%svalidator", strings.ToLower(t.GetFWType()))
+	var validators []string
+	switch {
+	case t.IsA("Enum"):
+		validators = append(validators, fmt.Sprintf("stringvalidator.OneOf(%s)", t.EnumValuesToString("\"", true)))
+	case t.IsA("NestedObject"):
+		validators = append(validators, "listvalidator.SizeAtMost(1)")
+	case t.IsA("Array"):
+		if t.MinSize != "" {
+			validators = append(validators, fmt.Sprintf("%s.SizeAtLeast(%s)", pkg, t.MinSize))
+		}
+		if t.MaxSize != "" {
+			validators = append(validators, fmt.Sprintf("%s.SizeAtMost(%s)", pkg, t.MaxSize))
+		}
+		if t.ItemType.IsA("Enum") {
+			validators = append(validators, fmt.Sprintf("%s.ValueStringsAre(stringvalidator.OneOf(%s))", pkg, t.ItemType.EnumValuesToString("\"", false)))
+		} else if t.ItemType.IsA("String") && t.ItemValidation.Regex != "" {
+			validators = append(validators, fmt.Sprintf("%s.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`%s`), \"\"))", pkg, t.ItemValidation.Regex))
+		}
+	}
+	if t.Validation.Regex != "" {
+		validators = append(validators, fmt.Sprintf("stringvalidator.RegexMatches(regexp.MustCompile(`%s`), \"\")", t.Validation.Regex))
+	}
+	if t.Required && t.IsFWBlock() {
+		validators = append(validators, fmt.Sprintf("fwvalidators.Required%sBlock()", t.GetFWType()))
+	}
+
+	relations := []struct {
+		constructor string
+		fields      []string
+	}{
+		{"ConflictsWith", t.Conflicting()},
+		{"AtLeastOneOf", t.AtLeastOneOfList()},
+		{"ExactlyOneOf", t.ExactlyOneOfList()},
+		{"RequiredWith", t.RequiredWithList()},
+	}
+	for _, rel := range relations {
+		var expressions []string
+		for _, p := range t.GetPropertySchemaPathList(rel.fields) {
+			expressions = append(expressions, fwPathExpression(p))
+		}
+		if len(expressions) > 0 {
+			validators = append(validators, fmt.Sprintf("fwvalidators.%s(%s)", rel.constructor, strings.Join(expressions, ", ")))
+		}
+	}
+
+	return append(validators, t.FrameworkValidators...)
+}
+
+// fwPathExpression converts a path of the SDK schema, such as `foo.0.bar`, into
+// the framework path expression matching it.
+func fwPathExpression(schemaPath string) string {
+	var expression string
+	for i, step := range strings.Split(schemaPath, ".") {
+		switch {
+		case i == 0:
+			expression = fmt.Sprintf("path.MatchRoot(%q)", step)
+		case isIndex(step):
+			expression = fmt.Sprintf("%s.AtListIndex(%s)", expression, step)
+		default:
+			expression = fmt.Sprintf("%s.AtName(%q)", expression, step)
+		}
+	}
+	return expression
+}
+
+func isIndex(step string) bool {
+	_, err := strconv.Atoi(step)
+	return err == nil
+}
+
+// GetFWPlanModifiers returns the Go expressions of the framework plan
+// modifiers of a field.
+func (t Type) GetFWPlanModifiers() []string {
+	pkg := fmt.Sprintf("%splanmodifier", strings.ToLower(t.GetFWType()))
+	var modifiers []string
+	if t.IsForceNew() {
+		modifiers = append(modifiers, fmt.Sprintf("%s.RequiresReplace()", pkg))
+	}
+	if t.DefaultFromApi {
+		modifiers = append(modifiers, fmt.Sprintf("%s.UseStateForUnknown()", pkg))
+	}
+	return modifiers
+}
+
+// GetFWDefault returns the Go expression of the framework default of a field,
+// or an empty string if it has no default value.
+func (t Type) GetFWDefault() string {
+	if t.DefaultValue == nil {
+		return ""
+	}
+	return fmt.Sprintf("%sdefault.Static%s(%s)", strings.ToLower(t.GetFWType()), t.GetFWType(), t.GoLiteral(t.DefaultValue))
+}
+
+// GetFWValue returns the Go expression of the framework value of the default
+// value of a field.
+func (t Type) GetFWValue() string {
+	return fmt.Sprintf("types.%sValue(%s)", t.GetFWType(), t.GoLiteral(t.DefaultValue))
+}
+
 // TODO rewrite: validation
 // // Represents an enum, and store is valid values
 // class Enum < Primitive
@@ -1140,7 +1438,8 @@ func propertyWithApiName(apiName string) func(*Type) {
 	}
 }
 
-func (t *Type) validateLabelsField() {
+func (t *Type) validateLabelsField() google.ValidationErrors {
+	var errs google.ValidationErrors
 	productName := t.ResourceMetadata.ProductMetadata.Name
 	resourceName := t.ResourceMetadata.Name
 	lineage := t.Lineage()
@@ -1163,21 +1462,22 @@ func (t *Type) validateLabelsField() {
 
 			// The "labels" field has type Array, so skip this resource
 			!(productName == "Monitoring" && resourceName == "MetricDescriptor") {
-			log.Fatalf("Please use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+			errs.Addf(t.yamlPath(), "please use type KeyValueLabels for field %s", lineage)
 		}
 	} else if t.IsA("KeyValueLabels") {
-		log.Fatalf("Please don't use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+		errs.Addf(t.yamlPath(), "please don't use type KeyValueLabels for field %s", lineage)
 	}
 
 	if lineage == "annotations" || lineage == "metadata.annotations" {
 		if !t.IsA("KeyValueAnnotations") &&
 			// The "annotations" field has "ouput: true", so skip this eap resource
 			!(productName == "Gkeonprem" && resourceName == "BareMetalAdminClusterEnrollment") {
-			log.Fatalf("Please use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+			errs.Addf(t.yamlPath(), "please use type KeyValueAnnotations for field %s", lineage)
 		}
 	} else if t.IsA("KeyValueAnnotations") {
-		log.Fatalf("Please don't use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+		errs.Addf(t.yamlPath(), "please don't use type KeyValueAnnotations for field %s", lineage)
 	}
+	return errs
 }
 
 func (t Type) fieldMinVersion() string {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 )
 
 func TestTypeMinVersionObj(t *testing.T) {
@@ -256,6 +257,91 @@ func TestMetadataLineage(t *testing.T) {
 	}
 }
 
+func TestTypeYamlPath(t *testing.T) {
+	t.Parallel()
+
+	root := &Type{
+		Name: "root",
+		Type: "NestedObject",
+		Properties: []*Type{
+			{
+				Name: "bars",
+				Type: "Array",
+				ItemType: &Type{
+					Type: "NestedObject",
+					Properties: []*Type{
+						{
+							Name: "fooBar",
+							Type: "String",
+						},
+					},
+				},
+			},
+			{
+				Name:      "labels",
+				Type:      "Map",
+				ValueType: &Type{Type: "String"},
+			},
+		},
+	}
+	parameter := &Type{
+		Name: "zone",
+		Type: "String",
+	}
+	r := &Resource{Properties: []*Type{root}, Parameters: []*Type{parameter}}
+	root.SetDefault(r)
+	parameter.SetDefault(r)
+
+	cases := []struct {
+		description string
+		obj         *Type
+		expected    string
+	}{
+		{
+			description: "property",
+			obj:         root,
+			expected:    "properties.root",
+		},
+		{
+			description: "parameter",
+			obj:         parameter,
+			expected:    "parameters.zone",
+		},
+		{
+			description: "nested property",
+			obj:         root.Properties[0],
+			expected:    "properties.root.properties.bars",
+		},
+		{
+			description: "array item",
+			obj:         root.Properties[0].ItemType,
+			expected:    "properties.root.properties.bars.item_type",
+		},
+		{
+			description: "array of objects",
+			obj:         root.Properties[0].ItemType.Properties[0],
+			expected:    "properties.root.properties.bars.item_type.properties.fooBar",
+		},
+		{
+			description: "map value",
+			obj:         root.Properties[1].ValueType,
+			expected:    "properties.root.properties.labels.value_type",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.yamlPath(); got != tc.expected {
+				t.Errorf("expected %q to be %q", got, tc.expected)
+			}
+		})
+	}
+}
+
 func TestMetadataDefaultLineage(t *testing.T) {
 	t.Parallel()
 
@@ -475,6 +561,54 @@ func TestMetadataApiLineage(t *testing.T) {
 	}
 }
 
+func TestMetadataEnumValuesAndValidationRegex(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description   string
+		obj           Type
+		expectedEnum  []string
+		expectedRegex string
+	}{
+		{
+			description:   "string",
+			obj:           Type{Name: "foo", Type: "String", Validation: resource.Validation{Regex: "^[a-z]+$"}},
+			expectedRegex: "^[a-z]+$",
+		},
+		{
+			description:  "enum",
+			obj:          Type{Name: "foo", Type: "Enum", EnumValues: []string{"A", "B"}},
+			expectedEnum: []string{"A", "B"},
+		},
+		{
+			description: "array of enums",
+			obj: Type{
+				Name:           "foos",
+				Type:           "Array",
+				ItemType:       &Type{Type: "Enum", EnumValues: []string{"A", "B"}},
+				ItemValidation: resource.Validation{Regex: "^[A-Z]$"},
+			},
+			expectedEnum:  []string{"A", "B"},
+			expectedRegex: "^[A-Z]$",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.MetadataEnumValues(); !reflect.DeepEqual(got, tc.expectedEnum) {
+				t.Errorf("expected enum values %v to be %v", got, tc.expectedEnum)
+			}
+			if got := tc.obj.MetadataValidationRegex(); got != tc.expectedRegex {
+				t.Errorf("expected validation regex %q to be %q", got, tc.expectedRegex)
+			}
+		})
+	}
+}
+
 func TestProviderOnly(t *testing.T) {
 	t.Parallel()
 
@@ -563,3 +697,235 @@ func TestProviderOnly(t *testing.T) {
 		})
 	}
 }
+
+func TestTypeIsFWPrimitiveOrCollection(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Type
+		expected    bool
+	}{
+		{
+			description: "string",
+			obj:         Type{Type: "String"},
+			expected:    true,
+		},
+		{
+			description: "list of strings",
+			obj:         Type{Type: "Array", ItemType: &Type{Type: "String"}},
+			expected:    true,
+		},
+		{
+			description: "list of nested objects",
+			obj:         Type{Type: "Array", ItemType: &Type{Type: "NestedObject"}},
+			expected:    false,
+		},
+		{
+			description: "nested object",
+			obj:         Type{Type: "NestedObject"},
+			expected:    false,
+		},
+		{
+			description: "labels",
+			obj:         Type{Type: "KeyValueLabels"},
+			expected:    true,
+		},
+		{
+			description: "map of nested objects",
+			obj:         Type{Type: "Map", ValueType: &Type{Type: "NestedObject"}},
+			expected:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.IsFWPrimitiveOrCollection(); got != tc.expected {
+				t.Errorf("expected %v to be %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTypeIsFWBlock(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Type
+		expected    bool
+	}{
+		{
+			description: "string",
+			obj:         Type{Type: "String"},
+			expected:    false,
+		},
+		{
+			description: "nested object",
+			obj:         Type{Type: "NestedObject"},
+			expected:    true,
+		},
+		{
+			description: "list of nested objects",
+			obj:         Type{Type: "Array", ItemType: &Type{Type: "NestedObject"}},
+			expected:    true,
+		},
+		{
+			description: "output nested object",
+			obj:         Type{Type: "NestedObject", Output: true},
+			expected:    false,
+		},
+		{
+			description: "list of strings",
+			obj:         Type{Type: "Array", ItemType: &Type{Type: "String"}},
+			expected:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.IsFWBlock(); got != tc.expected {
+				t.Errorf("expected %v to be %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFWPathExpression(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		path        string
+		expected    string
+	}{
+		{
+			description: "root",
+			path:        "foo",
+			expected:    `path.MatchRoot("foo")`,
+		},
+		{
+			description: "nested",
+			path:        "foo.0.bar_baz",
+			expected:    `path.MatchRoot("foo").AtListIndex(0).AtName("bar_baz")`,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := fwPathExpression(tc.path); got != tc.expected {
+				t.Errorf("expected %q to be %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTypeGetFWValidators(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Type
+		expected    []string
+	}{
+		{
+			description: "plain string",
+			obj:         Type{Type: "String"},
+			expected:    nil,
+		},
+		{
+			description: "regex and framework validators",
+			obj: Type{
+				Type:                "String",
+				Validation:          resource.Validation{Regex: "^a$"},
+				FrameworkValidators: []string{"stringvalidator.LengthAtMost(63)"},
+			},
+			expected: []string{
+				"stringvalidator.RegexMatches(regexp.MustCompile(`^a$`), \"\")",
+				"stringvalidator.LengthAtMost(63)",
+			},
+		},
+		{
+			description: "sized list",
+			obj:         Type{Type: "Array", ItemType: &Type{Type: "String"}, MinSize: "1", MaxSize: "3"},
+			expected:    []string{"listvalidator.SizeAtLeast(1)", "listvalidator.SizeAtMost(3)"},
+		},
+		{
+			description: "required nested object",
+			obj:         Type{Type: "NestedObject", Required: true},
+			expected:    []string{"listvalidator.SizeAtMost(1)", "fwvalidators.RequiredListBlock()"},
+		},
+		{
+			description: "output",
+			obj:         Type{Type: "String", Output: true, FrameworkValidators: []string{"stringvalidator.LengthAtMost(63)"}},
+			expected:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.GetFWValidators(); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v to be %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTypeGetFWDefault(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Type
+		expected    string
+	}{
+		{
+			description: "no default",
+			obj:         Type{Type: "String"},
+			expected:    "",
+		},
+		{
+			description: "enum",
+			obj:         Type{Type: "Enum", DefaultValue: "FOO"},
+			expected:    `stringdefault.StaticString("FOO")`,
+		},
+		{
+			description: "boolean",
+			obj:         Type{Type: "Boolean", DefaultValue: true},
+			expected:    "booldefault.StaticBool(true)",
+		},
+		{
+			description: "integer",
+			obj:         Type{Type: "Integer", DefaultValue: 5},
+			expected:    "int64default.StaticInt64(5)",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.GetFWDefault(); got != tc.expected {
+				t.Errorf("expected %q to be %q", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package google
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError is a problem found in a YAML configuration file.
+type ValidationError struct {
+	// The path of the file containing the problem.
+	File string `json:"file,omitempty"`
+
+	// The position of the problem in the file, starting at 1, or 0 if unknown.
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+
+	// The dotted path of the field with the problem from the root of the file,
+	// such as `properties.name.description`. Items of lists are identified by
+	// their `name`.
+	Field string `json:"field,omitempty"`
+
+	Message string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	var sb strings.Builder
+	if e.File != "" {
+		sb.WriteString(e.File)
+		if e.Line > 0 {
+			fmt.Fprintf(&sb, ":%d", e.Line)
+		}
+		if e.Column > 0 {
+			fmt.Fprintf(&sb, ":%d", e.Column)
+		}
+		sb.WriteString(": ")
+	}
+	if e.Field != "" {
+		fmt.Fprintf(&sb, "%s: ", e.Field)
+	}
+	sb.WriteString(e.Message)
+	return sb.String()
+}
+
+// ValidationErrors collects the problems found in YAML configuration files, so
+// that they can be reported together.
+type ValidationErrors []*ValidationError
+
+func (errs ValidationErrors) Error() string {
+	var lines []string
+	for _, e := range errs {
+		lines = append(lines, e.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Addf adds a problem with the field at the given path.
+func (errs *ValidationErrors) Addf(field, format string, a ...any) {
+	*errs = append(*errs, &ValidationError{Field: field, Message: fmt.Sprintf(format, a...)})
+}
+
+// Extend adds the problems found in a nested object, prefixing their fields
+// with the path of the object.
+func (errs *ValidationErrors) Extend(prefix string, nested ValidationErrors) {
+	for _, e := range nested {
+		if prefix != "" {
+			e.Field = strings.TrimSuffix(prefix+"."+e.Field, ".")
+		}
+		*errs = append(*errs, e)
+	}
+}
+
+// ItemField returns the path of the item with the given name in a list field,
+// or of the list itself if the item has no name.
+func ItemField(list, name string) string {
+	if name == "" {
+		return list
+	}
+	return list + "." + name
+}
+
+// Locate sets the file of the problems that don't have one yet, and their
+// position within it from the file's contents. Problems are positioned at the
+// deepest part of their field found in the file.
+func (errs ValidationErrors) Locate(file string, contents []byte) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(contents, &root); err != nil || len(root.Content) == 0 {
+		root = yaml.Node{}
+	}
+
+	for _, e := range errs {
+		if e.File != "" {
+			continue
+		}
+		e.File = file
+		if e.Line == 0 && len(root.Content) > 0 {
+			node, _ := findYamlNode(root.Content[0], e.Field)
+			e.Line, e.Column = node.Line, node.Column
+		}
+	}
+}
+
+// ResolvesIn reports whether the whole field of the problem is found in the
+// given YAML contents.
+func (e *ValidationError) ResolvesIn(contents []byte) bool {
+	var root yaml.Node
+	if err := yaml.Unmarshal(contents, &root); err != nil || len(root.Content) == 0 {
+		return false
+	}
+	_, found := findYamlNode(root.Content[0], e.Field)
+	return found
+}
+
+// findYamlNode returns the node of a dotted field path, or the deepest node
+// found along it. Mapping values are positioned at their key.
+func findYamlNode(node *yaml.Node, field string) (*yaml.Node, bool) {
+	found := node
+	if field == "" {
+		return found, true
+	}
+
+	for _, step := range strings.Split(field, ".") {
+		var next, pos *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == step {
+					pos, next = node.Content[i], node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			for _, item := range node.Content {
+				if yamlMappingValue(item, "name") == step {
+					pos, next = item, item
+					break
+				}
+			}
+		}
+		if next == nil {
+			return found, false
+		}
+		node, found = next, pos
+	}
+	return found, true
+}
+
+// yamlMappingValue returns the scalar value of a key of a mapping node.
+func yamlMappingValue(node *yaml.Node, key string) string {
+	if node.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// WriteValidationErrors writes problems in the given format: `text`, with one
+// problem per line, `json` or `sarif`.
+func WriteValidationErrors(w io.Writer, errs ValidationErrors, format string) error {
+	switch format {
+	case "", "text":
+		for _, e := range errs {
+			if _, err := fmt.Fprintln(w, e.Error()); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "json":
+		if errs == nil {
+			errs = ValidationErrors{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(errs)
+	case "sarif":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(sarifLog(errs))
+	}
+	return fmt.Errorf("unknown validation output format %q", format)
+}
+
+// sarifLog converts problems into a SARIF 2.1.0 log, with file paths relative
+// to the mmv1 directory.
+func sarifLog(errs ValidationErrors) map[string]any {
+	results := []map[string]any{}
+	for _, e := range errs {
+		message := e.Message
+		if e.Field != "" {
+			message = fmt.Sprintf("%s: %s", e.Field, e.Message)
+		}
+		result := map[string]any{
+			"ruleId":  "mmv1/invalid-config",
+			"level":   "error",
+			"message": map[string]any{"text": message},
+		}
+		if e.File != "" {
+			physicalLocation := map[string]any{
+				"artifactLocation": map[string]any{"uri": e.File},
+			}
+			if e.Line > 0 {
+				region := map[string]any{"startLine": e.Line}
+				if e.Column > 0 {
+					region["startColumn"] = e.Column
+				}
+				physicalLocation["region"] = region
+			}
+			result["locations"] = []map[string]any{{"physicalLocation": physicalLocation}}
+		}
+		results = append(results, result)
+	}
+
+	return map[string]any{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []map[string]any{{
+			"tool": map[string]any{
+				"driver": map[string]any{
+					"name":           "mmv1",
+					"informationUri": "https://googlecloudplatform.github.io/magic-modules/",
+					"rules": []map[string]any{{
+						"id":               "mmv1/invalid-config",
+						"shortDescription": map[string]any{"text": "Invalid MMv1 configuration"},
+					}},
+				},
+			},
+			"results": results,
+		}},
+	}
+}
@@ -0,0 +1,210 @@
+package google
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+const validationErrorsYaml = `name: 'Rule'
+create_verb: 'GET'
+properties:
+  - name: 'enabled'
+    type: Boolean
+  - name: 'match'
+    type: NestedObject
+    properties:
+      - name: 'host'
+        type: String
+`
+
+func TestValidationErrorsLocate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		field       string
+		line        int
+		column      int
+		resolves    bool
+	}{
+		{
+			description: "root",
+			field:       "",
+			line:        1,
+			column:      1,
+			resolves:    true,
+		},
+		{
+			description: "top-level field",
+			field:       "create_verb",
+			line:        2,
+			column:      1,
+			resolves:    true,
+		},
+		{
+			description: "list item",
+			field:       "properties.enabled",
+			line:        4,
+			column:      5,
+			resolves:    true,
+		},
+		{
+			description: "nested list item",
+			field:       "properties.match.properties.host",
+			line:        9,
+			column:      9,
+			resolves:    true,
+		},
+		{
+			description: "missing field",
+			field:       "properties.match.properties.path",
+			line:        8,
+			column:      5,
+			resolves:    false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			e := &ValidationError{Field: tc.field, Message: "invalid"}
+			ValidationErrors{e}.Locate("Rule.yaml", []byte(validationErrorsYaml))
+			if e.File != "Rule.yaml" || e.Line != tc.line || e.Column != tc.column {
+				t.Errorf("expected %s:%d:%d to be Rule.yaml:%d:%d", e.File, e.Line, e.Column, tc.line, tc.column)
+			}
+			if got := e.ResolvesIn([]byte(validationErrorsYaml)); got != tc.resolves {
+				t.Errorf("expected ResolvesIn to be %t, got %t", tc.resolves, got)
+			}
+		})
+	}
+}
+
+func TestValidationErrorsExtend(t *testing.T) {
+	t.Parallel()
+
+	var nested ValidationErrors
+	nested.Addf("", "missing `steps`")
+	nested.Addf("config_path", "invalid path")
+
+	var errs ValidationErrors
+	errs.Extend(ItemField("samples", "basic"), nested)
+	errs.Extend("", ValidationErrors{{Field: "name", Message: "missing"}})
+
+	expected := []string{"samples.basic", "samples.basic.config_path", "name"}
+	if len(errs) != len(expected) {
+		t.Fatalf("expected %d errors, got %d", len(expected), len(errs))
+	}
+	for i, e := range errs {
+		if e.Field != expected[i] {
+			t.Errorf("expected %q to be %q", e.Field, expected[i])
+		}
+	}
+}
+
+func TestYamlValidatorParseErrors(t *testing.T) {
+	t.Parallel()
+
+	var obj struct {
+		Name    string `yaml:"name"`
+		Enabled bool   `yaml:"enabled"`
+	}
+	yamlValidator := YamlValidator{}
+	err := yamlValidator.Parse([]byte("name: 'Rule'\nenabled: 'maybe'\n"), &obj, "Rule.yaml")
+
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 1 {
+		t.Fatalf("expected a single ValidationError, got %v", err)
+	}
+	if errs[0].File != "Rule.yaml" || errs[0].Line != 2 {
+		t.Errorf("expected error at Rule.yaml:2, got %s", errs[0])
+	}
+}
+
+func TestWriteValidationErrors(t *testing.T) {
+	t.Parallel()
+
+	errs := ValidationErrors{
+		{File: "Rule.yaml", Line: 2, Column: 1, Field: "create_verb", Message: "invalid verb"},
+		{File: "product.yaml", Message: "cannot open the file"},
+	}
+
+	cases := []struct {
+		description string
+		format      string
+		check       func(t *testing.T, out []byte)
+	}{
+		{
+			description: "text",
+			format:      "text",
+			check: func(t *testing.T, out []byte) {
+				expected := "Rule.yaml:2:1: create_verb: invalid verb\nproduct.yaml: cannot open the file\n"
+				if string(out) != expected {
+					t.Errorf("expected %q to be %q", out, expected)
+				}
+			},
+		},
+		{
+			description: "json",
+			format:      "json",
+			check: func(t *testing.T, out []byte) {
+				var got ValidationErrors
+				if err := json.Unmarshal(out, &got); err != nil {
+					t.Fatal(err)
+				}
+				if len(got) != 2 || *got[0] != *errs[0] {
+					t.Errorf("expected %v to be %v", got, errs)
+				}
+			},
+		},
+		{
+			description: "sarif",
+			format:      "sarif",
+			check: func(t *testing.T, out []byte) {
+				var got struct {
+					Version string
+					Runs    []struct {
+						Results []struct {
+							RuleId    string
+							Locations []struct {
+								PhysicalLocation struct {
+									Region *struct{ StartLine int }
+								}
+							}
+						}
+					}
+				}
+				if err := json.Unmarshal(out, &got); err != nil {
+					t.Fatal(err)
+				}
+				if got.Version != "2.1.0" || len(got.Runs) != 1 || len(got.Runs[0].Results) != 2 {
+					t.Fatalf("unexpected SARIF log %s", out)
+				}
+				results := got.Runs[0].Results
+				if region := results[0].Locations[0].PhysicalLocation.Region; region == nil || region.StartLine != 2 {
+					t.Errorf("expected the first result to start at line 2, got %s", out)
+				}
+				if region := results[1].Locations[0].PhysicalLocation.Region; region != nil {
+					t.Errorf("expected the second result to have no region, got %s", out)
+				}
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var out bytes.Buffer
+			if err := WriteValidationErrors(&out, errs, tc.format); err != nil {
+				t.Fatal(err)
+			}
+			tc.check(t, out.Bytes())
+		})
+	}
+}
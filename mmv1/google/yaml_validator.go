@@ -15,20 +15,159 @@ package google
 
 import (
 	"bytes"
-	"log"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
 
+var yamlErrorLineRegex = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
 // A helper class to validate contents coming from YAML files.
 type YamlValidator struct{}
 
-func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) {
+// Parse decodes content into obj, returning ValidationErrors for the problems
+// found in the file at yamlPath, such as unknown fields.
+func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) error {
 	// Create a new decoder to enable strict validation with KnownFields(true)
 	decoder := yaml.NewDecoder(bytes.NewReader(content))
 	decoder.KnownFields(true)
 
 	if err := decoder.Decode(obj); err != nil {
-		log.Fatalf("Cannot unmarshal data from file %s: %v", yamlPath, err)
+		messages := []string{err.Error()}
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			messages = typeErr.Errors
+		}
+
+		var errs ValidationErrors
+		for _, message := range messages {
+			e := &ValidationError{File: yamlPath, Message: "cannot unmarshal: " + strings.TrimPrefix(message, "yaml: ")}
+			if m := yamlErrorLineRegex.FindStringSubmatch(message); m != nil {
+				e.Line, _ = strconv.Atoi(m[1])
+				e.Message = "cannot unmarshal: " + m[2]
+			}
+			errs = append(errs, e)
+		}
+		return errs
+	}
+	return nil
+}
+
+var nodeUnmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+
+// DecodeKnownFields decodes value into obj like value.Decode, and also reports
+// the keys in value that don't match a field of obj.
+//
+// Types implementing yaml.Unmarshaler use this instead of value.Decode, which
+// ignores the KnownFields setting of the decoder that called them. The check
+// descends into nested structs until it reaches another yaml.Unmarshaler,
+// which checks its own fields.
+func DecodeKnownFields(value *yaml.Node, obj interface{}) error {
+	err := value.Decode(obj)
+	var messages []string
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	} else if err != nil {
+		return err
+	}
+
+	checkKnownFields(value, reflect.TypeOf(obj), &messages)
+	if len(messages) > 0 {
+		return &yaml.TypeError{Errors: messages}
+	}
+	return nil
+}
+
+func checkKnownFields(value *yaml.Node, t reflect.Type, messages *[]string) {
+	for value.Kind == yaml.AliasNode {
+		value = value.Alias
+	}
+	if value.Kind == yaml.DocumentNode && len(value.Content) > 0 {
+		value = value.Content[0]
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if value.Kind != yaml.SequenceNode {
+			return
+		}
+		for _, item := range value.Content {
+			checkFieldValue(item, t.Elem(), messages)
+		}
+	case reflect.Map:
+		if value.Kind != yaml.MappingNode {
+			return
+		}
+		for i := 1; i < len(value.Content); i += 2 {
+			checkFieldValue(value.Content[i], t.Elem(), messages)
+		}
+	case reflect.Struct:
+		if value.Kind != yaml.MappingNode {
+			return
+		}
+		fields := make(map[string]reflect.Type)
+		yamlFields(t, fields)
+		for i := 0; i+1 < len(value.Content); i += 2 {
+			key := value.Content[i]
+			if key.Value == "<<" {
+				continue
+			}
+			fieldType, ok := fields[key.Value]
+			if !ok {
+				*messages = append(*messages, fmt.Sprintf("line %d: field %s not found in type %s", key.Line, key.Value, t))
+				continue
+			}
+			checkFieldValue(value.Content[i+1], fieldType, messages)
+		}
+	}
+}
+
+// checkFieldValue checks value unless t checks its own fields when decoded.
+func checkFieldValue(value *yaml.Node, t reflect.Type, messages *[]string) {
+	if t.Implements(nodeUnmarshalerType) || reflect.PointerTo(t).Implements(nodeUnmarshalerType) {
+		return
+	}
+	checkKnownFields(value, t, messages)
+}
+
+// yamlFields adds the YAML keys of the fields of struct type t to fields,
+// following the naming rules of the yaml package.
+func yamlFields(t reflect.Type, fields map[string]reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		tag := f.Tag.Get("yaml")
+		if tag == "" && !strings.Contains(string(f.Tag), ":") {
+			tag = string(f.Tag)
+		}
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if strings.Contains(opts, "inline") {
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				yamlFields(ft, fields)
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		fields[name] = f.Type
 	}
 }
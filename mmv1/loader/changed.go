@@ -0,0 +1,140 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ChangedProducts returns the products, as `products/<name>` paths, whose
+// configuration changed since the given git ref, including uncommitted and
+// untracked changes. Changes within the mmv1 directory and the override
+// directory are considered. The boolean result is true if a change outside of
+// the product configurations, such as to a template or the generator itself,
+// may affect every product, or if the override directory isn't part of the
+// same git repository and so can't be compared to the ref.
+func (l *Loader) ChangedProducts(ref string) ([]string, bool, error) {
+	changed, err := l.changedFiles(l.BaseDirectory, ref)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Override directories within mmv1 are covered by its diff.
+	if l.OverrideDirectory != "" && strings.HasPrefix(filepath.ToSlash(l.OverrideDirectory), "..") {
+		overrides := filepath.Join(l.BaseDirectory, l.OverrideDirectory)
+		same, err := l.sameRepository(overrides)
+		if err != nil {
+			return nil, false, err
+		}
+		if !same {
+			return nil, true, nil
+		}
+		overrideChanges, err := l.changedFiles(overrides, ref)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, c := range overrideChanges {
+			changed = append(changed, filepath.Join(l.OverrideDirectory, c))
+		}
+	}
+
+	var products []string
+	seen := make(map[string]bool)
+	for _, c := range changed {
+		product, ok := l.changedProduct(c)
+		if !ok {
+			return nil, true, nil
+		}
+		if product != "" && !seen[product] {
+			seen[product] = true
+			products = append(products, product)
+		}
+	}
+	return products, false, nil
+}
+
+// changedFiles returns the files within dir, relative to it, that changed
+// since the given git ref, including uncommitted and untracked changes.
+func (l *Loader) changedFiles(dir, ref string) ([]string, error) {
+	diff, err := git(dir, "diff", "--name-only", "--relative", ref)
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := git(dir, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+	return append(diff, untracked...), nil
+}
+
+// sameRepository reports whether dir is in the same git repository as the
+// mmv1 directory.
+func (l *Loader) sameRepository(dir string) (bool, error) {
+	mmv1, err := git(l.BaseDirectory, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return false, err
+	}
+	other, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		// The override directory isn't in a git repository.
+		return false, nil
+	}
+	return len(mmv1) == 1 && len(other) == 1 && mmv1[0] == other[0], nil
+}
+
+// changedProduct maps a changed file, relative to the mmv1 directory, to the
+// product it belongs to. Files that don't affect generation, such as Go tests
+// of the generator, map to an empty product. The boolean result is false if
+// the file may affect every product.
+func (l *Loader) changedProduct(changed string) (string, bool) {
+	if l.OverrideDirectory != "" {
+		if rel, err := filepath.Rel(l.OverrideDirectory, changed); err == nil && !strings.HasPrefix(rel, "..") {
+			changed = rel
+		}
+	}
+
+	parts := strings.Split(filepath.ToSlash(changed), "/")
+	if parts[0] == "products" {
+		if len(parts) < 3 {
+			return "", false
+		}
+		return fmt.Sprintf("products/%s", parts[1]), true
+	}
+	if strings.HasSuffix(changed, "_test.go") && !strings.HasPrefix(changed, "third_party/") {
+		return "", true
+	}
+	return "", false
+}
+
+// git runs a git command in the given directory and returns the lines it
+// printed.
+func git(dir string, args ...string) ([]string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error running git %s: %w", strings.Join(args, " "), err)
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestChangedProduct(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description       string
+		overrideDirectory string
+		changed           string
+		product           string
+		ok                bool
+	}{
+		{
+			description: "resource",
+			changed:     "products/pubsub/Topic.yaml",
+			product:     "products/pubsub",
+			ok:          true,
+		},
+		{
+			description: "product",
+			changed:     "products/pubsub/product.yaml",
+			product:     "products/pubsub",
+			ok:          true,
+		},
+		{
+			description:       "override",
+			overrideDirectory: "overrides",
+			changed:           "overrides/products/pubsub/Topic.yaml",
+			product:           "products/pubsub",
+			ok:                true,
+		},
+		{
+			description: "template",
+			changed:     "templates/terraform/resource.go.tmpl",
+			ok:          false,
+		},
+		{
+			description: "generator test",
+			changed:     "api/resource_test.go",
+			ok:          true,
+		},
+		{
+			description: "handwritten test",
+			changed:     "third_party/terraform/services/pubsub/resource_pubsub_topic_test.go",
+			ok:          false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			l := Loader{OverrideDirectory: tc.overrideDirectory}
+			product, ok := l.changedProduct(tc.changed)
+			if product != tc.product || ok != tc.ok {
+				t.Errorf("expected (%q, %t) to be (%q, %t)", product, ok, tc.product, tc.ok)
+			}
+		})
+	}
+}
+
+func TestChangedProductsOverrideDirectory(t *testing.T) {
+	repo := t.TempDir()
+	mmv1 := filepath.Join(repo, "mmv1")
+	for _, file := range []string{"mmv1/products/pubsub/Topic.yaml", "overrides/products/pubsub/Topic.yaml"} {
+		writeTestFile(t, filepath.Join(repo, file))
+	}
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "-A"},
+		{"-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial"},
+	} {
+		if _, err := git(repo, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeTestFile(t, filepath.Join(repo, "overrides/products/compute/Network.yaml"))
+
+	cases := []struct {
+		description       string
+		overrideDirectory string
+		products          []string
+		all               bool
+	}{
+		{
+			description:       "override directory in the same repository",
+			overrideDirectory: "../overrides",
+			products:          []string{"products/compute"},
+		},
+		{
+			description:       "override directory outside of the repository",
+			overrideDirectory: t.TempDir(),
+			all:               true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.description, func(t *testing.T) {
+			l := NewLoader(Config{BaseDirectory: mmv1, OverrideDirectory: tc.overrideDirectory, Version: "ga"})
+			products, all, err := l.ChangedProducts("HEAD")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(products, tc.products) || all != tc.all {
+				t.Errorf("expected (%v, %t) to be (%v, %t)", products, all, tc.products, tc.all)
+			}
+		})
+	}
+}
+
+func writeTestFile(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("name: 'Test'\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"sync"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"golang.org/x/exp/slices"
 )
 
@@ -59,7 +60,9 @@ func NewLoader(config Config) *Loader {
 	return l
 }
 
-func (l *Loader) LoadProducts() map[string]*api.Product {
+// LoadProducts loads every product. Problems in the configuration of any
+// product are returned together as google.ValidationErrors.
+func (l *Loader) LoadProducts() (map[string]*api.Product, error) {
 	if l.Version == "" {
 		log.Printf("No version specified, assuming ga")
 		l.Version = "ga"
@@ -98,7 +101,7 @@ func (l *Loader) LoadProducts() map[string]*api.Product {
 	return l.batchLoadProducts(allProductFiles)
 }
 
-func (l *Loader) batchLoadProducts(productNames []string) map[string]*api.Product {
+func (l *Loader) batchLoadProducts(productNames []string) (map[string]*api.Product, error) {
 	products := make(map[string]*api.Product)
 
 	// Create result type for clarity
@@ -134,6 +137,7 @@ func (l *Loader) batchLoadProducts(productNames []string) map[string]*api.Produc
 
 	// Collect results as they complete
 	loadFailureCount := 0
+	var validationErrs google.ValidationErrors
 	for result := range productChan {
 		if result.err != nil {
 			// Check if the error is the specific "version not found" error
@@ -142,6 +146,12 @@ func (l *Loader) batchLoadProducts(productNames []string) map[string]*api.Produc
 				continue
 			}
 
+			var errs google.ValidationErrors
+			if errors.As(result.err, &errs) {
+				validationErrs = append(validationErrs, errs...)
+				continue
+			}
+
 			loadFailureCount++
 			log.Printf("Error loading %s: %v", result.name, result.err)
 			continue
@@ -151,12 +161,18 @@ func (l *Loader) batchLoadProducts(productNames []string) map[string]*api.Produc
 	if loadFailureCount > 0 {
 		log.Fatalf("Failed to load %d products", loadFailureCount)
 	}
+	if len(validationErrs) > 0 {
+		sortValidationErrors(validationErrs)
+		return products, validationErrs
+	}
 
-	return products
+	return products, nil
 }
 
 // Load compiles a product with all its resources from the given path and optional overrides
 // This loads the product configuration and all its resources into memory without generating any files
+// Problems in the configuration of the product or its resources are returned
+// together as google.ValidationErrors.
 func (l *Loader) LoadProduct(productName string) (*api.Product, error) {
 	p := &api.Product{}
 	productYamlPath := filepath.Join(productName, "product.yaml")
@@ -176,17 +192,23 @@ func (l *Loader) LoadProduct(productName string) (*api.Product, error) {
 	}
 
 	// Compile the product configuration
+	var errs google.ValidationErrors
 	if overrideProductExists {
 		if baseProductExists {
-			api.Compile(baseProductPath, p, l.OverrideDirectory)
+			addCompileErrors(&errs, api.Compile(baseProductPath, p, l.OverrideDirectory))
 			overrideApiProduct := &api.Product{}
-			api.Compile(productOverridePath, overrideApiProduct, l.OverrideDirectory)
+			addCompileErrors(&errs, api.Compile(productOverridePath, overrideApiProduct, l.OverrideDirectory))
 			api.Merge(reflect.ValueOf(p).Elem(), reflect.ValueOf(*overrideApiProduct), l.Version)
 		} else {
-			api.Compile(productOverridePath, p, l.OverrideDirectory)
+			addCompileErrors(&errs, api.Compile(productOverridePath, p, l.OverrideDirectory))
 		}
 	} else {
-		api.Compile(baseProductPath, p, l.OverrideDirectory)
+		addCompileErrors(&errs, api.Compile(baseProductPath, p, l.OverrideDirectory))
+	}
+	// The rest of the product can't be loaded reliably from a file that
+	// couldn't be decoded.
+	if len(errs) > 0 {
+		return nil, errs
 	}
 
 	// Check if product exists at the requested l.Version
@@ -196,19 +218,31 @@ func (l *Loader) LoadProduct(productName string) (*api.Product, error) {
 
 	// Compile all resources
 	p.PackagePath = productName
-	resources, err := l.loadResources(p)
+	resources, err := l.loadResources(p, &errs)
 	if err != nil {
 		return nil, err
 	}
 
 	p.Objects = resources
-	p.Validate()
+	productErrs := p.Validate()
+	if !baseProductExists {
+		baseProductPath = ""
+	}
+	if !overrideProductExists {
+		productOverridePath = ""
+	}
+	locateValidationErrors(productErrs, baseProductPath, productOverridePath)
+	errs = append(errs, productErrs...)
+	if len(errs) > 0 {
+		return nil, errs
+	}
 
 	return p, nil
 }
 
-// loadResources loads all resources for a product
-func (l *Loader) loadResources(product *api.Product) ([]*api.Resource, error) {
+// loadResources loads all resources for a product, adding problems in their
+// configuration to errs
+func (l *Loader) loadResources(product *api.Product, errs *google.ValidationErrors) ([]*api.Resource, error) {
 	var resources []*api.Resource = make([]*api.Resource, 0)
 
 	// Get base resource files
@@ -231,13 +265,16 @@ func (l *Loader) loadResources(product *api.Product) ([]*api.Resource, error) {
 			}
 		}
 
-		resource := l.loadResource(product, resourceYamlPath, "")
-		resources = append(resources, resource)
+		resource, resourceErrs := l.loadResource(product, resourceYamlPath, "")
+		*errs = append(*errs, resourceErrs...)
+		if resource != nil {
+			resources = append(resources, resource)
+		}
 	}
 
 	// Compile override resources
 	if l.OverrideDirectory != "" {
-		resources, err = l.reconcileOverrideResources(product, resources)
+		resources, err = l.reconcileOverrideResources(product, resources, errs)
 		if err != nil {
 			return nil, err
 		}
@@ -247,7 +284,7 @@ func (l *Loader) loadResources(product *api.Product) ([]*api.Resource, error) {
 }
 
 // reconcileOverrideResources handles resolution of override resources
-func (l *Loader) reconcileOverrideResources(product *api.Product, resources []*api.Resource) ([]*api.Resource, error) {
+func (l *Loader) reconcileOverrideResources(product *api.Product, resources []*api.Resource, errs *google.ValidationErrors) ([]*api.Resource, error) {
 	productOverridePath := filepath.Join(l.OverrideDirectory, product.PackagePath, "product.yaml")
 	productOverrideDir := filepath.Dir(productOverridePath)
 
@@ -262,8 +299,11 @@ func (l *Loader) reconcileOverrideResources(product *api.Product, resources []*a
 		}
 
 		baseResourcePath := filepath.Join(product.PackagePath, filepath.Base(overrideYamlPath))
-		resource := l.loadResource(product, baseResourcePath, overrideYamlPath)
-		resources = append(resources, resource)
+		resource, resourceErrs := l.loadResource(product, baseResourcePath, overrideYamlPath)
+		*errs = append(*errs, resourceErrs...)
+		if resource != nil {
+			resources = append(resources, resource)
+		}
 	}
 
 	// Sort resources by name for consistent output
@@ -274,30 +314,35 @@ func (l *Loader) reconcileOverrideResources(product *api.Product, resources []*a
 	return resources, nil
 }
 
-// loadResource loads a single resource with optional override
-func (l *Loader) loadResource(product *api.Product, baseResourcePath string, overrideResourcePath string) *api.Resource {
+// loadResource loads a single resource with optional override. The resource
+// is nil if its files couldn't be decoded.
+func (l *Loader) loadResource(product *api.Product, baseResourcePath string, overrideResourcePath string) (*api.Resource, google.ValidationErrors) {
 	resource := &api.Resource{}
 
 	// Check if base resource exists
 	baseResourceExists := Exists(l.BaseDirectory, baseResourcePath)
 
+	var errs google.ValidationErrors
 	if overrideResourcePath != "" {
 		if baseResourceExists {
 			// Merge base and override
-			api.Compile(baseResourcePath, resource, l.OverrideDirectory)
+			addCompileErrors(&errs, api.Compile(baseResourcePath, resource, l.OverrideDirectory))
 			overrideResource := &api.Resource{}
-			api.Compile(overrideResourcePath, overrideResource, l.OverrideDirectory)
+			addCompileErrors(&errs, api.Compile(overrideResourcePath, overrideResource, l.OverrideDirectory))
 			api.Merge(reflect.ValueOf(resource).Elem(), reflect.ValueOf(*overrideResource), l.Version)
 			resource.SourceYamlFile = baseResourcePath
 		} else {
 			// Override only
-			api.Compile(overrideResourcePath, resource, l.OverrideDirectory)
+			addCompileErrors(&errs, api.Compile(overrideResourcePath, resource, l.OverrideDirectory))
 		}
 	} else {
 		// Base only
-		api.Compile(baseResourcePath, resource, l.OverrideDirectory)
+		addCompileErrors(&errs, api.Compile(baseResourcePath, resource, l.OverrideDirectory))
 		resource.SourceYamlFile = baseResourcePath
 	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
 
 	// Set resource defaults and validate
 	resource.TargetVersionName = l.Version
@@ -306,12 +351,113 @@ func (l *Loader) loadResource(product *api.Product, baseResourcePath string, ove
 	resource.Properties = resource.AddExtraFields(resource.PropertiesWithExcluded(), nil)
 	// SetDefault after AddExtraFields to ensure relevant metadata is available for the newly generated fields
 	resource.SetDefault(product)
-	resource.Validate()
+	if errs = l.loadSchemaSnapshots(resource); len(errs) > 0 {
+		return nil, errs
+	}
+	if errs = resource.Validate(); len(errs) > 0 {
+		if !baseResourceExists {
+			baseResourcePath = ""
+		}
+		locateValidationErrors(errs, baseResourcePath, overrideResourcePath)
+		return nil, errs
+	}
 	resource.TestSampleSetUp()
 
 	for _, e := range resource.Examples {
 		e.LoadHCLText(l.BaseDirectory)
 	}
 
-	return resource
+	return resource, nil
+}
+
+// SchemaSnapshotPath returns the path to record the snapshot of a schema
+// version of a resource to. Snapshots of resources defined or overridden in
+// the override directory are recorded there, where loadSchemaSnapshots looks
+// for them first.
+func (l *Loader) SchemaSnapshotPath(resource *api.Resource, version int) string {
+	snapshotPath := resource.SchemaSnapshotFile(version)
+	if l.OverrideDirectory != "" {
+		overridden := resource.SourceYamlFile == "" || Exists(l.OverrideDirectory, resource.SourceYamlFile)
+		if overridden || Exists(l.OverrideDirectory, snapshotPath) {
+			return filepath.Join(l.OverrideDirectory, snapshotPath)
+		}
+	}
+	return filepath.Join(l.BaseDirectory, snapshotPath)
+}
+
+// loadSchemaSnapshots loads the recorded snapshots of the resource's prior
+// schema versions, preferring those in the override directory.
+func (l *Loader) loadSchemaSnapshots(resource *api.Resource) google.ValidationErrors {
+	var errs google.ValidationErrors
+	for _, v := range resource.StateUpgradersCount() {
+		snapshotPath := resource.SchemaSnapshotFile(v)
+		if l.OverrideDirectory != "" && Exists(l.OverrideDirectory, snapshotPath) {
+			snapshotPath = filepath.Join(l.OverrideDirectory, snapshotPath)
+		} else if Exists(l.BaseDirectory, snapshotPath) {
+			snapshotPath = filepath.Join(l.BaseDirectory, snapshotPath)
+		} else {
+			continue
+		}
+
+		snapshot := &api.SchemaSnapshot{}
+		if err := api.Compile(snapshotPath, snapshot, l.OverrideDirectory); err != nil {
+			addCompileErrors(&errs, err)
+			continue
+		}
+		if snapshot.SchemaVersion != v {
+			errs = append(errs, &google.ValidationError{File: snapshotPath, Message: fmt.Sprintf("the snapshot is of schema version %d instead of %d", snapshot.SchemaVersion, v)})
+			continue
+		}
+		resource.SchemaSnapshots = append(resource.SchemaSnapshots, snapshot)
+	}
+	return errs
+}
+
+// addCompileErrors adds the problems found decoding a file to errs. Other
+// errors can't be attributed to the configuration and stop the generator.
+func addCompileErrors(errs *google.ValidationErrors, err error) {
+	if err == nil {
+		return
+	}
+	var compileErrs google.ValidationErrors
+	if !errors.As(err, &compileErrs) {
+		log.Fatalf("%v", err)
+	}
+	*errs = append(*errs, compileErrs...)
+}
+
+// locateValidationErrors positions problems within the file that sets their
+// field: the override file if it sets the whole field, or the base file
+// otherwise. Either path may be empty if the file doesn't exist.
+func locateValidationErrors(errs google.ValidationErrors, basePath, overridePath string) {
+	var base, override []byte
+	if basePath != "" {
+		base, _ = os.ReadFile(basePath)
+	}
+	if overridePath != "" {
+		override, _ = os.ReadFile(overridePath)
+	}
+
+	for _, e := range errs {
+		if overridePath != "" && (basePath == "" || e.ResolvesIn(override)) {
+			google.ValidationErrors{e}.Locate(overridePath, override)
+		} else {
+			google.ValidationErrors{e}.Locate(basePath, base)
+		}
+	}
+}
+
+// sortValidationErrors orders problems by their position, as products are
+// loaded concurrently.
+func sortValidationErrors(errs google.ValidationErrors) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		a, b := errs[i], errs[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
 }
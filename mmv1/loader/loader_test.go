@@ -0,0 +1,103 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loader
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func TestSchemaSnapshotPath(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	overrides := t.TempDir()
+	for _, f := range []string{
+		filepath.Join(base, "products/pubsub/Topic.yaml"),
+		filepath.Join(base, "products/pubsub/Subscription.yaml"),
+		filepath.Join(overrides, "products/pubsub/Subscription.yaml"),
+		filepath.Join(overrides, "products/pubsub/schema_snapshots/Schema_v0_ga.yaml"),
+	} {
+		if err := os.MkdirAll(filepath.Dir(f), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(f, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cases := []struct {
+		description       string
+		overrideDirectory string
+		name              string
+		sourceYamlFile    string
+		expected          string
+	}{
+		{
+			description:    "base resource",
+			name:           "Topic",
+			sourceYamlFile: "products/pubsub/Topic.yaml",
+			expected:       filepath.Join(base, "products/pubsub/schema_snapshots/Topic_v0_ga.yaml"),
+		},
+		{
+			description:       "base resource with overrides",
+			overrideDirectory: overrides,
+			name:              "Topic",
+			sourceYamlFile:    "products/pubsub/Topic.yaml",
+			expected:          filepath.Join(base, "products/pubsub/schema_snapshots/Topic_v0_ga.yaml"),
+		},
+		{
+			description:       "overridden resource",
+			overrideDirectory: overrides,
+			name:              "Subscription",
+			sourceYamlFile:    "products/pubsub/Subscription.yaml",
+			expected:          filepath.Join(overrides, "products/pubsub/schema_snapshots/Subscription_v0_ga.yaml"),
+		},
+		{
+			description:       "override only resource",
+			overrideDirectory: overrides,
+			name:              "Snapshot",
+			expected:          filepath.Join(overrides, "products/pubsub/schema_snapshots/Snapshot_v0_ga.yaml"),
+		},
+		{
+			description:       "snapshot in the override directory",
+			overrideDirectory: overrides,
+			name:              "Schema",
+			sourceYamlFile:    "products/pubsub/Schema.yaml",
+			expected:          filepath.Join(overrides, "products/pubsub/schema_snapshots/Schema_v0_ga.yaml"),
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			l := Loader{BaseDirectory: base, OverrideDirectory: tc.overrideDirectory}
+			r := &api.Resource{
+				Name:              tc.name,
+				SourceYamlFile:    tc.sourceYamlFile,
+				ProductMetadata:   &api.Product{PackagePath: "products/pubsub"},
+				TargetVersionName: "ga",
+			}
+			if got := l.SchemaSnapshotPath(r, 0); got != tc.expected {
+				t.Errorf("expected %q to be %q", got, tc.expected)
+			}
+		})
+	}
+}
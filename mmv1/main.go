@@ -1,16 +1,22 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
 
 	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/openapi_generate"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/provider"
@@ -38,7 +44,21 @@ var doNotGenerateDocs = flag.Bool("no-docs", false, "do not generate docs")
 
 var providerFlag = flag.String("provider", "", "optional provider name. If specified, a non-default provider will be used.")
 
-var openapiGenerate = flag.Bool("openapi-generate", false, "Generate MMv1 YAML from openapi directory (Experimental)")
+var cacheDirFlag = flag.String("cache-dir", "", "optional directory to cache generation in. If specified, resources that haven't changed since the last generation into the same output path are skipped.")
+
+var changedSinceFlag = flag.String("changed-since", "", "optional git ref. If specified, only products whose configuration changed since the ref are generated, unless the generator or its templates changed.")
+
+var validateOnly = flag.Bool("validate-only", false, "validate the configuration of all products and report every problem found without generating any files")
+
+var validateFormatFlag = flag.String("validate-format", "text", "format of the problems reported by --validate-only: text, json or sarif")
+
+var recordSchemaSnapshots = flag.Bool("record-schema-snapshots", false, "record the current Terraform schemas of the resources of --product, or only --resource, to generate their state upgraders from once their schema_version is bumped. Records GA and beta schemas unless --version is set")
+
+var openapiGenerate = flag.Bool("openapi-generate", false, "Generate MMv1 YAML from the OpenAPI and Discovery documents of the openapi directory (Experimental)")
+
+var openapiDiff = flag.Bool("openapi-diff", false, "Report the fields added to, removed from or changed in the APIs of the openapi directory compared to the existing MMv1 YAML (Experimental)")
+
+var openapiDiffPatchFlag = flag.String("openapi-diff-patch", "", "optional file to write a patch adding the missing fields found by --openapi-diff to")
 
 func main() {
 
@@ -51,15 +71,110 @@ func main() {
 		return
 	}
 
+	if *openapiDiff {
+		parser := openapi_generate.NewOpenapiParser("openapi_generate/openapi", "products")
+		loader := loader.NewLoader(loader.Config{Version: "ga", OverrideDirectory: *overrideDirectoryFlag})
+		diffs := parser.Diff(loader, *openapiDiffPatchFlag)
+		if len(diffs) > 0 {
+			fmt.Println(openapi_generate.FormatDiffs(diffs))
+		}
+		log.Printf("Found %d differences", len(diffs))
+		return
+	}
+
+	if *validateOnly {
+		os.Exit(ValidateProducts(*versionFlag, *overrideDirectoryFlag, *validateFormatFlag))
+	}
+
+	if *recordSchemaSnapshots {
+		RecordSchemaSnapshots(*productFlag, *resourceFlag, *versionFlag, *overrideDirectoryFlag)
+		return
+	}
+
 	if *outputPathFlag == "" {
 		log.Printf("No output path specified, exiting")
 		return
 	}
 
-	GenerateProducts(*productFlag, *resourceFlag, *providerFlag, *versionFlag, *outputPathFlag, *overrideDirectoryFlag, !*doNotGenerateCode, !*doNotGenerateDocs)
+	GenerateProducts(*productFlag, *resourceFlag, *providerFlag, *versionFlag, *outputPathFlag, *overrideDirectoryFlag, *cacheDirFlag, *changedSinceFlag, !*doNotGenerateCode, !*doNotGenerateDocs)
+}
+
+// ValidateProducts loads every product and writes the problems found in their
+// configuration to stdout in the given format. It returns the exit code of the
+// generator: 1 if any problem was found.
+func ValidateProducts(version, overrideDirectory, format string) int {
+	if version == "" {
+		version = "ga"
+	}
+
+	loader := loader.NewLoader(loader.Config{Version: version, OverrideDirectory: overrideDirectory})
+	var errs google.ValidationErrors
+	if _, err := loader.LoadProducts(); err != nil && !errors.As(err, &errs) {
+		log.Fatalf("Error loading products: %v", err)
+	}
+
+	if err := google.WriteValidationErrors(os.Stdout, errs, format); err != nil {
+		log.Fatalf("Error writing validation errors: %v", err)
+	}
+	if len(errs) > 0 {
+		log.Printf("Found %d problem(s) in the product configuration", len(errs))
+		return 1
+	}
+	return 0
+}
+
+// RecordSchemaSnapshots writes snapshots of the current schemas of a product's
+// resources to the product's schema_snapshots directory, in the override
+// directory for resources defined or overridden there.
+func RecordSchemaSnapshots(product, resource, version, overrideDirectory string) {
+	if product == "" {
+		log.Fatalf("--record-schema-snapshots requires --product")
+	}
+	versions := []string{"ga", "beta"}
+	if version != "" {
+		versions = []string{version}
+	}
+
+	for _, v := range versions {
+		l := loader.NewLoader(loader.Config{Version: v, OverrideDirectory: overrideDirectory})
+		productApi, err := l.LoadProduct(fmt.Sprintf("products/%s", product))
+		if err != nil {
+			var versionErr *loader.ErrProductVersionNotFound
+			if errors.As(err, &versionErr) {
+				continue
+			}
+			log.Fatalf("Error loading product %s: %v", product, err)
+		}
+		for _, r := range productApi.Objects {
+			r.ExcludeIfNotInVersion(productApi.VersionObjOrClosest(v))
+			if r.Exclude || resource != "" && r.Name != resource {
+				continue
+			}
+			if err := writeSchemaSnapshot(r, l.SchemaSnapshotPath(r, r.SchemaVersion)); err != nil {
+				log.Fatalf("Error recording the schema of %s: %v", r.Name, err)
+			}
+		}
+	}
+}
+
+func writeSchemaSnapshot(r *api.Resource, snapshotPath string) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# The schema of %s at schema version %d, recorded with --record-schema-snapshots\n", r.TerraformName(), r.SchemaVersion)
+	fmt.Fprintf(&b, "# to generate its state upgrader from. Don't edit this file.\n")
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(r.SchemaSnapshot()); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(snapshotPath), os.ModePerm); err != nil {
+		return err
+	}
+	log.Printf("Recording the schema of %s to %s", r.Name, snapshotPath)
+	return os.WriteFile(snapshotPath, b.Bytes(), 0644)
 }
 
-func GenerateProducts(product, resource, providerName, version, outputPath, overrideDirectory string, generateCode, generateDocs bool) {
+func GenerateProducts(product, resource, providerName, version, outputPath, overrideDirectory, cacheDir, changedSince string, generateCode, generateDocs bool) {
 	if version == "" {
 		log.Printf("No version specified, assuming ga")
 		version = "ga"
@@ -74,7 +189,10 @@ func GenerateProducts(product, resource, providerName, version, outputPath, over
 	log.Printf("Building %s provider", providerName)
 
 	loader := loader.NewLoader(loader.Config{Version: version, OverrideDirectory: overrideDirectory})
-	loadedProducts := loader.LoadProducts()
+	loadedProducts, err := loader.LoadProducts()
+	if err != nil {
+		log.Fatalf("Invalid product configuration:\n%v", err)
+	}
 
 	var productsToGenerate []string
 	if product == "" {
@@ -86,12 +204,40 @@ func GenerateProducts(product, resource, providerName, version, outputPath, over
 		productsToGenerate = []string{productToGenerate}
 	}
 
+	if changedSince != "" {
+		changedProducts, all, err := loader.ChangedProducts(changedSince)
+		if err != nil {
+			log.Fatalf("Error finding products changed since %s: %v", changedSince, err)
+		}
+		if all {
+			log.Printf("Files outside of products changed since %s, generating all products", changedSince)
+		} else {
+			log.Printf("Products changed since %s: %v", changedSince, changedProducts)
+			productsToGenerate = google.Select(productsToGenerate, func(p string) bool {
+				return slices.Contains(changedProducts, p)
+			})
+		}
+	}
+
+	var cache *provider.GenerationCache
+	if cacheDir != "" {
+		var err error
+		cache, err = provider.LoadGenerationCache(cacheDir, outputPath, providerName, version, generateCode, generateDocs)
+		if err != nil {
+			log.Fatalf("Error loading generation cache from %s: %v", cacheDir, err)
+		}
+	}
+
 	for _, productApi := range loadedProducts {
 		wg.Add(1)
-		go GenerateProduct(version, providerName, productApi, outputPath, startTime, productsToGenerate, resource, generateCode, generateDocs)
+		go GenerateProduct(version, providerName, productApi, outputPath, startTime, productsToGenerate, resource, cache, generateCode, generateDocs)
 	}
 	wg.Wait()
 
+	if err := cache.Save(); err != nil {
+		log.Printf("Error saving generation cache to %s: %v", cacheDir, err)
+	}
+
 	var productsForVersion []*api.Product
 	for _, p := range loadedProducts {
 		productsForVersion = append(productsForVersion, p)
@@ -102,7 +248,7 @@ func GenerateProducts(product, resource, providerName, version, outputPath, over
 
 	// In order to only copy/compile files once per provider this must be called outside
 	// of the products loop. Create an MMv1 provider with an arbitrary product (the first loaded).
-	providerToGenerate := newProvider(providerName, version, productsForVersion[0], startTime)
+	providerToGenerate := newProvider(providerName, version, productsForVersion[0], startTime, nil)
 	providerToGenerate.CopyCommonFiles(outputPath, generateCode, generateDocs)
 
 	if generateCode {
@@ -114,7 +260,7 @@ func GenerateProducts(product, resource, providerName, version, outputPath, over
 // This now uses the CompileProduct method to separate compilation from generation
 func GenerateProduct(version, providerName string, productApi *api.Product, outputPath string,
 	startTime time.Time, productsToGenerate []string, resourceToGenerate string,
-	generateCode, generateDocs bool) {
+	cache *provider.GenerationCache, generateCode, generateDocs bool) {
 	defer wg.Done()
 
 	if !slices.Contains(productsToGenerate, productApi.PackagePath) {
@@ -123,11 +269,13 @@ func GenerateProduct(version, providerName string, productApi *api.Product, outp
 	}
 
 	log.Printf("%s: Generating files", productApi.PackagePath)
-	providerToGenerate := newProvider(providerName, version, productApi, startTime)
+	providerToGenerate := newProvider(providerName, version, productApi, startTime, cache)
 	providerToGenerate.Generate(outputPath, resourceToGenerate, generateCode, generateDocs)
 }
 
-func newProvider(providerName, version string, productApi *api.Product, startTime time.Time) provider.Provider {
+// newProvider creates the provider to generate. The cache is only supported by
+// the default provider.
+func newProvider(providerName, version string, productApi *api.Product, startTime time.Time, cache *provider.GenerationCache) provider.Provider {
 	switch providerName {
 	case "tgc":
 		return provider.NewTerraformGoogleConversion(productApi, version, startTime)
@@ -138,6 +286,8 @@ func newProvider(providerName, version string, productApi *api.Product, startTim
 	case "oics":
 		return provider.NewTerraformOiCS(productApi, version, startTime)
 	default:
-		return provider.NewTerraform(productApi, version, startTime)
+		t := provider.NewTerraform(productApi, version, startTime)
+		t.Cache = cache
+		return t
 	}
 }
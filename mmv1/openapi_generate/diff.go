@@ -0,0 +1,420 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
+)
+
+// The kinds of differences between an OpenAPI document and MMv1 resources.
+const (
+	MissingResource = "missing resource"
+	MissingField    = "missing field"
+	RemovedField    = "removed field"
+	ChangedType     = "changed type"
+)
+
+// FieldDiff is a difference between a field of an MMv1 resource and the schema
+// of its API.
+type FieldDiff struct {
+	// The YAML file of the resource.
+	File string
+
+	Resource string
+	Kind     string
+
+	// The path of the field in the API, such as `foo.barBaz`.
+	Field string
+
+	// The MMv1 type of the field in the configuration and in the API. The
+	// former is empty for missing fields and the latter for removed ones.
+	Type    string
+	ApiType string
+
+	// missing holds the configuration generated for a missing field, and
+	// parent the path of the list it's missing from, such as
+	// `properties.foo.properties`.
+	missing *api.Type
+	parent  []string
+}
+
+func (d FieldDiff) String() string {
+	switch d.Kind {
+	case MissingResource:
+		return fmt.Sprintf("%s: %s %s", d.File, d.Kind, d.Resource)
+	case MissingField:
+		return fmt.Sprintf("%s: %s %s `%s` (%s)", d.File, d.Resource, d.Kind, d.Field, d.ApiType)
+	case RemovedField:
+		return fmt.Sprintf("%s: %s %s `%s` (%s)", d.File, d.Resource, d.Kind, d.Field, d.Type)
+	}
+	return fmt.Sprintf("%s: %s %s of `%s` from %s to %s", d.File, d.Resource, d.Kind, d.Field, d.Type, d.ApiType)
+}
+
+// Diff compares the resources of the products in mmv1/products with the
+// OpenAPI documents in the parser's folder, and reports the fields that were
+// added to, removed from or changed in the APIs. If patchPath is set, a patch
+// adding the missing fields to the YAML files of the resources is written to
+// it.
+func (parser Parser) Diff(l *loader.Loader, patchPath string) []FieldDiff {
+	files, err := os.ReadDir(parser.Folder)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	var diffs []FieldDiff
+	for _, file := range files {
+		filePath := path.Join(parser.Folder, file.Name())
+		doc := loadDocument(filePath)
+		productName := productNameOf(filePath)
+
+		// Products are loaded at the version of the document, so that only
+		// the fields in that version are compared.
+		productLoader := *l
+		productLoader.Version = versionName(doc)
+		p, err := productLoader.LoadProduct(fmt.Sprintf("products/%s", productName))
+		var versionErr *loader.ErrProductVersionNotFound
+		if errors.As(err, &versionErr) {
+			log.Printf("Skipping %s, as product %s doesn't exist at version %s", filePath, productName, productLoader.Version)
+			continue
+		}
+		if err != nil {
+			log.Fatalf("error loading product %s: %v", productName, err)
+		}
+
+		for _, pathArray := range findResources(doc) {
+			resourcePath, resourceName := pathArray[0], pathArray[1]
+			resource := findResource(p, resourcePath, resourceName)
+			if resource == nil {
+				diffs = append(diffs, FieldDiff{File: p.PackagePath, Resource: resourceName, Kind: MissingResource})
+				continue
+			}
+			diffs = append(diffs, DiffResource(resource, resourcePath, doc)...)
+		}
+	}
+
+	if patchPath != "" {
+		patch, err := buildPatch(diffs)
+		if err != nil {
+			log.Fatalf("error building patch: %v", err)
+		}
+		if err := os.WriteFile(patchPath, patch, 0644); err != nil {
+			log.Fatalf("error writing patch %s: %v", patchPath, err)
+		}
+	}
+	return diffs
+}
+
+// findResource returns the resource of a product generated from the given
+// collection of an API, matched by name or base URL.
+func findResource(p *api.Product, resourcePath, resourceName string) *api.Resource {
+	url := baseUrl(resourcePath)
+	for _, r := range p.Objects {
+		if r.Name == resourceName || r.BaseUrl == url {
+			return r
+		}
+	}
+	return nil
+}
+
+// DiffResource compares the fields of an MMv1 resource with the schema of the
+// create method of the given collection of an OpenAPI document.
+func DiffResource(resource *api.Resource, resourcePath string, doc *openapi3.T) []FieldDiff {
+	properties := parseOpenApi(resourcePath, resource.Name, doc)[1].([]*api.Type)
+	version := ""
+	if v := versionName(doc); v != "ga" {
+		version = v
+	}
+
+	var diffs []FieldDiff
+	for _, d := range diffFields("", []string{"properties"}, resource.Properties, properties, version) {
+		d.File = resource.SourceYamlFile
+		d.Resource = resource.Name
+		diffs = append(diffs, d)
+	}
+	return diffs
+}
+
+// diffFields compares configured fields with the fields generated from their
+// API schema. prefix is the API path of their parent field and parent the
+// YAML path of their list.
+func diffFields(prefix string, parent []string, configured, generated []*api.Type, version string) []FieldDiff {
+	var diffs []FieldDiff
+	byApiName := make(map[string]*api.Type)
+	for _, c := range configured {
+		if isApiField(c) {
+			byApiName[apiNameOf(c)] = c
+		}
+	}
+
+	for _, g := range generated {
+		if g.Name == "" {
+			continue
+		}
+		field := strings.TrimPrefix(prefix+"."+g.Name, ".")
+		c, ok := byApiName[g.Name]
+		if !ok {
+			if version != "" {
+				g.MinVersion = version
+			}
+			diffs = append(diffs, FieldDiff{Kind: MissingField, Field: field, ApiType: g.Type, missing: g, parent: parent})
+			continue
+		}
+		delete(byApiName, g.Name)
+
+		if !compatibleTypes(c.Type, g.Type) {
+			diffs = append(diffs, FieldDiff{Kind: ChangedType, Field: field, Type: c.Type, ApiType: g.Type})
+			continue
+		}
+		path := append(slices.Clone(parent), c.Name)
+		switch {
+		case c.IsA("NestedObject"):
+			diffs = append(diffs, diffFields(field, append(path, "properties"), c.Properties, g.Properties, version)...)
+		case c.IsA("Array") && c.ItemType != nil && g.ItemType != nil:
+			if !compatibleTypes(c.ItemType.Type, g.ItemType.Type) {
+				diffs = append(diffs, FieldDiff{Kind: ChangedType, Field: field + "[]", Type: c.ItemType.Type, ApiType: g.ItemType.Type})
+			} else if c.ItemType.IsA("NestedObject") {
+				diffs = append(diffs, diffFields(field, append(path, "item_type", "properties"), c.ItemType.Properties, g.ItemType.Properties, version)...)
+			}
+		case c.IsA("Map") && c.ValueType != nil && g.ValueType != nil:
+			diffs = append(diffs, diffFields(field, append(path, "value_type", "properties"), c.ValueType.Properties, g.ValueType.Properties, version)...)
+		}
+	}
+
+	for _, c := range configured {
+		apiName := apiNameOf(c)
+		if byApiName[apiName] != c {
+			continue
+		}
+		// Fields of a less stable version aren't expected in the API
+		if version == "" && c.MinVersion != "" && c.MinVersion != "ga" {
+			continue
+		}
+		field := strings.TrimPrefix(prefix+"."+apiName, ".")
+		diffs = append(diffs, FieldDiff{Kind: RemovedField, Field: field, Type: c.Type})
+	}
+	return diffs
+}
+
+func apiNameOf(t *api.Type) string {
+	if t.ApiName != "" {
+		return t.ApiName
+	}
+	return t.Name
+}
+
+// isApiField reports whether a configured field is expected to be found in the
+// API schema, unlike fields added by MMv1 or the provider.
+func isApiField(t *api.Type) bool {
+	if t.UrlParamOnly || t.ClientSide {
+		return false
+	}
+	return !t.IsA("KeyValueEffectiveLabels") && !t.IsA("KeyValueTerraformLabels")
+}
+
+// compatibleTypes reports whether a field configured with one MMv1 type can
+// represent a field generated with another.
+func compatibleTypes(configured, generated string) bool {
+	family := func(t string) string {
+		switch t {
+		case "Enum", "ResourceRef", "Time", "Fingerprint", "Integer":
+			// Integers are strings in the JSON of int64 fields
+			return "String"
+		case "KeyValueLabels", "KeyValueAnnotations", "KeyValuePairs":
+			return "KeyValuePairs"
+		}
+		return t
+	}
+	return configured == generated || family(configured) == family(generated) || family(configured) == "String" && generated == "Integer"
+}
+
+// buildPatch returns a unified diff adding the missing fields to the YAML
+// files of their resources, at the end of the list of fields they're missing
+// from. Paths are relative to the mmv1 directory.
+func buildPatch(diffs []FieldDiff) ([]byte, error) {
+	byFile := make(map[string][]FieldDiff)
+	var files []string
+	for _, d := range diffs {
+		if d.missing == nil || d.File == "" {
+			continue
+		}
+		if _, ok := byFile[d.File]; !ok {
+			files = append(files, d.File)
+		}
+		byFile[d.File] = append(byFile[d.File], d)
+	}
+	sort.Strings(files)
+
+	var patch bytes.Buffer
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		inserts, err := fieldInsertions(contents, byFile[file])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		patch.WriteString(unifiedDiff(file, splitLines(contents), inserts))
+	}
+	return patch.Bytes(), nil
+}
+
+// fieldInsertions returns the lines to insert into a YAML file for missing
+// fields, keyed by the index of the line they are inserted before.
+func fieldInsertions(contents []byte, diffs []FieldDiff) (map[int][]string, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(contents, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("empty file")
+	}
+	lines := splitLines(contents)
+
+	inserts := make(map[int][]string)
+	for _, d := range diffs {
+		list := findYamlPath(doc.Content[0], d.parent)
+		if list == nil || list.Kind != yaml.SequenceNode || len(list.Content) == 0 {
+			log.Printf("%s: cannot find where to insert `%s`, add it by hand", d.File, d.Field)
+			continue
+		}
+		last := list.Content[len(list.Content)-1]
+		indent := last.Column - 3
+
+		// The last item ends before the next line that is indented at most as
+		// much as its `-`.
+		end := last.Line
+		for i := last.Line; i < len(lines); i++ {
+			line := strings.TrimRight(lines[i], "\n")
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			if len(line)-len(strings.TrimLeft(line, " ")) <= indent {
+				break
+			}
+			end = i + 1
+		}
+
+		var field bytes.Buffer
+		encoder := yaml.NewEncoder(&field)
+		encoder.SetIndent(2)
+		if err := encoder.Encode([]*api.Type{d.missing}); err != nil {
+			return nil, err
+		}
+		for _, line := range strings.SplitAfter(strings.TrimSuffix(field.String(), "\n"), "\n") {
+			inserts[end] = append(inserts[end], strings.Repeat(" ", indent)+strings.TrimSuffix(line, "\n")+"\n")
+		}
+	}
+	return inserts, nil
+}
+
+// splitLines splits the contents of a file into lines, keeping their line
+// endings.
+func splitLines(contents []byte) []string {
+	lines := strings.SplitAfter(string(contents), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// findYamlPath returns the node at a path of mapping keys and names of
+// sequence items.
+func findYamlPath(node *yaml.Node, steps []string) *yaml.Node {
+	for _, step := range steps {
+		switch node.Kind {
+		case yaml.MappingNode:
+			i := mappingIndex(node, step)
+			if i < 0 {
+				return nil
+			}
+			node = node.Content[i+1]
+		case yaml.SequenceNode:
+			node = findNamed(node, step)
+			if node == nil {
+				return nil
+			}
+		default:
+			return nil
+		}
+	}
+	return node
+}
+
+// unifiedDiff returns a unified diff inserting lines into a file, with three
+// lines of context around each insertion.
+func unifiedDiff(file string, lines []string, inserts map[int][]string) string {
+	const context = 3
+	var at []int
+	for i := range inserts {
+		at = append(at, i)
+	}
+	if len(at) == 0 {
+		return ""
+	}
+	sort.Ints(at)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- a/%s\n+++ b/%s\n", file, file)
+	offset := 0
+	for len(at) > 0 {
+		// Group insertions whose context overlaps into one hunk
+		start := max(at[0]-context, 0)
+		n := 1
+		for n < len(at) && at[n]-at[n-1] <= 2*context {
+			n++
+		}
+		end := min(at[n-1]+context, len(lines))
+
+		var body strings.Builder
+		added := 0
+		for i := start; i <= end; i++ {
+			for _, line := range inserts[i] {
+				body.WriteString("+" + line)
+				added++
+			}
+			if i < end {
+				body.WriteString(" " + strings.TrimSuffix(lines[i], "\n") + "\n")
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", start+1, end-start, start+1+offset, end-start+added)
+		sb.WriteString(body.String())
+		offset += added
+		at = at[n:]
+	}
+	return sb.String()
+}
+
+// FormatDiffs returns a report of the differences, one per line.
+func FormatDiffs(diffs []FieldDiff) string {
+	var lines []string
+	for _, d := range diffs {
+		lines = append(lines, d.String())
+	}
+	return strings.Join(lines, "\n")
+}
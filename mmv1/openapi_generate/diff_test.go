@@ -0,0 +1,119 @@
+package openapi_generate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func TestDiffFields(t *testing.T) {
+	configured := []*api.Type{
+		{Name: "name", Type: "String"},
+		{Name: "size", Type: "Integer"},
+		{Name: "color", Type: "Boolean"},
+		{Name: "effectiveLabels", ApiName: "labels", Type: "KeyValueEffectiveLabels"},
+		{Name: "legacy", Type: "String"},
+		{Name: "preview", Type: "String", MinVersion: "beta"},
+		{Name: "zone", Type: "String", UrlParamOnly: true},
+		{
+			Name: "config",
+			Type: "NestedObject",
+			Properties: []*api.Type{
+				{Name: "enabled", Type: "Boolean"},
+			},
+		},
+	}
+	generated := []*api.Type{
+		{Name: "name", Type: "String"},
+		{Name: "size", Type: "String"},
+		{Name: "color", Type: "Enum"},
+		{Name: "labels", Type: "KeyValueLabels"},
+		{
+			Name: "config",
+			Type: "NestedObject",
+			Properties: []*api.Type{
+				{Name: "enabled", Type: "Boolean"},
+				{Name: "mode", Type: "Enum"},
+			},
+		},
+	}
+
+	var got []string
+	for _, d := range diffFields("", []string{"properties"}, configured, generated, "") {
+		got = append(got, d.Kind+" "+d.Field)
+	}
+	expected := []string{
+		"changed type color",
+		"missing field labels",
+		"missing field config.mode",
+		"removed field legacy",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v to be %v", got, expected)
+	}
+}
+
+func TestBuildPatch(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "Widget.yaml")
+	contents := `# License
+---
+name: 'Widget'
+properties:
+  - name: 'config'
+    type: NestedObject
+    properties:
+      - name: 'enabled'
+        type: Boolean
+        description: |
+          Whether the widget is enabled.
+
+          Defaults to false.
+  - name: 'size'
+    type: Integer
+# Trailing comment
+`
+	if err := os.WriteFile(file, []byte(contents), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	diffs := []FieldDiff{
+		{
+			File:    file,
+			Kind:    MissingField,
+			missing: &api.Type{Name: "mode", Type: "String", Description: "The mode.", MinVersion: "beta"},
+			parent:  []string{"properties", "config", "properties"},
+		},
+		{
+			File:    file,
+			Kind:    MissingField,
+			missing: &api.Type{Name: "color", Type: "String", Description: "The color."},
+			parent:  []string{"properties"},
+		},
+	}
+	patch, err := buildPatch(diffs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "--- a/" + file + "\n+++ b/" + file + "\n" +
+		`@@ -11,6 +11,13 @@
+           Whether the widget is enabled.
+` + " \n" + `           Defaults to false.
++      - name: mode
++        type: String
++        description: The mode.
++        min_version: beta
+   - name: 'size'
+     type: Integer
++  - name: color
++    type: String
++    description: The color.
+ # Trailing comment
+`
+	if string(patch) != expected {
+		t.Errorf("expected\n%s\nto be\n%s", patch, expected)
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// discoveryDocument is a Google API Discovery document, as described at
+// https://developers.google.com/discovery/v1/reference/apis.
+type discoveryDocument struct {
+	DiscoveryVersion string                       `json:"discoveryVersion"`
+	Version          string                       `json:"version"`
+	Title            string                       `json:"title"`
+	RootUrl          string                       `json:"rootUrl"`
+	ServicePath      string                       `json:"servicePath"`
+	Schemas          map[string]*discoverySchema  `json:"schemas"`
+	Resources        map[string]discoveryResource `json:"resources"`
+}
+
+type discoveryResource struct {
+	Methods   map[string]discoveryMethod   `json:"methods"`
+	Resources map[string]discoveryResource `json:"resources"`
+}
+
+type discoveryMethod struct {
+	Id         string                      `json:"id"`
+	HttpMethod string                      `json:"httpMethod"`
+	Path       string                      `json:"path"`
+	FlatPath   string                      `json:"flatPath"`
+	Parameters map[string]*discoverySchema `json:"parameters"`
+	Request    *discoverySchema            `json:"request"`
+	Response   *discoverySchema            `json:"response"`
+}
+
+type discoverySchema struct {
+	Ref                  string                      `json:"$ref"`
+	Type                 string                      `json:"type"`
+	Format               string                      `json:"format"`
+	Description          string                      `json:"description"`
+	Location             string                      `json:"location"`
+	Pattern              string                      `json:"pattern"`
+	Required             bool                        `json:"required"`
+	ReadOnly             bool                        `json:"readOnly"`
+	Deprecated           bool                        `json:"deprecated"`
+	Enum                 []string                    `json:"enum"`
+	Properties           map[string]*discoverySchema `json:"properties"`
+	Items                *discoverySchema            `json:"items"`
+	AdditionalProperties *discoverySchema            `json:"additionalProperties"`
+}
+
+// fieldBehaviorPrefixes map the prefixes Google APIs add to the descriptions of
+// fields to their google.api.field_behavior annotations, which Discovery
+// documents don't include.
+var fieldBehaviorPrefixes = map[string]string{
+	"Required.":    "REQUIRED",
+	"Output only.": "OUTPUT_ONLY",
+	"Immutable.":   "IMMUTABLE",
+	"Input only.":  "INPUT_ONLY",
+	"Identifier.":  "IDENTIFIER",
+}
+
+var flatPathSegmentRegex = regexp.MustCompile(`([^/]+)/\[\^/\]\+`)
+
+var pathParamRegex = regexp.MustCompile(`\{(\w+)\}`)
+
+// isDiscoveryDocument reports whether the contents of a file are a Discovery
+// document rather than an OpenAPI document.
+func isDiscoveryDocument(contents []byte) bool {
+	var doc struct {
+		DiscoveryVersion string `json:"discoveryVersion"`
+	}
+	return json.Unmarshal(contents, &doc) == nil && doc.DiscoveryVersion != ""
+}
+
+// convertDiscoveryDocument converts a Discovery document to an OpenAPI
+// document, so that it can be imported like one. Methods are mapped to the
+// operations of their flat path, such as
+// `/v1/projects/{projectsId}/locations/{locationsId}/widgets`, and named like
+// `CreateWidget` after their verb and the schema of their resource.
+func convertDiscoveryDocument(contents []byte) (*openapi3.T, error) {
+	var d discoveryDocument
+	if err := json.Unmarshal(contents, &d); err != nil {
+		return nil, err
+	}
+
+	doc := &openapi3.T{
+		OpenAPI: "3.0.0",
+		Info:    &openapi3.Info{Title: d.Title, Version: d.Version},
+		Servers: openapi3.Servers{{URL: strings.TrimSuffix(d.RootUrl+d.ServicePath, "/")}},
+		Paths:   openapi3.NewPaths(),
+		Components: &openapi3.Components{
+			Schemas: make(openapi3.Schemas),
+		},
+	}
+
+	// Schemas are created before being filled in, as they may reference each
+	// other.
+	for id := range d.Schemas {
+		doc.Components.Schemas[id] = &openapi3.SchemaRef{Ref: "#/components/schemas/" + id, Value: openapi3.NewSchema()}
+	}
+	for id, s := range d.Schemas {
+		*doc.Components.Schemas[id].Value = *convertDiscoverySchema(s, doc.Components.Schemas).Value
+	}
+
+	addDiscoveryMethods(doc, d.Resources)
+	return doc, nil
+}
+
+func addDiscoveryMethods(doc *openapi3.T, resources map[string]discoveryResource) {
+	for _, name := range sortedKeys(resources) {
+		resource := resources[name]
+		for _, methodName := range sortedKeys(resource.Methods) {
+			method := resource.Methods[methodName]
+			path := "/" + discoveryFlatPath(method)
+			item := doc.Paths.Value(path)
+			if item == nil {
+				item = &openapi3.PathItem{}
+				doc.Paths.Set(path, item)
+			}
+			item.SetOperation(method.HttpMethod, discoveryOperation(methodName, method, doc.Components.Schemas))
+		}
+		addDiscoveryMethods(doc, resource.Resources)
+	}
+}
+
+// discoveryFlatPath returns the path of a method with a named parameter for
+// each resource ID, expanding reserved expansions such as `{+name}` from the
+// pattern of their parameter.
+func discoveryFlatPath(method discoveryMethod) string {
+	if method.FlatPath != "" {
+		return method.FlatPath
+	}
+	path := method.Path
+	for name, param := range method.Parameters {
+		if param.Location != "path" || param.Pattern == "" {
+			continue
+		}
+		// A pattern like `^projects/[^/]+/locations/[^/]+$` expands to
+		// `projects/{projectsId}/locations/{locationsId}`
+		pattern := strings.TrimSuffix(strings.TrimPrefix(param.Pattern, "^"), "$")
+		expanded := flatPathSegmentRegex.ReplaceAllString(pattern, "$1/{${1}Id}")
+		path = strings.ReplaceAll(path, "{+"+name+"}", expanded)
+	}
+	return path
+}
+
+func discoveryOperation(name string, method discoveryMethod, schemas openapi3.Schemas) *openapi3.Operation {
+	op := &openapi3.Operation{
+		OperationID: google.Camelize(name, "upper") + discoveryResourceName(method),
+		Responses:   openapi3.NewResponses(),
+	}
+
+	// Path parameters are named after the flat path rather than the method
+	for _, match := range pathParamRegex.FindAllStringSubmatch(discoveryFlatPath(method), -1) {
+		op.Parameters = append(op.Parameters, &openapi3.ParameterRef{Value: &openapi3.Parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   openapi3.NewStringSchema().NewRef(),
+		}})
+	}
+	for _, paramName := range sortedKeys(method.Parameters) {
+		param := method.Parameters[paramName]
+		if param.Location != "query" {
+			continue
+		}
+		op.Parameters = append(op.Parameters, &openapi3.ParameterRef{Value: &openapi3.Parameter{
+			Name:        paramName,
+			In:          "query",
+			Description: param.Description,
+			Required:    param.Required,
+			Schema:      convertDiscoverySchema(param, schemas),
+		}})
+	}
+
+	if method.Request != nil {
+		op.RequestBody = &openapi3.RequestBodyRef{Value: openapi3.NewRequestBody().WithJSONSchemaRef(convertDiscoverySchema(method.Request, schemas))}
+	}
+	response := openapi3.NewResponse().WithDescription("OK")
+	if method.Response != nil {
+		response = response.WithJSONSchemaRef(convertDiscoverySchema(method.Response, schemas))
+	}
+	op.Responses.Set("200", &openapi3.ResponseRef{Value: response})
+	return op
+}
+
+// discoveryResourceName returns the name of the resource a method acts on: the
+// schema of its request or response, unless it's a long-running operation, or
+// the singular name of its collection otherwise.
+func discoveryResourceName(method discoveryMethod) string {
+	for _, s := range []*discoverySchema{method.Request, method.Response} {
+		if s != nil && s.Ref != "" && !operationSchemaRegex.MatchString(s.Ref) && s.Ref != "Empty" {
+			return s.Ref
+		}
+	}
+	parts := strings.Split(method.Id, ".")
+	if len(parts) < 2 {
+		return ""
+	}
+	return google.Camelize(singular(parts[len(parts)-2]), "upper")
+}
+
+func convertDiscoverySchema(s *discoverySchema, schemas openapi3.Schemas) *openapi3.SchemaRef {
+	if s.Ref != "" {
+		ref, ok := schemas[s.Ref]
+		if !ok {
+			return openapi3.NewObjectSchema().NewRef()
+		}
+		if s.Description == "" && !s.ReadOnly && !s.Deprecated {
+			return ref
+		}
+		// The annotations of a field referencing a schema apply to that field
+		// only, so they're kept alongside the reference like in OpenAPI.
+		schema := &openapi3.Schema{AllOf: openapi3.SchemaRefs{ref}}
+		annotateDiscoverySchema(schema, s)
+		return schema.NewRef()
+	}
+
+	schema := openapi3.NewSchema()
+	schemaType := s.Type
+	if schemaType == "any" || schemaType == "" {
+		schemaType = "object"
+	}
+	schema.Type = &openapi3.Types{schemaType}
+	schema.Format = s.Format
+	for _, e := range s.Enum {
+		schema.Enum = append(schema.Enum, e)
+	}
+	annotateDiscoverySchema(schema, s)
+
+	if len(s.Properties) > 0 {
+		schema.Properties = make(openapi3.Schemas)
+		for name, p := range s.Properties {
+			schema.Properties[name] = convertDiscoverySchema(p, schemas)
+		}
+	}
+	if s.Items != nil {
+		schema.Items = convertDiscoverySchema(s.Items, schemas)
+	}
+	if s.AdditionalProperties != nil {
+		schema.AdditionalProperties = openapi3.AdditionalProperties{Schema: convertDiscoverySchema(s.AdditionalProperties, schemas)}
+	}
+	return schema.NewRef()
+}
+
+// annotateDiscoverySchema copies the description of a Discovery schema to an
+// OpenAPI one, along with the field behaviors found at its start.
+func annotateDiscoverySchema(schema *openapi3.Schema, s *discoverySchema) {
+	schema.Description = s.Description
+	schema.ReadOnly = s.ReadOnly
+	schema.Deprecated = s.Deprecated
+
+	var behaviors []any
+	description := s.Description
+	for found := true; found; {
+		found = false
+		for prefix, behavior := range fieldBehaviorPrefixes {
+			if rest, ok := strings.CutPrefix(description, prefix); ok {
+				behaviors = append(behaviors, behavior)
+				description = strings.TrimLeft(rest, " ")
+				found = true
+			}
+		}
+	}
+	if len(behaviors) > 0 {
+		schema.Extensions = map[string]any{"x-google-field-behavior": behaviors}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,101 @@
+package openapi_generate
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+func TestConvertDiscoveryDocument(t *testing.T) {
+	contents, err := os.ReadFile("./test_data/widgets_v1_discovery.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isDiscoveryDocument(contents) {
+		t.Fatal("expected a Discovery document")
+	}
+	discovery, err := convertDiscoveryDocument(contents)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	loader := &openapi3.Loader{Context: ctx, IsExternalRefsAllowed: true}
+	openapi, err := loader.LoadFromFile("./test_data/widgets_v1_openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := discovery.Servers[0].URL, openapi.Servers[0].URL; got != want {
+		t.Errorf("expected server %q to be %q", got, want)
+	}
+	if got, want := findResources(discovery), findResources(openapi); len(got) != len(want) {
+		t.Errorf("expected resources %v to be %v", got, want)
+	}
+
+	// The resources built from both documents only differ by the resource
+	// references, which Discovery documents don't include.
+	resourcePath := "/v1/projects/{projectsId}/locations/{locationsId}/widgets"
+	fromDiscovery := buildResource("widgets_v1_discovery.json", resourcePath, "Widget", discovery)
+	fromOpenapi := buildResource("widgets_v1_openapi.yaml", resourcePath, "Widget", openapi)
+	for _, p := range fromOpenapi.Properties {
+		p.Resource = ""
+	}
+	got, err := yaml.Marshal(fromDiscovery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := yaml.Marshal(fromOpenapi)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("expected\n%s\nto be\n%s", got, want)
+	}
+}
+
+func TestDiscoveryFlatPath(t *testing.T) {
+	cases := []struct {
+		description string
+		method      discoveryMethod
+		expected    string
+	}{
+		{
+			description: "flat path",
+			method: discoveryMethod{
+				Path:     "v1/{+name}",
+				FlatPath: "v1/projects/{projectsId}/widgets/{widgetsId}",
+			},
+			expected: "v1/projects/{projectsId}/widgets/{widgetsId}",
+		},
+		{
+			description: "reserved expansion",
+			method: discoveryMethod{
+				Path: "v1/{+parent}/widgets",
+				Parameters: map[string]*discoverySchema{
+					"parent": {Location: "path", Pattern: "^projects/[^/]+/locations/[^/]+$"},
+				},
+			},
+			expected: "v1/projects/{projectsId}/locations/{locationsId}/widgets",
+		},
+		{
+			description: "simple expansion",
+			method: discoveryMethod{
+				Path: "projects/{project}/widgets",
+				Parameters: map[string]*discoverySchema{
+					"project": {Location: "path"},
+				},
+			},
+			expected: "projects/{project}/widgets",
+		},
+	}
+
+	for _, tc := range cases {
+		if got := discoveryFlatPath(tc.method); got != tc.expected {
+			t.Errorf("%s: expected %q to be %q", tc.description, got, tc.expected)
+		}
+	}
+}
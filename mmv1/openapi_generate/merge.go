@@ -0,0 +1,259 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// writeYaml writes a product or resource to a YAML file. If the file already
+// exists, the generated configuration is merged into it rather than replacing
+// it, so that changes made to the file by hand are kept. version is the
+// version of the API the configuration was generated from.
+func writeYaml(filePath string, obj any, version string) {
+	var generated yaml.Node
+	if err := generated.Encode(obj); err != nil {
+		log.Fatalf("Failed to encode %s: %v", filePath, err)
+	}
+
+	existing, err := os.ReadFile(filePath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Fatalf("error reading %s: %v", filePath, err)
+	}
+
+	contents, versioned, err := mergeYaml(existing, &generated, version)
+	if err != nil {
+		log.Fatalf("error merging %s: %v", filePath, err)
+	}
+	for _, field := range versioned {
+		if field == "" {
+			field = "the resource"
+		} else {
+			field = fmt.Sprintf("field `%s`", field)
+		}
+		log.Printf("WARNING: %s: %s is in the ga API but has a `min_version`, which must be removed by hand if it was promoted to ga", filePath, field)
+	}
+	if err := os.WriteFile(filePath, contents, 0644); err != nil {
+		log.Fatalf("error writing %s: %v", filePath, err)
+	}
+
+	var merged yaml.Node
+	if err := yaml.Unmarshal(contents, &merged); err != nil {
+		log.Fatalf("error reading back %s: %v", filePath, err)
+	}
+	for _, field := range mapsMissingKeyName(&merged, "") {
+		log.Printf("ERROR: %s: map field `%s` has no `key_name`, which must be set by hand to the name of the field holding the map keys", filePath, field)
+	}
+}
+
+// mapsMissingKeyName returns the paths of the fields of type Map without a
+// `key_name` under a node, such as `properties.parts`.
+func mapsMissingKeyName(node *yaml.Node, path string) []string {
+	var missing []string
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, item := range node.Content {
+			itemPath := path
+			if name := mappingValue(item, "name"); name != "" && path != "" {
+				itemPath = path + "." + name
+			}
+			missing = append(missing, mapsMissingKeyName(item, itemPath)...)
+		}
+	case yaml.MappingNode:
+		if mappingValue(node, "type") == "Map" && mappingValue(node, "key_name") == "" {
+			missing = append(missing, path)
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+			missing = append(missing, mapsMissingKeyName(node.Content[i+1], childPath)...)
+		}
+	}
+	return missing
+}
+
+// mergeYaml merges generated configuration into the contents of an existing
+// file, which may be empty:
+//   - values already set in the file are kept, as they may have been edited
+//   - fields missing from the file are added, with a `min_version` if version
+//     isn't `ga`
+//
+// Fields removed from the API are kept, as they need to be removed by hand
+// following the breaking change policy. Likewise, the `min_version` of fields
+// found in `ga` is kept, as it may have been set on purpose; the paths of these
+// fields are returned to be reported, with an empty path for the resource.
+func mergeYaml(existing []byte, generated *yaml.Node, version string) ([]byte, []string, error) {
+	prefix, body := splitHeader(existing)
+	var doc yaml.Node
+	if err := yaml.Unmarshal(body, &doc); err != nil {
+		return nil, nil, err
+	}
+
+	var out bytes.Buffer
+	var versioned []string
+	if len(doc.Content) == 0 {
+		out.Write(header)
+		doc = *generated
+	} else {
+		out.Write(prefix)
+		versioned = mergeYamlNode(doc.Content[0], generated, version, "", true)
+	}
+
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, nil, err
+	}
+	return out.Bytes(), versioned, nil
+}
+
+// splitHeader splits the comments and document start marker at the top of a
+// file, such as its license header, from its contents so that they can be
+// written back unchanged.
+func splitHeader(contents []byte) ([]byte, []byte) {
+	rest := contents
+	for len(rest) > 0 {
+		line, next, _ := bytes.Cut(rest, []byte("\n"))
+		trimmed := bytes.TrimSpace(line)
+		if bytes.Equal(trimmed, []byte("---")) {
+			i := len(contents) - len(next)
+			return contents[:i], contents[i:]
+		}
+		if len(trimmed) > 0 && trimmed[0] != '#' {
+			break
+		}
+		rest = next
+	}
+	return nil, contents
+}
+
+// mergeYamlNode merges a generated node at path into an existing one, and
+// returns the paths of the fields found in `ga` with a `min_version`. versioned
+// is true for the nodes of a resource and its fields, which can have a
+// `min_version`.
+func mergeYamlNode(existing, generated *yaml.Node, version, path string, versioned bool) []string {
+	var found []string
+	switch {
+	case existing.Kind == yaml.MappingNode && generated.Kind == yaml.MappingNode:
+		if versioned && version == "ga" && mappingIndex(existing, "min_version") >= 0 {
+			found = append(found, path)
+		}
+		for i := 0; i+1 < len(generated.Content); i += 2 {
+			key, value := generated.Content[i], generated.Content[i+1]
+			j := mappingIndex(existing, key.Value)
+			if j < 0 {
+				existing.Content = append(existing.Content, key, value)
+				continue
+			}
+			childPath := key.Value
+			if path != "" {
+				childPath = path + "." + key.Value
+			}
+			fields := key.Value == "properties" || key.Value == "parameters"
+			if fields || key.Value == "item_type" || key.Value == "value_type" {
+				found = append(found, mergeFields(existing.Content[j+1], value, version, childPath)...)
+			} else {
+				mergeYamlNode(existing.Content[j+1], value, version, childPath, false)
+			}
+		}
+	case existing.Kind == yaml.SequenceNode && generated.Kind == yaml.SequenceNode:
+		// Items of lists of objects, such as versions, are matched by name
+		for _, item := range generated.Content {
+			name := mappingValue(item, "name")
+			if name == "" {
+				continue
+			}
+			if match := findNamed(existing, name); match != nil {
+				mergeYamlNode(match, item, version, path, false)
+			} else {
+				existing.Content = append(existing.Content, item)
+			}
+		}
+	}
+	return found
+}
+
+// mergeFields merges a generated list of fields at path, or the item or value
+// type of a field, into an existing one, and returns the paths of the fields
+// found in `ga` with a `min_version`.
+func mergeFields(existing, generated *yaml.Node, version, path string) []string {
+	if existing.Kind == yaml.MappingNode {
+		return mergeYamlNode(existing, generated, version, path, true)
+	}
+	if existing.Kind != yaml.SequenceNode || generated.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var found []string
+	for _, field := range generated.Content {
+		name := mappingValue(field, "name")
+		if match := findNamed(existing, name); match != nil {
+			found = append(found, mergeYamlNode(match, field, version, path+"."+name, true)...)
+			continue
+		}
+		if version != "ga" && mappingIndex(field, "min_version") < 0 {
+			field.Content = append(field.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: "min_version"},
+				&yaml.Node{Kind: yaml.ScalarNode, Value: version, Style: yaml.SingleQuotedStyle},
+			)
+		}
+		existing.Content = append(existing.Content, field)
+	}
+	return found
+}
+
+// mappingIndex returns the index of a key in a mapping node, or -1.
+func mappingIndex(node *yaml.Node, key string) int {
+	if node.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// mappingValue returns the scalar value of a key of a mapping node.
+func mappingValue(node *yaml.Node, key string) string {
+	if i := mappingIndex(node, key); i >= 0 {
+		return node.Content[i+1].Value
+	}
+	return ""
+}
+
+// findNamed returns the item of a sequence node with the given name.
+func findNamed(node *yaml.Node, name string) *yaml.Node {
+	for _, item := range node.Content {
+		if mappingValue(item, "name") == name {
+			return item
+		}
+	}
+	return nil
+}
+
+func fileExists(filePath string) bool {
+	_, err := os.Stat(filePath)
+	return err == nil
+}
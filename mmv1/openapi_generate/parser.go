@@ -16,7 +16,6 @@
 package openapi_generate
 
 import (
-	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
@@ -35,7 +34,6 @@ import (
 	r "github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"github.com/getkin/kin-openapi/openapi3"
-	"gopkg.in/yaml.v3"
 
 	_ "embed"
 )
@@ -79,53 +77,76 @@ func (parser Parser) Run() {
 		log.Fatalf("No OpenAPI files found in %s", parser.Folder)
 	}
 
+	// Documents are written from the most to the least stable version, so
+	// that fields only found in a less stable version are marked with its
+	// `min_version` when merged into the files written for a more stable one.
+	var paths []string
 	for _, file := range files {
-		parser.WriteYaml(path.Join(parser.Folder, file))
+		paths = append(paths, path.Join(parser.Folder, file))
+	}
+	docs := make(map[string]*openapi3.T)
+	for _, filePath := range paths {
+		docs[filePath] = loadDocument(filePath)
+	}
+	slices.SortStableFunc(paths, func(a, b string) int {
+		return slices.Index(product.ORDER, versionName(docs[a])) - slices.Index(product.ORDER, versionName(docs[b]))
+	})
+
+	for _, filePath := range paths {
+		parser.writeDocument(filePath, docs[filePath])
 	}
 }
 
-func (parser Parser) WriteYaml(filePath string) {
+func loadDocument(filePath string) *openapi3.T {
 	log.Printf("Reading from file path %s", filePath)
 
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		contents, err := os.ReadFile(filePath)
+		if err != nil {
+			log.Fatalf("error reading %s: %v", filePath, err)
+		}
+		if isDiscoveryDocument(contents) {
+			doc, err := convertDiscoveryDocument(contents)
+			if err != nil {
+				log.Fatalf("error loading %s: %v", filePath, err)
+			}
+			return doc
+		}
+	}
+
 	ctx := context.Background()
 	loader := &openapi3.Loader{Context: ctx, IsExternalRefsAllowed: true}
-	doc, _ := loader.LoadFromFile(filePath)
+	doc, err := loader.LoadFromFile(filePath)
+	if err != nil {
+		log.Fatalf("error loading %s: %v", filePath, err)
+	}
 	_ = doc.Validate(ctx)
+	return doc
+}
+
+func (parser Parser) WriteYaml(filePath string) {
+	parser.writeDocument(filePath, loadDocument(filePath))
+}
 
+func (parser Parser) writeDocument(filePath string, doc *openapi3.T) {
+	version := versionName(doc)
 	resourcePaths := findResources(doc)
-	productPath := buildProduct(filePath, parser.Output, doc, header)
+	productPath := buildProduct(filePath, parser.Output, doc)
 
 	log.Printf("Generated product %+v/product.yaml", productPath)
+	var resourceNames []string
+	for _, pathArray := range resourcePaths {
+		resourceNames = append(resourceNames, pathArray[1])
+	}
 	for _, pathArray := range resourcePaths {
 		resource := buildResource(filePath, pathArray[0], pathArray[1], doc)
+		resolveResourceRefs(google.Concat(resource.Parameters, resource.Properties), resourceNames)
 
-		// marshal method
-		var yamlContent bytes.Buffer
 		resourceOutPathMarshal := filepath.Join(productPath, fmt.Sprintf("%s.yaml", resource.Name))
-		encoder := yaml.NewEncoder(&yamlContent)
-		encoder.SetIndent(2)
-
-		err := encoder.Encode(&resource)
-		if err != nil {
-			log.Fatalf("Failed to encode: %v", err)
-		}
-
-		f, err := os.Create(resourceOutPathMarshal)
-		if err != nil {
-			log.Fatalf("error creating resource file %v", err)
-		}
-		_, err = f.Write(header)
-		if err != nil {
-			log.Fatalf("error writing resource file header %v", err)
-		}
-		_, err = f.Write(yamlContent.Bytes())
-		if err != nil {
-			log.Fatalf("error writing resource file %v", err)
-		}
-		err = f.Close()
-		if err != nil {
-			log.Fatalf("error closing resource file %v", err)
+		if version != "ga" && !fileExists(resourceOutPathMarshal) {
+			resource.MinVersion = version
 		}
+		writeYaml(resourceOutPathMarshal, &resource, version)
 		log.Printf("Generated resource %s", resourceOutPathMarshal)
 	}
 }
@@ -150,12 +171,12 @@ func findResources(doc *openapi3.T) [][]string {
 	return resourcePaths
 }
 
-func buildProduct(filePath, output string, root *openapi3.T, header []byte) string {
+func buildProduct(filePath, output string, root *openapi3.T) string {
 
 	version := root.Info.Version
 	server := root.Servers[0].URL
 
-	productName := strings.Split(filepath.Base(filePath), "_")[0]
+	productName := productNameOf(filePath)
 	productPath := filepath.Join(output, productName)
 
 	if err := os.MkdirAll(productPath, os.ModePerm); err != nil {
@@ -166,8 +187,7 @@ func buildProduct(filePath, output string, root *openapi3.T, header []byte) stri
 	apiVersion := &product.Version{}
 
 	apiVersion.BaseUrl = fmt.Sprintf("%s/%s/", server, version)
-	// TODO figure out how to tell the API version
-	apiVersion.Name = "ga"
+	apiVersion.Name = versionName(root)
 	apiProduct.Versions = []*product.Version{apiVersion}
 
 	// Standard titling is "Service Name API"
@@ -179,32 +199,17 @@ func buildProduct(filePath, output string, root *openapi3.T, header []byte) stri
 	apiProduct.Scopes = []string{"https://www.googleapis.com/auth/cloud-platform"}
 
 	productOutPathMarshal := filepath.Join(output, fmt.Sprintf("/%s/product.yaml", productName))
-
-	// Default yaml marshaller
-	bytes, err := yaml.Marshal(apiProduct)
-	if err != nil {
-		log.Fatalf("error marshalling yaml %v: %v", productOutPathMarshal, err)
-	}
-
-	f, err := os.Create(productOutPathMarshal)
-	if err != nil {
-		log.Fatalf("error creating product file %v", err)
-	}
-	_, err = f.Write(header)
-	if err != nil {
-		log.Fatalf("error writing product file header %v", err)
-	}
-	_, err = f.Write(bytes)
-	if err != nil {
-		log.Fatalf("error writing product file %v", err)
-	}
-	err = f.Close()
-	if err != nil {
-		log.Fatalf("error closing product file %v", err)
-	}
+	writeYaml(productOutPathMarshal, apiProduct, apiVersion.Name)
 	return productPath
 }
 
+// productNameOf returns the name of the product directory of an API document,
+// which is its file name up to the first underscore, such as `widgets` for
+// `widgets_v1_openapi.yaml` or `widgets_v1_discovery.json`.
+func productNameOf(filePath string) string {
+	return strings.Split(filepath.Base(filePath), "_")[0]
+}
+
 func baseUrl(resourcePath string) string {
 	base := strings.ReplaceAll(resourcePath, "{", "{{")
 	base = strings.ReplaceAll(base, "}", "}}")
@@ -249,20 +254,46 @@ func buildResource(filePath, resourcePath, resourceName string, root *openapi3.T
 	resource.IdFormat = selfLink
 	resource.ImportFormat = []string{selfLink}
 	resource.CreateUrl = fmt.Sprintf("%s?%s={{%s}}", baseUrl, queryParam, google.Underscore(queryParam))
-	resource.Description = "Description"
-
-	resource.AutogenAsync = true
-	async := api.NewAsync()
-	async.Operation.BaseUrl = "{{op_id}}"
-	async.Result.ResourceInsideResponse = true
-	resource.Async = async
-
-	if hasUpdate(resourceName, root) {
-		resource.UpdateVerb = "PATCH"
-		resource.UpdateMask = true
-	} else {
+	resource.Description = resourceDescription(resourcePath, root)
+
+	create := root.Paths.Find(resourcePath).Post
+	var read, update, delete *openapi3.Operation
+	if item := findSelfLinkPath(resourcePath, root); item != nil {
+		read, delete = item.Get, item.Delete
+		switch {
+		case item.Patch != nil:
+			update = item.Patch
+			resource.UpdateVerb = "PATCH"
+			resource.UpdateMask = hasParameter(item.Patch, "updateMask")
+		case item.Put != nil:
+			update = item.Put
+			resource.UpdateVerb = "PUT"
+		}
+	}
+	if update == nil {
 		resource.Immutable = true
 	}
+	if read != nil && read.Deprecated || create.Deprecated {
+		resource.DeprecationMessage = "The API of this resource is deprecated. The resource will be removed in a future major release."
+	}
+
+	// Operations that return a google.longrunning.Operation are polled until
+	// they complete.
+	var actions []string
+	for action, op := range map[string]*openapi3.Operation{"create": create, "update": update, "delete": delete} {
+		if returnsOperation(op) {
+			actions = append(actions, action)
+		}
+	}
+	if len(actions) > 0 {
+		slices.Sort(actions)
+		resource.AutogenAsync = true
+		async := api.NewAsync()
+		async.Actions = actions
+		async.Operation.BaseUrl = "{{op_id}}"
+		async.Result.ResourceInsideResponse = slices.Contains(actions, "create")
+		resource.Async = async
+	}
 
 	example := r.Examples{}
 	example.Name = "name_of_example_file"
@@ -279,20 +310,82 @@ func buildResource(filePath, resourcePath, resourceName string, root *openapi3.T
 	return resource
 }
 
-func hasUpdate(resourceName string, root *openapi3.T) bool {
-	// Create and Update have different paths in the OpenAPI spec, so look
-	// through all paths to find one that matches the expected operation name
-	for _, pathValue := range root.Paths.Map() {
-		if pathValue.Patch == nil {
+// findSelfLinkPath returns the path of a single resource under the
+// collection at resourcePath, such as `/v1/projects/{projectsId}/foos/{foosId}`
+// for `/v1/projects/{projectsId}/foos`.
+func findSelfLinkPath(resourcePath string, root *openapi3.T) *openapi3.PathItem {
+	for key, pathValue := range root.Paths.Map() {
+		rest, ok := strings.CutPrefix(key, resourcePath+"/{")
+		if ok && strings.HasSuffix(rest, "}") && !strings.Contains(rest, "/") {
+			return pathValue
+		}
+	}
+	return nil
+}
+
+func hasParameter(op *openapi3.Operation, name string) bool {
+	return slices.ContainsFunc(op.Parameters, func(p *openapi3.ParameterRef) bool {
+		return p.Value != nil && p.Value.Name == name
+	})
+}
+
+var operationSchemaRegex = regexp.MustCompile(`(?i)(^|/|\.)(google\.?longrunning\.?)?Operation$`)
+
+// returnsOperation reports whether an API method is long-running, which is
+// the case when its response is a google.longrunning.Operation.
+func returnsOperation(op *openapi3.Operation) bool {
+	if op == nil || op.Responses == nil {
+		return false
+	}
+	for _, response := range op.Responses.Map() {
+		if response.Value == nil {
+			continue
+		}
+		content := response.Value.Content.Get("application/json")
+		if content == nil || content.Schema == nil {
 			continue
 		}
-		if pathValue.Patch.OperationID == fmt.Sprintf("Update%s", resourceName) {
+		if operationSchemaRegex.MatchString(content.Schema.Ref) {
+			return true
+		}
+		if content.Schema.Value != nil && operationSchemaRegex.MatchString(content.Schema.Value.Title) {
 			return true
 		}
 	}
 	return false
 }
 
+// resourceDescription returns the description of the resource's schema, which
+// is the body of its create method.
+func resourceDescription(resourcePath string, root *openapi3.T) string {
+	body := root.Paths.Find(resourcePath).Post.RequestBody
+	description := ""
+	if body != nil && body.Value != nil {
+		if content := body.Value.Content.Get("application/json"); content != nil && content.Schema != nil && content.Schema.Value != nil {
+			description = content.Schema.Value.Description
+		}
+	}
+	if strings.TrimSpace(description) == "" {
+		return "No description"
+	}
+	return trimDescription(description)
+}
+
+// versionName returns the MMv1 version of an API from its version string,
+// such as `beta` for `v1beta1`.
+func versionName(root *openapi3.T) string {
+	version := ""
+	if root.Info != nil {
+		version = root.Info.Version
+	}
+	for _, v := range []string{"beta", "alpha"} {
+		if strings.Contains(version, v) {
+			return v
+		}
+	}
+	return "ga"
+}
+
 func parseOpenApi(resourcePath, resourceName string, root *openapi3.T) []any {
 	returnArray := []any{}
 	path := root.Paths.Find(resourcePath)
@@ -347,8 +440,9 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 	case "locationsId":
 		name = "location"
 	}
-	additionalDescription := ""
-
+	// A field referencing a schema through allOf can have a description and
+	// field behaviors of its own.
+	annotations := obj
 	if len(obj.Value.AllOf) > 0 {
 		obj = obj.Value.AllOf[0]
 		objType = *obj.Value.Type
@@ -358,15 +452,9 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 	switch objType[0] {
 	case "string":
 		field.Type = "String"
-		if len(obj.Value.Enum) > 0 {
-			var enums []string
-			for _, enum := range obj.Value.Enum {
-				if strings.HasSuffix(fmt.Sprintf("%v", enum), "_UNSPECIFIED") {
-					continue
-				}
-				enums = append(enums, fmt.Sprintf("%v", enum))
-			}
-			additionalDescription = fmt.Sprintf("\n Possible values:\n %s", strings.Join(enums, "\n"))
+		if enums := enumValues(obj); len(enums) > 0 {
+			field.Type = "Enum"
+			field.EnumValues = enums
 		}
 	case "integer":
 		field.Type = "Integer"
@@ -395,10 +483,12 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 
 		field.Type = "NestedObject"
 		if obj.Value.AdditionalProperties.Schema != nil {
+			// The API doesn't name the key of the map, so key_name is left
+			// unset for writeYaml to report unless the file already sets it.
 			field.Type = "Map"
-			field.KeyName = "TODO: CHANGEME"
+			field.KeyDescription = fmt.Sprintf("The key of the `%s` entry.", google.Underscore(name))
 			var valueType api.Type
-			valueType.Name = "TODO: CHANGEME"
+			valueType.Name = singular(name)
 			valueType.Type = "NestedObject"
 			valueType.Properties = buildProperties(obj.Value.AdditionalProperties.Schema.Value.Properties, obj.Value.AdditionalProperties.Schema.Value.Required)
 			field.ValueType = &valueType
@@ -412,6 +502,10 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 		switch typ[0] {
 		case "string":
 			subField.Type = "String"
+			if enums := enumValues(obj.Value.Items); len(enums) > 0 {
+				subField.Type = "Enum"
+				subField.EnumValues = enums
+			}
 		case "integer":
 			subField.Type = "Integer"
 		case "number":
@@ -427,7 +521,10 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 		panic(fmt.Sprintf("Failed to identify field type for %s %s", field.Name, objType[0]))
 	}
 
-	description := fmt.Sprintf("%s %s", obj.Value.Description, additionalDescription)
+	description := annotations.Value.Description
+	if strings.TrimSpace(description) == "" {
+		description = obj.Value.Description
+	}
 	if strings.TrimSpace(description) == "" {
 		description = "No description"
 	}
@@ -440,7 +537,7 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 	}
 
 	// These methods are only available when the field is set
-	if obj.Value.ReadOnly {
+	if obj.Value.ReadOnly || annotations.Value.ReadOnly {
 		field.Output = true
 	}
 
@@ -456,9 +553,102 @@ func WriteObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 		field.Immutable = true
 	}
 
+	// x-google-field-behavior holds the google.api.field_behavior annotations
+	// of the field described by AIP 203.
+	for _, behavior := range stringsExtension(annotations, "x-google-field-behavior") {
+		switch behavior {
+		case "REQUIRED":
+			field.Required = !field.Output
+		case "OUTPUT_ONLY", "IDENTIFIER":
+			field.Output = true
+			field.Required = false
+		case "IMMUTABLE":
+			field.Immutable = true
+		case "INPUT_ONLY":
+			field.IgnoreRead = true
+		}
+	}
+
+	// x-google-resource-reference holds the google.api.resource_reference
+	// annotation of the field, which is resolved once all of the resources of
+	// the API are known.
+	if ref := obj.Value.Extensions["x-google-resource-reference"]; ref != nil {
+		if m, ok := ref.(map[string]any); ok {
+			if refType, ok := m["type"].(string); ok {
+				field.Resource = refType
+			}
+		}
+	}
+
+	if obj.Value.Deprecated || annotations.Value.Deprecated {
+		field.DeprecationMessage = fmt.Sprintf("`%s` is deprecated and will be removed in a future major release.", google.Underscore(name))
+	}
+
 	return field
 }
 
+// enumValues returns the values of an enum schema, without the default
+// `_UNSPECIFIED` value of protobuf enums.
+func enumValues(obj *openapi3.SchemaRef) []string {
+	var enums []string
+	for _, enum := range obj.Value.Enum {
+		if strings.HasSuffix(fmt.Sprintf("%v", enum), "_UNSPECIFIED") {
+			continue
+		}
+		enums = append(enums, fmt.Sprintf("%v", enum))
+	}
+	return enums
+}
+
+// stringsExtension returns the values of a string list extension of a schema.
+func stringsExtension(obj *openapi3.SchemaRef, name string) []string {
+	values, _ := obj.Value.Extensions[name].([]any)
+	var ret []string
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			ret = append(ret, s)
+		}
+	}
+	return ret
+}
+
+// resolveResourceRefs turns fields referencing one of the given resources of
+// the API into ResourceRef fields. References to other resources stay plain
+// fields.
+func resolveResourceRefs(properties []*api.Type, resourceNames []string) {
+	for _, p := range properties {
+		if p.Resource != "" {
+			// Resource types are `<service>/<Kind>`, such as
+			// `compute.googleapis.com/Network`
+			kind := p.Resource[strings.LastIndex(p.Resource, "/")+1:]
+			p.Resource = ""
+			if slices.Contains(resourceNames, kind) && p.Type == "String" {
+				p.Type = "ResourceRef"
+				p.Resource = kind
+				p.Imports = "name"
+			}
+		}
+		resolveResourceRefs(p.Properties, resourceNames)
+		if p.ItemType != nil {
+			resolveResourceRefs(p.ItemType.Properties, resourceNames)
+		}
+		if p.ValueType != nil {
+			resolveResourceRefs(p.ValueType.Properties, resourceNames)
+		}
+	}
+}
+
+// singular returns a naive singular form of a plural field name.
+func singular(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	case strings.HasSuffix(name, "s"):
+		return strings.TrimSuffix(name, "s")
+	}
+	return name
+}
+
 func buildProperties(props openapi3.Schemas, required []string) []*api.Type {
 	properties := []*api.Type{}
 	for _, k := range slices.Sorted(maps.Keys(props)) {
@@ -474,12 +664,14 @@ func buildProperties(props openapi3.Schemas, required []string) []*api.Type {
 
 // Trims whitespace from the ends of lines in a description to force multiline
 // formatting for strings with newlines present
-// Also trim "Output only." and "Required." from descriptions as this gets duplicated
+// Also trim field behaviors such as "Output only." and "Required." from descriptions as this gets duplicated
 func trimDescription(description string) string {
 	description, _ = strings.CutPrefix(description, "Optional. ")
 	description, _ = strings.CutPrefix(description, "Output only. ")
 	description, _ = strings.CutPrefix(description, "Required. ")
 	description, _ = strings.CutPrefix(description, "Immutable. ")
+	description, _ = strings.CutPrefix(description, "Identifier. ")
+	description, _ = strings.CutPrefix(description, "Input only. ")
 	lines := strings.Split(description, "\n")
 	var trimmedDescription []string
 	for _, line := range lines {
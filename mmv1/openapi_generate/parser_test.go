@@ -2,8 +2,15 @@ package openapi_generate
 
 import (
 	"context"
-	"github.com/getkin/kin-openapi/openapi3"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
 )
 
 func TestMapType(t *testing.T) {
@@ -15,10 +22,234 @@ func TestMapType(t *testing.T) {
 
 	petSchema := doc.Paths.Map()["/pets"].Post.Parameters[0].Value.Schema
 	mmObject := WriteObject("pet", petSchema, propType(petSchema), false)
-	if mmObject.KeyName == "" || mmObject.Type != "Map" {
+	if mmObject.Type != "Map" {
 		t.Error("Failed to parse map type")
 	}
+	if mmObject.KeyName != "" {
+		t.Errorf("Expected the key name to be left for the user to set, got %q", mmObject.KeyName)
+	}
 	if len(mmObject.ValueType.Properties) != 4 {
 		t.Errorf("Expected 4 properties, found %d", len(mmObject.ValueType.Properties))
 	}
 }
+
+func TestBuildResource(t *testing.T) {
+	ctx := context.Background()
+	loader := &openapi3.Loader{Context: ctx, IsExternalRefsAllowed: true}
+	doc, err := loader.LoadFromFile("./test_data/widgets_v1_openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resourcePath := "/v1/projects/{projectsId}/locations/{locationsId}/widgets"
+	resource := buildResource("widgets_v1_openapi.yaml", resourcePath, "Widget", doc)
+	resolveResourceRefs(resource.Properties, []string{"Widget", "Gadget"})
+
+	if resource.Description != "A widget assembled from gadgets." {
+		t.Errorf("unexpected description %q", resource.Description)
+	}
+	if resource.UpdateVerb != "PATCH" || !resource.UpdateMask || resource.Immutable {
+		t.Errorf("expected an update mask PATCH update, got verb %q, update_mask %t, immutable %t", resource.UpdateVerb, resource.UpdateMask, resource.Immutable)
+	}
+	if resource.Async == nil || !reflect.DeepEqual(resource.Async.Actions, []string{"create", "update"}) {
+		t.Errorf("expected async create and update, got %+v", resource.Async)
+	}
+
+	properties := make(map[string]*api.Type)
+	for _, p := range resource.Properties {
+		properties[p.Name] = p
+	}
+	cases := []struct {
+		description string
+		name        string
+		check       func(p *api.Type) bool
+	}{
+		{"identifier", "name", func(p *api.Type) bool { return p.Output && !p.Required }},
+		{"required", "displayName", func(p *api.Type) bool { return p.Required }},
+		{"immutable", "shape", func(p *api.Type) bool { return p.Immutable }},
+		{"input only", "secret", func(p *api.Type) bool {
+			return p.IgnoreRead && p.Description == "A secret used to assemble the widget."
+		}},
+		{"enum", "state", func(p *api.Type) bool {
+			return p.Type == "Enum" && p.Output && reflect.DeepEqual(p.EnumValues, []string{"ACTIVE", "DELETING"})
+		}},
+		{"deprecated", "legacyColor", func(p *api.Type) bool { return p.DeprecationMessage != "" }},
+		{"resource reference", "gadget", func(p *api.Type) bool { return p.Type == "ResourceRef" && p.Resource == "Gadget" }},
+		{"external resource reference", "network", func(p *api.Type) bool { return p.Type == "String" && p.Resource == "" }},
+		{"map", "parts", func(p *api.Type) bool { return p.KeyName == "" && p.ValueType.Name == "part" }},
+		{"annotated reference", "config", func(p *api.Type) bool {
+			return p.Immutable && p.Description == "The configuration of the widget." && len(p.Properties) == 1
+		}},
+	}
+	for _, tc := range cases {
+		p, ok := properties[tc.name]
+		if !ok {
+			t.Errorf("%s: missing property %s", tc.description, tc.name)
+			continue
+		}
+		if !tc.check(p) {
+			t.Errorf("%s: unexpected property %+v", tc.description, p)
+		}
+	}
+}
+
+func TestRunMergesVersions(t *testing.T) {
+	folder := t.TempDir()
+	output := t.TempDir()
+	for _, f := range []string{"widgets_v1_openapi.yaml", "widgets_v1beta1_openapi.yaml"} {
+		contents, err := os.ReadFile(filepath.Join("test_data", f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(folder, f), contents, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	parser := Parser{Folder: folder, Output: output}
+	parser.Run()
+
+	widgetPath := filepath.Join(output, "widgets", "Widget.yaml")
+	first, err := os.ReadFile(widgetPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var product api.Product
+	var widget api.Resource
+	readYaml(t, filepath.Join(output, "widgets", "product.yaml"), &product)
+	readYaml(t, widgetPath, &widget)
+	if len(product.Versions) != 2 {
+		t.Errorf("expected ga and beta versions, got %d", len(product.Versions))
+	}
+	if widget.MinVersion != "" {
+		t.Errorf("expected a ga resource, got min_version %q", widget.MinVersion)
+	}
+	for _, p := range widget.Properties {
+		if want := map[bool]string{true: "beta", false: ""}[p.Name == "sparkle"]; p.MinVersion != want {
+			t.Errorf("expected %s to have min_version %q, got %q", p.Name, want, p.MinVersion)
+		}
+	}
+
+	// Edits to generated files are kept when running again
+	edited := strings.Replace(string(first), "description: The shape of the widget.", "description: The shape of the widget, edited.", 1)
+	if err := os.WriteFile(widgetPath, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	parser.Run()
+	second, err := os.ReadFile(widgetPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(second) != edited {
+		t.Errorf("expected running again to keep the file unchanged, got\n%s", second)
+	}
+}
+
+func TestMergeYaml(t *testing.T) {
+	cases := []struct {
+		description string
+		existing    string
+		generated   string
+		version     string
+		expected    string
+		versioned   []string
+	}{
+		{
+			description: "new file",
+			generated:   "name: Foo\n",
+			version:     "ga",
+			expected:    string(header) + "name: Foo\n",
+		},
+		{
+			description: "keeps existing values and comments",
+			existing:    "# License\n---\nname: Foo\n# Edited by hand\ndescription: Edited\n",
+			generated:   "name: Foo\ndescription: Generated\nimmutable: true\n",
+			version:     "ga",
+			expected:    "# License\n---\nname: Foo\n# Edited by hand\ndescription: Edited\nimmutable: true\n",
+		},
+		{
+			description: "adds beta fields",
+			existing:    "properties:\n  - name: foo\n    properties:\n      - name: bar\n",
+			generated:   "properties:\n  - name: foo\n    properties:\n      - name: bar\n      - name: baz\n  - name: qux\n",
+			version:     "beta",
+			expected:    "properties:\n  - name: foo\n    properties:\n      - name: bar\n      - name: baz\n        min_version: 'beta'\n  - name: qux\n    min_version: 'beta'\n",
+		},
+		{
+			description: "reports the min_version of ga fields",
+			existing:    "min_version: beta\nproperties:\n  - name: foo\n    min_version: beta\n    properties:\n      - name: baz\n        min_version: beta\n  - name: bar\n    min_version: beta\n",
+			generated:   "properties:\n  - name: foo\n    properties:\n      - name: baz\n",
+			version:     "ga",
+			expected:    "min_version: beta\nproperties:\n  - name: foo\n    min_version: beta\n    properties:\n      - name: baz\n        min_version: beta\n  - name: bar\n    min_version: beta\n",
+			versioned:   []string{"", "properties.foo", "properties.foo.properties.baz"},
+		},
+	}
+
+	for _, tc := range cases {
+		var generated yaml.Node
+		if err := yaml.Unmarshal([]byte(tc.generated), &generated); err != nil {
+			t.Fatal(err)
+		}
+		got, versioned, err := mergeYaml([]byte(tc.existing), generated.Content[0], tc.version)
+		if err != nil {
+			t.Errorf("%s: %v", tc.description, err)
+			continue
+		}
+		if string(got) != tc.expected {
+			t.Errorf("%s: expected\n%s\nto be\n%s", tc.description, got, tc.expected)
+		}
+		if !reflect.DeepEqual(versioned, tc.versioned) {
+			t.Errorf("%s: expected the fields with a min_version %v to be %v", tc.description, versioned, tc.versioned)
+		}
+	}
+}
+
+func TestProductNameOf(t *testing.T) {
+	cases := map[string]string{
+		"widgets_v1_openapi.yaml":           "widgets",
+		"widgets_v1_discovery.json":         "widgets",
+		"cloud-widgets_v1beta_openapi.yaml": "cloud-widgets",
+	}
+	for filePath, expected := range cases {
+		if got := productNameOf(filePath); got != expected {
+			t.Errorf("expected the product of %s to be %q, got %q", filePath, expected, got)
+		}
+	}
+}
+
+func TestMapsMissingKeyName(t *testing.T) {
+	contents := `properties:
+  - name: parts
+    type: Map
+    value_type:
+      name: part
+      type: NestedObject
+      properties:
+        - name: labels
+          type: Map
+          key_name: key
+        - name: sizes
+          type: Map
+  - name: shapes
+    type: Map
+    key_name: shape
+`
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(contents), &doc); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"properties.parts", "properties.parts.value_type.properties.sizes"}
+	if got := mapsMissingKeyName(&doc, ""); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func readYaml(t *testing.T, filePath string, obj any) {
+	t.Helper()
+	contents, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := yaml.Unmarshal(contents, obj); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,355 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+// sharedTemplateGlobs match the templates used to generate every resource.
+// Templates specific to a resource, such as custom code, are found from its
+// configuration instead.
+var sharedTemplateGlobs = []string{
+	"templates/terraform/*.tmpl",
+	"templates/terraform/examples/base_configs/*",
+	"templates/terraform/samples/base_configs/*",
+	"templates/terraform/iam/*",
+	"templates/terraform/iam/example_config_body/*",
+}
+
+var templatePathRegex = regexp.MustCompile(`templates/[\w./-]+`)
+
+// GenerationCache records the files generated for each resource, keyed by a
+// hash of everything their generation depends on, so that resources which
+// haven't changed since a previous run can be skipped. The contents of the
+// generated files are stored by their hash, so that files deleted since, such
+// as by `make clean-provider`, are restored instead of regenerated. A nil cache
+// disables caching.
+type GenerationCache struct {
+	path string
+
+	// filesDir holds the contents of the generated files, named by their hash.
+	filesDir string
+
+	// inputs holds the hashes shared by every resource: the generator binary,
+	// the shared templates and the generation settings.
+	inputs string
+
+	mu      sync.Mutex
+	entries map[string]generationCacheEntry
+	hashes  map[string]string
+}
+
+type generationCacheEntry struct {
+	Key string `json:"key"`
+
+	// Files maps the files generated for a resource to the hash of their
+	// contents.
+	Files map[string]string `json:"files"`
+}
+
+// LoadGenerationCache loads the cache of the generation of a provider version
+// into outputFolder from cacheDir, creating an empty cache if there is none.
+func LoadGenerationCache(cacheDir, outputFolder, providerName, version string, generateCode, generateDocs bool) (*GenerationCache, error) {
+	absOutput, err := filepath.Abs(outputFolder)
+	if err != nil {
+		return nil, err
+	}
+	generator, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &GenerationCache{
+		path:     filepath.Join(cacheDir, hashStrings(absOutput, providerName, version)+".json"),
+		filesDir: filepath.Join(cacheDir, "files"),
+		entries:  make(map[string]generationCacheEntry),
+		hashes:   make(map[string]string),
+	}
+
+	generatorHash, err := c.hashFile(generator)
+	if err != nil {
+		return nil, err
+	}
+	var templates []string
+	for _, glob := range sharedTemplateGlobs {
+		matches, err := filepath.Glob(glob)
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && !info.IsDir() {
+				templates = append(templates, match)
+			}
+		}
+	}
+	templatesHash, err := c.hashFiles(templates)
+	if err != nil {
+		return nil, err
+	}
+	c.inputs = hashStrings(generatorHash, templatesHash, fmt.Sprint(generateCode), fmt.Sprint(generateDocs))
+
+	contents, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(contents, &c.entries); err != nil {
+		return nil, fmt.Errorf("error parsing generation cache %s: %w", c.path, err)
+	}
+	return c, nil
+}
+
+// Key returns the cache key of a resource: a hash of its merged configuration,
+// its product's configuration, its schema snapshots, the resources it
+// references, the templates it uses and the inputs shared by all resources.
+func (c *GenerationCache) Key(object api.Resource) (string, error) {
+	if c == nil {
+		return "", nil
+	}
+
+	product := *object.ProductMetadata
+	product.Objects = nil
+	configs := []any{object, product}
+	if len(object.SchemaSnapshots) > 0 {
+		configs = append(configs, object.SchemaSnapshots)
+	}
+	for _, p := range object.AllNestedProperties(object.AllUserProperties()) {
+		if p.IsResourceRefFound() {
+			configs = append(configs, p.ResourceRef())
+		}
+	}
+
+	var hashes, templates []string
+	for _, config := range configs {
+		contents, err := yaml.Marshal(config)
+		if err != nil {
+			return "", err
+		}
+		hashes = append(hashes, hashBytes(contents))
+		templates = append(templates, templatePathRegex.FindAllString(string(contents), -1)...)
+	}
+	for _, e := range object.Examples {
+		templates = append(templates, e.ConfigPath)
+	}
+	for _, s := range object.Samples {
+		for _, step := range s.Steps {
+			templates = append(templates, step.ConfigPath)
+		}
+	}
+	templatesHash, err := c.hashFiles(templates)
+	if err != nil {
+		return "", err
+	}
+	return hashStrings(append(hashes, templatesHash, c.inputs)...), nil
+}
+
+// Hit reports whether the files of the named resource were generated with the
+// given key and are unchanged since. Files that were deleted since are
+// restored from the cache.
+func (c *GenerationCache) Hit(name, key string) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[name]
+	c.mu.Unlock()
+	if !ok || entry.Key != key {
+		return false
+	}
+	var deleted []string
+	for file, hash := range entry.Files {
+		contents, err := os.ReadFile(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			deleted = append(deleted, file)
+			continue
+		}
+		if err != nil || hashBytes(contents) != hash {
+			return false
+		}
+	}
+	for _, file := range deleted {
+		if err := c.restore(file, entry.Files[file]); err != nil {
+			log.Printf("Error restoring %s from the generation cache: %v", file, err)
+			return false
+		}
+	}
+	return true
+}
+
+// Record stores the files generated for the named resource with the given key.
+func (c *GenerationCache) Record(name, key string, files []string) error {
+	if c == nil {
+		return nil
+	}
+
+	entry := generationCacheEntry{Key: key, Files: make(map[string]string)}
+	for _, file := range files {
+		contents, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		hash := hashBytes(contents)
+		if err := c.store(hash, contents); err != nil {
+			return err
+		}
+		entry.Files[file] = hash
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[name] = entry
+	return nil
+}
+
+// store writes the contents of a generated file to the cache, unless it is
+// already there.
+func (c *GenerationCache) store(hash string, contents []byte) error {
+	path := filepath.Join(c.filesDir, hash)
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(c.filesDir, os.ModePerm); err != nil {
+		return err
+	}
+	// Files are written under a temporary name and renamed, so that a file is
+	// never read while it is partially written.
+	f, err := os.CreateTemp(c.filesDir, hash+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(contents); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// restore writes a generated file back from the cache.
+func (c *GenerationCache) restore(file, hash string) error {
+	contents, err := os.ReadFile(filepath.Join(c.filesDir, hash))
+	if err != nil {
+		return err
+	}
+	if hashBytes(contents) != hash {
+		return fmt.Errorf("the cached contents of %s are corrupted", file)
+	}
+	if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(file, contents, 0644)
+}
+
+// Save writes the cache to its directory.
+func (c *GenerationCache) Save() error {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	contents, err := json.Marshal(c.entries)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, contents, 0644)
+}
+
+// hashFiles returns a hash of the contents of a set of files. Missing files
+// are hashed by their name only, so that creating them changes the hash.
+func (c *GenerationCache) hashFiles(files []string) (string, error) {
+	sorted := append([]string{}, files...)
+	sort.Strings(sorted)
+
+	var hashes []string
+	for _, file := range sorted {
+		hash, err := c.hashFile(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			hash = ""
+		} else if err != nil {
+			return "", err
+		}
+		hashes = append(hashes, file, hash)
+	}
+	return hashStrings(hashes...), nil
+}
+
+// hashFile returns a hash of the contents of a file, which is computed once per
+// run as templates are shared by many resources.
+func (c *GenerationCache) hashFile(file string) (string, error) {
+	c.mu.Lock()
+	hash, ok := c.hashes[file]
+	c.mu.Unlock()
+	if ok {
+		return hash, nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	hash = hex.EncodeToString(h.Sum(nil))
+
+	c.mu.Lock()
+	c.hashes[file] = hash
+	c.mu.Unlock()
+	return hash, nil
+}
+
+func hashBytes(b []byte) string {
+	h := sha256.Sum256(b)
+	return hex.EncodeToString(h[:])
+}
+
+func hashStrings(s ...string) string {
+	h := sha256.New()
+	for _, v := range s {
+		// Length-prefix values so that different splits of the same bytes
+		// don't collide.
+		fmt.Fprintf(h, "%d:%s", len(v), v)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerationCacheHit(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		key         string
+		change      func(file string) error
+		hit         bool
+	}{
+		{
+			description: "unchanged",
+			key:         "key",
+			change:      func(string) error { return nil },
+			hit:         true,
+		},
+		{
+			description: "deleted files are restored",
+			key:         "key",
+			change:      os.Remove,
+			hit:         true,
+		},
+		{
+			description: "modified files are regenerated",
+			key:         "key",
+			change: func(file string) error {
+				return os.WriteFile(file, []byte("package widgets // modified\n"), 0644)
+			},
+		},
+		{
+			description: "changed keys are regenerated",
+			key:         "other",
+			change:      func(string) error { return nil },
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			cacheDir := t.TempDir()
+			c := &GenerationCache{
+				path:     filepath.Join(cacheDir, "cache.json"),
+				filesDir: filepath.Join(cacheDir, "files"),
+				entries:  make(map[string]generationCacheEntry),
+				hashes:   make(map[string]string),
+			}
+			file := filepath.Join(t.TempDir(), "google/services/widgets/resource_widgets_widget.go")
+			contents := []byte("package widgets\n")
+			if err := os.MkdirAll(filepath.Dir(file), os.ModePerm); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(file, contents, 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := c.Record("products/widgets/Widget", "key", []string{file}); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := tc.change(file); err != nil {
+				t.Fatal(err)
+			}
+			if got := c.Hit("products/widgets/Widget", tc.key); got != tc.hit {
+				t.Fatalf("expected hit to be %t, got %t", tc.hit, got)
+			}
+			if tc.hit {
+				got, err := os.ReadFile(file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(got) != string(contents) {
+					t.Errorf("expected %s to contain %q, got %q", file, contents, got)
+				}
+			}
+		})
+	}
+}
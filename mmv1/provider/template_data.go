@@ -30,6 +30,10 @@ type TemplateData struct {
 	OutputFolder string
 	VersionName  string
 
+	// GeneratedFiles, if set, collects the paths of the files written by
+	// GenerateFile.
+	GeneratedFiles *[]string
+
 	// TODO rewrite: is this needed?
 	//     # Information about the local environment
 	//     # (which formatters are enabled, start-time)
@@ -59,6 +63,11 @@ func (td *TemplateData) GenerateResourceFile(filePath string, resource api.Resou
 		"templates/terraform/update_mask.go.tmpl",
 		"templates/terraform/nested_query.go.tmpl",
 		"templates/terraform/unordered_list_customize_diff.go.tmpl",
+		"templates/terraform/locks.go.tmpl",
+		"templates/terraform/poll_conditions.go.tmpl",
+		"templates/terraform/request_body.go.tmpl",
+		"templates/terraform/validate_only.go.tmpl",
+		"templates/terraform/state_upgraders.go.tmpl",
 	}
 	td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
@@ -68,6 +77,25 @@ func (td *TemplateData) GenerateFWResourceFile(filePath string, resource api.Res
 	templates := []string{
 		templatePath,
 		"templates/terraform/schema_property_fw.go.tmpl",
+		"templates/terraform/locks.go.tmpl",
+		"templates/terraform/poll_conditions.go.tmpl",
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateFWStateUpgradersFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/resource_fw_state_upgraders.go.tmpl"
+	templates := []string{
+		templatePath,
+		"templates/terraform/state_upgraders.go.tmpl",
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateFWParityTestFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/resource_fw_parity_test.go.tmpl"
+	templates := []string{
+		templatePath,
 	}
 	td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
@@ -88,6 +116,30 @@ func (td *TemplateData) GenerateDataSourceFile(filePath string, resource api.Res
 	td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
+func (td *TemplateData) GenerateListResourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/list_resource.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateEphemeralResourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/ephemeral_resource.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateEphemeralResourceDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/ephemeral_resource.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
 func (td *TemplateData) GenerateProductFile(filePath string, product api.Product) {
 	templatePath := "templates/terraform/product.go.tmpl"
 	templates := []string{
@@ -341,6 +393,9 @@ func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, g
 	if err != nil {
 		glog.Exit(err)
 	}
+	if td.GeneratedFiles != nil {
+		*td.GeneratedFiles = append(*td.GeneratedFiles, filePath)
+	}
 }
 
 type TestInput struct {
@@ -14,7 +14,6 @@
 package provider
 
 import (
-	"bufio"
 	"bytes"
 	"errors"
 	"fmt"
@@ -40,6 +39,10 @@ type Terraform struct {
 
 	IAMResourceCount int
 
+	ListResourceCount int
+
+	EphemeralResourceCount int
+
 	ResourcesForVersion []map[string]string
 
 	TargetVersionName string
@@ -49,6 +52,12 @@ type Terraform struct {
 	Product *api.Product
 
 	StartTime time.Time
+
+	// Cache, if set, skips the generation of resources that haven't changed
+	// since a previous run.
+	Cache *GenerationCache
+
+	cacheKeys map[string]string
 }
 
 func NewTerraform(product *api.Product, versionName string, startTime time.Time) Terraform {
@@ -85,6 +94,23 @@ func (t Terraform) Generate(outputFolder, resourceToGenerate string, generateCod
 }
 
 func (t *Terraform) GenerateObjects(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+	// Cache keys are computed before generating any object, as generating an
+	// object may alter the objects it references.
+	if t.Cache != nil {
+		t.cacheKeys = make(map[string]string)
+		for _, object := range t.Product.Objects {
+			object.ExcludeIfNotInVersion(&t.Version)
+		}
+		for _, object := range t.Product.Objects {
+			key, err := t.Cache.Key(*object)
+			if err != nil {
+				log.Printf("Error computing the cache key of %s, it will be regenerated: %v", object.Name, err)
+				continue
+			}
+			t.cacheKeys[object.Name] = key
+		}
+	}
+
 	for _, object := range t.Product.Objects {
 		object.ExcludeIfNotInVersion(&t.Version)
 
@@ -98,7 +124,22 @@ func (t *Terraform) GenerateObjects(outputFolder, resourceToGenerate string, gen
 }
 
 func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPath string, generateCode, generateDocs bool) {
+	cacheName := path.Join(t.Product.PackagePath, object.Name)
+	cacheKey, cached := t.cacheKeys[object.Name]
+	if cached && t.Cache.Hit(cacheName, cacheKey) {
+		log.Printf("Skipping %s, it is unchanged since the last generation", object.Name)
+		return
+	}
+
 	templateData := NewTemplateData(outputFolder, t.TargetVersionName)
+	if cached {
+		templateData.GeneratedFiles = &[]string{}
+		defer func() {
+			if err := t.Cache.Record(cacheName, cacheKey, *templateData.GeneratedFiles); err != nil {
+				log.Printf("Error caching the generation of %s: %v", object.Name, err)
+			}
+		}()
+	}
 
 	if !object.IsExcluded() {
 		log.Printf("Generating %s resource", object.Name)
@@ -110,11 +151,18 @@ func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPat
 			t.GenerateResourceSweeper(object, *templateData, outputFolder)
 			t.GenerateSingularDataSource(object, *templateData, outputFolder)
 			t.GenerateSingularDataSourceTests(object, *templateData, outputFolder)
+			t.GenerateListResource(object, *templateData, outputFolder)
 			// log.Printf("Generating %s metadata", object.Name)
 			t.GenerateResourceMetadata(object, *templateData, outputFolder)
 		}
 	}
 
+	// ephemeral resources may be generated for objects without a managed resource
+	if !object.Exclude && object.ShouldGenerateEphemeralResource() {
+		log.Printf("Generating %s ephemeral resource", object.Name)
+		t.GenerateEphemeralResource(object, *templateData, outputFolder, generateCode, generateDocs)
+	}
+
 	// if iam_policy is not defined or excluded, don't generate it
 	if object.IamPolicy == nil || object.IamPolicy.Exclude {
 		return
@@ -130,26 +178,23 @@ func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateD
 		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
 			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 		}
-		if object.FrameworkResource {
-			fmt.Printf("\n\x1b[1;33mWARNING:\x1b[0m\n")
-			fmt.Printf("The plugin framework generation code is considered a WIP and experimental.\nAre you sure you want to use it for %s? (y/n) ", t.ResourceGoFilename(object))
-
-			reader := bufio.NewReader(os.Stdin)
-			input, err := reader.ReadString('\n')
-			if err != nil {
-				log.Fatalf("Error reading input: %v", err)
-			}
-
-			if strings.ToLower(strings.TrimSpace(input)) == "y" {
-				targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_fw_%s.go", t.ResourceGoFilename(object)))
-				templateData.GenerateFWResourceFile(targetFilePath, object)
-			} else {
-				log.Fatalf("please remove \"plugin_framework: true\" from the YAML configuration.")
-			}
-		} else {
+		if !object.FrameworkResource {
 			targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s.go", t.ResourceGoFilename(object)))
 			templateData.GenerateResourceFile(targetFilePath, object)
 		}
+		if object.ShouldGenerateFrameworkResource() {
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_fw_%s.go", t.ResourceGoFilename(object)))
+			templateData.GenerateFWResourceFile(targetFilePath, object)
+		}
+		// the SDK resource file holds the state upgraders unless it isn't generated
+		if object.FrameworkResource && object.SchemaVersion > 0 && object.StateUpgraders {
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_fw_%s_state_upgraders.go", t.ResourceGoFilename(object)))
+			templateData.GenerateFWStateUpgradersFile(targetFilePath, object)
+		}
+		if object.FrameworkParity {
+			targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_fw_%s_parity_test.go", t.ResourceGoFilename(object)))
+			templateData.GenerateFWParityTestFile(targetFilePath, object)
+		}
 	}
 
 	if generateDocs {
@@ -292,6 +337,41 @@ func (t *Terraform) GenerateSingularDataSourceTests(object api.Resource, templat
 
 }
 
+func (t *Terraform) GenerateListResource(object api.Resource, templateData TemplateData, outputFolder string) {
+	if !object.ShouldGenerateListResource() {
+		return
+	}
+
+	productName := t.Product.ApiName
+	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+	}
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("list_resource_%s.go", t.ResourceGoFilename(object)))
+	templateData.GenerateListResourceFile(targetFilePath, object)
+}
+
+func (t *Terraform) GenerateEphemeralResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	if generateCode {
+		productName := t.Product.ApiName
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
+		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("ephemeral_%s.go", t.ResourceGoFilename(object)))
+		templateData.GenerateEphemeralResourceFile(targetFilePath, object)
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "ephemeral-resources")
+		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
+		templateData.GenerateEphemeralResourceDocumentationFile(targetFilePath, object)
+	}
+}
+
 // GenerateProduct creates the product.go file for a given service directory.
 // This will be used to seed the directory and add a package-level comment
 // specific to the product.
@@ -445,7 +525,7 @@ func (t Terraform) getCommonCopyFiles(versionName string, generateCode, generate
 	// save the folder name to foldersCopiedToGoogleDir
 	var foldersCopiedToGoogleDir []string
 	if generateCode {
-		foldersCopiedToGoogleDir = []string{"third_party/terraform/services", "third_party/terraform/acctest", "third_party/terraform/sweeper", "third_party/terraform/provider", "third_party/terraform/tpgdclresource", "third_party/terraform/tpgiamresource", "third_party/terraform/tpgresource", "third_party/terraform/transport", "third_party/terraform/fwmodels", "third_party/terraform/fwprovider", "third_party/terraform/fwtransport", "third_party/terraform/fwresource", "third_party/terraform/fwutils", "third_party/terraform/fwvalidators", "third_party/terraform/verify", "third_party/terraform/envvar", "third_party/terraform/functions", "third_party/terraform/test-fixtures"}
+		foldersCopiedToGoogleDir = []string{"third_party/terraform/services", "third_party/terraform/acctest", "third_party/terraform/sweeper", "third_party/terraform/provider", "third_party/terraform/tpgdclresource", "third_party/terraform/tpgiamresource", "third_party/terraform/tpgresource", "third_party/terraform/transport", "third_party/terraform/fwmodels", "third_party/terraform/fwprovider", "third_party/terraform/fwtransport", "third_party/terraform/fwresource", "third_party/terraform/fwutils", "third_party/terraform/fwvalidators", "third_party/terraform/verify", "third_party/terraform/envvar", "third_party/terraform/functions", "third_party/terraform/fakeapi", "third_party/terraform/test-fixtures"}
 	}
 	googleDir := "google"
 	if versionName != "ga" {
@@ -558,7 +638,7 @@ func (t Terraform) getCommonCompileFiles(versionName string) map[string]string {
 
 	// Case 2: When compile all of files except .tmpl in a folder to the google directory of downstream repository,
 	// save the folder name to foldersCopiedToGoogleDir
-	foldersCompiledToGoogleDir := []string{"third_party/terraform/services", "third_party/terraform/acctest", "third_party/terraform/sweeper", "third_party/terraform/provider", "third_party/terraform/tpgdclresource", "third_party/terraform/tpgiamresource", "third_party/terraform/tpgresource", "third_party/terraform/transport", "third_party/terraform/fwmodels", "third_party/terraform/fwprovider", "third_party/terraform/fwtransport", "third_party/terraform/fwresource", "third_party/terraform/verify", "third_party/terraform/envvar", "third_party/terraform/functions", "third_party/terraform/test-fixtures"}
+	foldersCompiledToGoogleDir := []string{"third_party/terraform/services", "third_party/terraform/acctest", "third_party/terraform/sweeper", "third_party/terraform/provider", "third_party/terraform/tpgdclresource", "third_party/terraform/tpgiamresource", "third_party/terraform/tpgresource", "third_party/terraform/transport", "third_party/terraform/fwmodels", "third_party/terraform/fwprovider", "third_party/terraform/fwtransport", "third_party/terraform/fwresource", "third_party/terraform/verify", "third_party/terraform/envvar", "third_party/terraform/functions", "third_party/terraform/fakeapi", "third_party/terraform/test-fixtures"}
 	googleDir := "google"
 	if versionName != "ga" {
 		googleDir = fmt.Sprintf("google-%s", versionName)
@@ -868,6 +948,8 @@ func (t Terraform) GetMmv1ServicesInVersion(products []*api.Product) []string {
 // # {
 // #    terraform_name:
 // #    resource_name:
+// #    list_resource_name:
+// #    ephemeral_resource_name:
 // #    iam_class_name:
 // # }
 // # The variable resources_for_version is used to generate resources in file
@@ -880,11 +962,25 @@ func (t *Terraform) generateResourcesForVersion(products []*api.Product) {
 				continue
 			}
 
-			var resourceName string
+			var resourceName, frameworkResourceName, listResourceName, ephemeralResourceName string
 
 			if !object.IsExcluded() {
 				t.ResourceCount++
-				resourceName = fmt.Sprintf("%s.Resource%s", service, object.ResourceName())
+				if object.FrameworkResource {
+					frameworkResourceName = fmt.Sprintf("%s.New%sFWResource", service, object.ResourceName())
+				} else {
+					resourceName = fmt.Sprintf("%s.Resource%s", service, object.ResourceName())
+				}
+
+				if object.ShouldGenerateListResource() {
+					t.ListResourceCount++
+					listResourceName = fmt.Sprintf("%s.New%sListResource", service, object.ResourceName())
+				}
+			}
+
+			if object.ShouldGenerateEphemeralResource() {
+				t.EphemeralResourceCount++
+				ephemeralResourceName = fmt.Sprintf("%s.New%sEphemeralResource", service, object.ResourceName())
 			}
 
 			var iamClassName string
@@ -898,9 +994,12 @@ func (t *Terraform) generateResourcesForVersion(products []*api.Product) {
 			}
 
 			t.ResourcesForVersion = append(t.ResourcesForVersion, map[string]string{
-				"TerraformName": object.TerraformName(),
-				"ResourceName":  resourceName,
-				"IamClassName":  iamClassName,
+				"TerraformName":         object.TerraformName(),
+				"ResourceName":          resourceName,
+				"FrameworkResourceName": frameworkResourceName,
+				"ListResourceName":      listResourceName,
+				"EphemeralResourceName": ephemeralResourceName,
+				"IamClassName":          iamClassName,
 			})
 		}
 	}
@@ -0,0 +1,254 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/loader"
+)
+
+const testProductYaml = `
+name: 'Widgets'
+display_name: 'Widgets'
+versions:
+  - name: 'ga'
+    base_url: 'https://widgets.googleapis.com/v1/'
+scopes:
+  - 'https://www.googleapis.com/auth/cloud-platform'
+`
+
+// generateTestResource generates the GA resource of a product with a single
+// resource, Widget, and returns the generated resource file.
+func generateTestResource(t *testing.T, resourceYaml string) string {
+	t.Helper()
+
+	// Templates are read relative to the mmv1 directory
+	t.Chdir("..")
+	overrides := t.TempDir()
+	for name, contents := range map[string]string{
+		"products/widgets/product.yaml": testProductYaml,
+		"products/widgets/Widget.yaml":  resourceYaml,
+	} {
+		path := filepath.Join(overrides, name)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := loader.NewLoader(loader.Config{Version: "ga", OverrideDirectory: overrides})
+	product, err := l.LoadProduct("products/widgets")
+	if err != nil {
+		t.Fatalf("loading the product: %v", err)
+	}
+
+	output := t.TempDir()
+	NewTerraform(product, "ga", time.Now()).Generate(output, "Widget", true, false)
+	path := filepath.Join(output, "google/services/widgets/resource_widgets_widget.go")
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), path, b, parser.AllErrors); err != nil {
+		t.Fatalf("generated invalid Go code: %v", err)
+	}
+	return string(b)
+}
+
+func TestGenerateResourceLocks(t *testing.T) {
+	code := generateTestResource(t, `
+name: 'Widget'
+description: 'A widget.'
+base_url: 'projects/{{project}}/widgets'
+create_url: 'projects/{{project}}/widgets?widgetId={{name}}'
+update_verb: 'PATCH'
+locks:
+  - key: 'projects/{{project}}/widgets/{{name}}'
+  - key: 'projects/{{project}}'
+    mode: 'shared'
+    actions: ['create', 'delete']
+properties:
+  - name: 'name'
+    type: String
+    required: true
+    immutable: true
+    url_param_only: true
+    description: 'The name of the widget.'
+  - name: 'size'
+    type: Integer
+    description: 'The size of the widget.'
+`)
+
+	createAndDelete := `lockKeys, err := tpgresource.ReplaceLockKeys(d, config, []transport_tpg.LockKey{
+		{Key: "projects/{{project}}/widgets/{{name}}"},
+		{Key: "projects/{{project}}", Shared: true},
+	})`
+	update := `lockKeys, err := tpgresource.ReplaceLockKeys(d, config, []transport_tpg.LockKey{
+		{Key: "projects/{{project}}/widgets/{{name}}"},
+	})`
+	if n := strings.Count(code, createAndDelete); n != 2 {
+		t.Errorf("expected create and delete to lock both keys, got %d matches", n)
+	}
+	if n := strings.Count(code, update); n != 1 {
+		t.Errorf("expected update to lock the widget, got %d matches", n)
+	}
+	if n := strings.Count(code, "defer transport_tpg.MutexStore.LockKeys(lockKeys)()"); n != 3 {
+		t.Errorf("expected the locks to be held by 3 actions, got %d", n)
+	}
+}
+
+func TestGenerateResourceBatching(t *testing.T) {
+	code := generateTestResource(t, `
+name: 'Widget'
+description: 'A widget.'
+base_url: 'projects/{{project}}/widgets'
+create_url: 'projects/{{project}}/widgets?widgetId={{name}}'
+immutable: true
+batching:
+  key_template: 'projects/{{project}}/widgets'
+  combine_strategy: 'dedupe'
+  send_function: 'sendWidgetsBatch'
+  actions: ['create']
+properties:
+  - name: 'name'
+    type: String
+    required: true
+    url_param_only: true
+    description: 'The name of the widget.'
+  - name: 'size'
+    type: Integer
+    description: 'The size of the widget.'
+`)
+
+	for _, want := range []string{
+		`batchKey, err := tpgresource.ReplaceVars(d, config, "projects/{{project}}/widgets:create")`,
+		`res, err := transport_tpg.SendBatchedRequest(config.RequestBatcherWidgets, batchKey, transport_tpg.DedupeBatchedRequests, sendWidgetsBatch, transport_tpg.SendRequestOptions{`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected the generated resource to contain %q", want)
+		}
+	}
+	// Deletes aren't batched
+	if n := strings.Count(code, "SendBatchedRequest("); n != 1 {
+		t.Errorf("expected a single batched request, got %d", n)
+	}
+}
+
+func TestGeneratePollConditions(t *testing.T) {
+	code := generateTestResource(t, `
+name: 'Widget'
+description: 'A widget.'
+base_url: 'projects/{{project}}/widgets'
+create_url: 'projects/{{project}}/widgets?widgetId={{name}}'
+immutable: true
+async:
+  type: 'PollAsync'
+  actions: ['create', 'delete']
+  existence_conditions:
+    done:
+      - field: 'status.state'
+        equals: 'READY'
+    failed:
+      - field: 'status.state'
+        in: ['FAILED', 'ERROR']
+  absence_conditions:
+    done:
+      - field: 'deleteTime'
+        absent: true
+    not_found_codes: [403, 404]
+properties:
+  - name: 'name'
+    type: String
+    required: true
+    url_param_only: true
+    description: 'The name of the widget.'
+`)
+
+	for _, want := range []string{
+		`transport_tpg.PollCheckForExistenceConditions(transport_tpg.PollConditions{
+		Done: []transport_tpg.PollFieldCondition{
+			{Field: "status.state", Values: []string{"READY"}},
+		},
+		Failed: []transport_tpg.PollFieldCondition{
+			{Field: "status.state", Values: []string{"FAILED", "ERROR"}},
+		},
+	})`,
+		`transport_tpg.PollCheckForAbsenceConditions(transport_tpg.PollConditions{
+		Done: []transport_tpg.PollFieldCondition{
+			{Field: "deleteTime", Absent: true},
+		},
+		NotFoundCodes: []int{403, 404},
+	})`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected the generated resource to contain %q", want)
+		}
+	}
+}
+
+func TestGenerateResourceResumable(t *testing.T) {
+	code := generateTestResource(t, `
+name: 'Widget'
+description: 'A widget.'
+base_url: 'projects/{{project}}/widgets'
+create_url: 'projects/{{project}}/widgets?widgetId={{name}}'
+update_verb: 'PATCH'
+async:
+  type: 'OpAsync'
+  actions: ['create', 'delete', 'update']
+  operation:
+    base_url: '{{op_id}}'
+  resumable: true
+properties:
+  - name: 'name'
+    type: String
+    required: true
+    url_param_only: true
+    description: 'The name of the widget.'
+  - name: 'size'
+    type: Integer
+    description: 'The size of the widget.'
+`)
+
+	for _, want := range []string{
+		// A create whose wait stopped keeps the operation and succeeds
+		`return resourceWidgetsWidgetPersistCreateOperation(d, res, err)`,
+		`	if err := d.Set("operation", name); err != nil {
+		return fmt.Errorf("Error setting operation: %s", err)
+	}
+	return nil
+}`,
+		// Refreshes and updates resume waiting for it
+		`if err := resourceWidgetsWidgetResumeCreateOperation(d, config, billingProject, userAgent, d.Timeout(schema.TimeoutRead)); err != nil {`,
+		`if err := resourceWidgetsWidgetResumeCreateOperation(d, config, billingProject, userAgent, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		// Keep the prior state, so the update is planned again.
+		d.Partial(true)`,
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("expected the generated resource to contain %q", want)
+		}
+	}
+	if strings.Contains(code, "config.Context.Done()") {
+		t.Errorf("expected a create that timed out to succeed")
+	}
+}
@@ -14,18 +14,32 @@
 package provider
 
 import (
+	"bytes"
 	"fmt"
 	"log"
 	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"github.com/otiai10/copy"
 )
 
 // Code generator for a library converting GCP CAI objects to Terraform state.
 type CaiToTerraformConversion struct {
+	// Services are the packages under cai2hcl/services with converters.
+	Services []string
+
+	// HandwrittenConverters and Resources are the handwritten and generated
+	// converters, registered in the converter map.
+	HandwrittenConverters []Cai2hclHandwrittenConverter
+	Resources             []Cai2hclResource
+
 	TargetVersionName string
 
 	Version product.Version
@@ -44,19 +58,177 @@ func NewCaiToTerraformConversion(product *api.Product, versionName string, start
 	}
 
 	t.Product.SetPropertiesBasedOnVersion(&t.Version)
-	t.Product.SetCompiler(ProviderName(t))
+	t.Product.SetCompiler(cai2hclCompiler)
 	for _, r := range t.Product.Objects {
-		r.SetCompiler(ProviderName(t))
-		r.ImportPath = ImportPathFromVersion(versionName)
+		r.SetCompiler(cai2hclCompiler)
+		// The converters use the beta provider, like the rest of cai2hcl
+		r.ImportPath = ImportPathFromVersion("beta")
 	}
 
 	return t
 }
 
+// The converters are part of terraform-google-conversion, so templates leave
+// out the provider-only code they leave out of its other converters.
+const cai2hclCompiler = "TerraformGoogleConversionCai2hcl"
+
+// Cai2hclResource identifies a generated converter.
+type Cai2hclResource struct {
+	ServiceName   string
+	TerraformName string
+	ResourceName  string
+
+	// Only one converter can convert the assets of a CAI asset type, so this is
+	// false if another converter already converts them.
+	ConvertsAssetType bool
+}
+
+// Cai2hclHandwrittenConverter identifies a handwritten converter in
+// third_party/cai2hcl, whose converter isn't generated.
+type Cai2hclHandwrittenConverter struct {
+	ServiceName   string
+	TerraformName string
+	// Constructor is the function in the service package creating the
+	// converter.
+	Constructor string
+
+	// AssetTypes are the CAI asset types converted by the converter.
+	AssetTypes []Cai2hclAssetType
+}
+
+// Cai2hclAssetType is a CAI asset type, and the constant naming it in the
+// package of its converter.
+type Cai2hclAssetType struct {
+	Name     string
+	Constant string
+}
+
+// handwrittenCai2hclConverters are registered in the converter map before the
+// generated converters, in this order.
+var handwrittenCai2hclConverters = []Cai2hclHandwrittenConverter{
+	{"compute", "google_compute_instance", "NewComputeInstanceConverter", []Cai2hclAssetType{{"compute.googleapis.com/Instance", "ComputeInstanceAssetType"}}},
+	{"compute", "google_compute_forwarding_rule", "NewComputeForwardingRuleConverter", []Cai2hclAssetType{{"compute.googleapis.com/ForwardingRule", "ComputeForwardingRuleAssetType"}}},
+	{"compute", "google_compute_backend_service", "NewComputeBackendServiceConverter", []Cai2hclAssetType{{"compute.googleapis.com/BackendService", "ComputeBackendServiceAssetType"}}},
+	{"compute", "google_compute_region_backend_service", "NewComputeRegionBackendServiceConverter", []Cai2hclAssetType{{"compute.googleapis.com/RegionBackendService", "ComputeRegionBackendServiceAssetType"}}},
+	{"compute", "google_compute_region_health_check", "NewComputeRegionHealthCheckConverter", []Cai2hclAssetType{{"compute.googleapis.com/RegionHealthCheck", "ComputeRegionHealthCheckAssetType"}}},
+	{"resourcemanager", "google_project", "NewProjectConverter", []Cai2hclAssetType{{"cloudresourcemanager.googleapis.com/Project", "ProjectAssetType"}, {"cloudbilling.googleapis.com/ProjectBillingInfo", "ProjectBillingAssetType"}}},
+	{"networksecurity", "google_network_security_server_tls_policy", "NewServerTLSPolicyConverter", []Cai2hclAssetType{{"networksecurity.googleapis.com/ServerTlsPolicy", "ServerTLSPolicyAssetType"}}},
+	{"networksecurity", "google_network_security_backend_authentication_config", "NewBackendAuthenticationConfigConverter", []Cai2hclAssetType{{"networksecurity.googleapis.com/BackendAuthenticationConfig", "BackendAuthenticationConfigAssetType"}}},
+	{"certificatemanager", "google_certificate_manager_certificate", "NewCertificateConverter", []Cai2hclAssetType{{"certificatemanager.googleapis.com/Certificate", "CertificateAssetType"}}},
+}
+
+// hasHandwrittenCai2hclConverter returns whether a resource has a handwritten
+// converter.
+func hasHandwrittenCai2hclConverter(terraformName string) bool {
+	return slices.ContainsFunc(handwrittenCai2hclConverters, func(c Cai2hclHandwrittenConverter) bool {
+		return c.TerraformName == terraformName
+	})
+}
+
 func (cai2hcl CaiToTerraformConversion) Generate(outputFolder, resourceToGenerate string, generateCode, generateDocs bool) {
+	if !generateCode {
+		return
+	}
+
+	for _, object := range cai2hcl.Product.Objects {
+		object.ExcludeIfNotInVersion(&cai2hcl.Version)
+
+		if resourceToGenerate != "" && object.Name != resourceToGenerate {
+			log.Printf("Excluding %s per user request", object.Name)
+			continue
+		}
+
+		cai2hcl.GenerateObject(*object, outputFolder)
+	}
+}
+
+func (cai2hcl CaiToTerraformConversion) GenerateObject(object api.Resource, outputFolder string) {
+	if object.ExcludeTgc || object.IsExcluded() {
+		return
+	}
+	if hasHandwrittenCai2hclConverter(object.TerraformName()) {
+		log.Printf("Skipping %s, which has a handwritten converter", object.Name)
+		return
+	}
+
+	serviceName := strings.ToLower(cai2hcl.Product.Name)
+	targetFolder := path.Join(outputFolder, "services", serviceName)
+	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+	}
+
+	templateData := NewTemplateData(outputFolder, cai2hcl.TargetVersionName)
+	templatePath := "templates/tgc_cai2hcl/resource_converter.go.tmpl"
+	// Names like connectivity_test need their override to not be test files
+	name := object.FilenameOverride
+	if name == "" {
+		name = google.Underscore(object.Name)
+	}
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_%s.go", serviceName, name))
+	templateData.GenerateTGCResourceFile(templatePath, targetFilePath, object)
 }
 
 func (cai2hcl CaiToTerraformConversion) CompileCommonFiles(outputFolder string, products []*api.Product, overridePath string) {
+	log.Print("Compiling cai2hcl common files")
+
+	cai2hcl.generateResources(products)
+
+	templateData := NewTemplateData(outputFolder, cai2hcl.TargetVersionName)
+	templatePath := "templates/tgc_cai2hcl/converter_map.go.tmpl"
+	templateData.GenerateFile(filepath.Join(outputFolder, "converter_map.go"), templatePath, cai2hcl, true, templatePath)
+}
+
+// Lists the generated converters and the services with converters, in the
+// same order for every run. When several resources share an asset type, the
+// first by Terraform name converts its assets.
+func (cai2hcl *CaiToTerraformConversion) generateResources(products []*api.Product) {
+	convertedAssetTypes := make(map[string]bool)
+	cai2hcl.HandwrittenConverters = handwrittenCai2hclConverters
+	for _, converter := range handwrittenCai2hclConverters {
+		cai2hcl.Services = append(cai2hcl.Services, converter.ServiceName)
+		for _, assetType := range converter.AssetTypes {
+			convertedAssetTypes[assetType.Name] = true
+		}
+	}
+
+	var resources []*api.Resource
+	for _, productDefinition := range products {
+		version := productDefinition.VersionObjOrClosest(cai2hcl.TargetVersionName)
+		if productDefinition.BaseUrl == "" {
+			// Products that weren't generated in this run, such as with
+			// --product, don't have the base URLs their asset types are built
+			// from set yet.
+			productDefinition.SetPropertiesBasedOnVersion(version)
+		}
+		for _, object := range productDefinition.Objects {
+			if object.IsExcluded() || object.ExcludeTgc || object.NotInVersion(version) {
+				continue
+			}
+			if hasHandwrittenCai2hclConverter(object.TerraformName()) {
+				continue
+			}
+			resources = append(resources, object)
+		}
+	}
+	slices.SortFunc(resources, func(r1, r2 *api.Resource) int {
+		return strings.Compare(r1.TerraformName(), r2.TerraformName())
+	})
+
+	for _, object := range resources {
+		serviceName := strings.ToLower(object.ProductMetadata.Name)
+		assetType := object.CaiAssetType()
+		cai2hcl.Services = append(cai2hcl.Services, serviceName)
+		cai2hcl.Resources = append(cai2hcl.Resources, Cai2hclResource{
+			ServiceName:       serviceName,
+			TerraformName:     object.TerraformName(),
+			ResourceName:      object.ResourceName(),
+			ConvertsAssetType: !convertedAssetTypes[assetType],
+		})
+		convertedAssetTypes[assetType] = true
+	}
+
+	slices.Sort(cai2hcl.Services)
+	cai2hcl.Services = slices.Compact(cai2hcl.Services)
 }
 
 func (cai2hcl CaiToTerraformConversion) CopyCommonFiles(outputFolder string, generateCode, generateDocs bool) {
@@ -72,4 +244,25 @@ func (cai2hcl CaiToTerraformConversion) CopyCommonFiles(outputFolder string, gen
 	if err := copy.Copy("third_party/cai2hcl", outputFolder); err != nil {
 		log.Println(fmt.Errorf("error copying directory %v: %v", outputFolder, err))
 	}
+
+	// Helpers shared by the flatteners of several generated converters
+	helpers := map[string]string{
+		"services/privateca/privateca_utils.go": "third_party/terraform/services/privateca/privateca_utils.go",
+		"services/compute/image.go":             "third_party/terraform/services/compute/image.go",
+	}
+	for target, source := range helpers {
+		targetFile := filepath.Join(outputFolder, target)
+		if err := os.MkdirAll(filepath.Dir(targetFile), os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating output directory %v: %v", filepath.Dir(targetFile), err))
+		}
+		sourceByte, err := os.ReadFile(source)
+		if err != nil {
+			log.Fatalf("Cannot read source file %s while copying: %s", source, err)
+		}
+		// Use the beta provider, like the rest of cai2hcl
+		sourceByte = bytes.ReplaceAll(sourceByte, []byte(ImportPathFromVersion("ga")), []byte(ImportPathFromVersion("beta")))
+		if err := os.WriteFile(targetFile, sourceByte, 0644); err != nil {
+			log.Fatalf("Cannot write target file %s while copying: %s", target, err)
+		}
+	}
 }
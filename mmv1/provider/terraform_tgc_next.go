@@ -228,6 +228,8 @@ func (tgc TerraformGoogleConversionNext) CopyCommonFiles(outputFolder string, ge
 		// common
 		"pkg/transport/batcher.go":                 "third_party/terraform/transport/batcher.go",
 		"pkg/transport/retry_transport.go":         "third_party/terraform/transport/retry_transport.go",
+		"pkg/transport/rate_limiter.go":            "third_party/terraform/transport/rate_limiter.go",
+		"pkg/transport/audit_log.go":               "third_party/terraform/transport/audit_log.go",
 		"pkg/transport/retry_utils.go":             "third_party/terraform/transport/retry_utils.go",
 		"pkg/transport/header_transport.go":        "third_party/terraform/transport/header_transport.go",
 		"pkg/transport/error_retry_predicates.go":  "third_party/terraform/transport/error_retry_predicates.go",
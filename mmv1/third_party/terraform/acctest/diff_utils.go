@@ -23,14 +23,17 @@ func isReleaseDiffEnabled() bool {
 	return releaseDiff == "true"
 }
 
-func initializeReleaseDiffTest(c resource.TestCase, testName string, tempOutputFile *os.File) resource.TestCase {
-	var releaseProvider string
+// releaseProviderName returns the name of the released provider matching the local build
+func releaseProviderName() string {
 	packagePath := fmt.Sprint(reflect.TypeOf(transport_tpg.Config{}).PkgPath())
 	if strings.Contains(packagePath, "google-beta") {
-		releaseProvider = "google-beta"
-	} else {
-		releaseProvider = "google"
+		return "google-beta"
 	}
+	return "google"
+}
+
+func initializeReleaseDiffTest(c resource.TestCase, testName string, tempOutputFile *os.File) resource.TestCase {
+	releaseProvider := releaseProviderName()
 
 	if c.ExternalProviders != nil {
 		c.ExternalProviders[releaseProvider] = resource.ExternalProvider{}
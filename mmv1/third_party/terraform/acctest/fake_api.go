@@ -0,0 +1,35 @@
+package acctest
+
+import (
+	"net/http/httptest"
+	"os"
+	"sync"
+
+	"github.com/hashicorp/terraform-provider-google/google/fakeapi"
+)
+
+// fakeApiAccessToken is the access token sent to the fake API, which doesn't
+// check credentials.
+const fakeApiAccessToken = "fake-api-access-token"
+
+var fakeApiOnce sync.Once
+
+// isFakeApiEnabled returns whether acceptance tests run against the in-memory
+// fake of the generated products, rather than Google Cloud.
+func isFakeApiEnabled() bool {
+	return os.Getenv("GOOGLE_FAKE_API") != ""
+}
+
+// setupFakeApi starts the fake API once per test binary, and points the
+// custom endpoints of the generated products to it. A fake access token
+// replaces any configured credentials, so that they're never sent to APIs
+// the fake doesn't serve.
+func setupFakeApi() {
+	fakeApiOnce.Do(func() {
+		server := httptest.NewServer(fakeapi.NewServer(fakeapi.Products, fakeapi.Resources))
+		for envVar, endpoint := range fakeapi.CustomEndpoints(server.URL, fakeapi.Products) {
+			os.Setenv(envVar, endpoint)
+		}
+		os.Setenv("GOOGLE_OAUTH_ACCESS_TOKEN", fakeApiAccessToken)
+	})
+}
@@ -53,6 +53,10 @@ func GoogleProviderConfig(t *testing.T) *transport_tpg.Config {
 }
 
 func AccTestPreCheck(t *testing.T) {
+	if isFakeApiEnabled() {
+		setupFakeApi()
+	}
+
 	if v := os.Getenv("GOOGLE_CREDENTIALS_FILE"); v != "" {
 		creds, err := ioutil.ReadFile(v)
 		if err != nil {
@@ -61,7 +65,8 @@ func AccTestPreCheck(t *testing.T) {
 		os.Setenv("GOOGLE_CREDENTIALS", string(creds))
 	}
 
-	if v := transport_tpg.MultiEnvSearch(envvar.CredsEnvVars); v == "" {
+	// The fake API doesn't need credentials
+	if v := transport_tpg.MultiEnvSearch(envvar.CredsEnvVars); v == "" && !isFakeApiEnabled() {
 		t.Fatalf("One of %s must be set for acceptance tests", strings.Join(envvar.CredsEnvVars, ", "))
 	}
 
@@ -0,0 +1,92 @@
+package acctest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// stateUpgradeVersions returns the released provider versions that state upgrade tests
+// start from, read from the comma-separated STATE_UPGRADE_VERSIONS environment variable.
+// Entries may be exact versions such as "6.49.0" or constraints such as "< 7.0.0" for the
+// last release of the previous major version.
+func stateUpgradeVersions() []string {
+	var versions []string
+	for _, v := range strings.Split(os.Getenv("STATE_UPGRADE_VERSIONS"), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			versions = append(versions, v)
+		}
+	}
+	return versions
+}
+
+// StateUpgradeTest checks that state written by older provider releases upgrades cleanly
+// in the local provider. For each version in STATE_UPGRADE_VERSIONS, the configs in the
+// test case are applied with that release and then planned with the local provider, which
+// must report no changes. The test is skipped when no versions are set, and in VCR mode as
+// the released provider's requests can't be recorded.
+func StateUpgradeTest(t *testing.T, c resource.TestCase) {
+	t.Helper()
+
+	versions := stateUpgradeVersions()
+	if len(versions) == 0 {
+		t.Skipf("STATE_UPGRADE_VERSIONS not set, skipping state upgrade test: %s", t.Name())
+	}
+	SkipIfVcr(t)
+
+	// Provider configs are cached by test name, so the subtests share the parent's name
+	// for the local provider.
+	testName := t.Name()
+	releaseProvider := releaseProviderName()
+	localProviderName := "google-local"
+	for _, version := range versions {
+		version := version
+		t.Run(version, func(t *testing.T) {
+			tc := c
+			tc.Providers = nil
+			tc.ExternalProviders = map[string]resource.ExternalProvider{
+				releaseProvider: {
+					Source:            fmt.Sprintf("hashicorp/%s", releaseProvider),
+					VersionConstraint: version,
+				},
+			}
+			tc.ProtoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+				localProviderName: func() (tfprotov5.ProviderServer, error) {
+					provider, err := MuxedProviders(testName)
+					return provider(), err
+				},
+			}
+			tc = InsertStateUpgradeSteps(tc, releaseProvider, localProviderName)
+			resource.Test(t, tc)
+		})
+	}
+}
+
+// InsertStateUpgradeSteps replaces the steps of the test case with, for each step that
+// applies a config, a step applying it with the release provider followed by a plan-only
+// step with the local provider. Import steps, plan-only steps and steps expecting an error
+// are dropped, as they don't write state.
+func InsertStateUpgradeSteps(c resource.TestCase, releaseProvider string, localProviderName string) resource.TestCase {
+	var steps []resource.TestStep
+	for _, testStep := range c.Steps {
+		if testStep.Config == "" || testStep.ExpectError != nil || testStep.PlanOnly {
+			continue
+		}
+		steps = append(steps,
+			resource.TestStep{
+				Config: ReformConfigWithProvider(testStep.Config, releaseProvider),
+			},
+			resource.TestStep{
+				Config:             ReformConfigWithProvider(testStep.Config, localProviderName),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		)
+	}
+	c.Steps = steps
+	return c
+}
@@ -0,0 +1,86 @@
+package acctest_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-google/google/acctest"
+)
+
+func TestInsertStateUpgradeSteps(t *testing.T) {
+	dummyCase := resource.TestCase{
+		Steps: []resource.TestStep{
+			{
+				Config: `resource "google_new_resource" "original" {
+                    provider = google-beta
+                }`,
+			},
+			{
+				ResourceName:      "google_new_resource.original",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: `resource "google_example_widget" "foo" {
+  name = "dummy"
+}`,
+			},
+			{
+				Config:   `resource "google_example_widget" "foo" {}`,
+				PlanOnly: true,
+			},
+			{
+				Config:      `resource "google_example_widget" "foo" {}`,
+				ExpectError: regexp.MustCompile("error"),
+			},
+		},
+	}
+	dummyCase = acctest.InsertStateUpgradeSteps(dummyCase, "google", "google-local")
+
+	// Each step applying a config becomes an apply with the release provider followed by a
+	// plan with the local provider; every other step is dropped.
+	expectedSteps := []resource.TestStep{
+		{
+			Config: `resource "google_new_resource" "original" {
+                    provider = google
+                }`,
+		},
+		{
+			Config: `resource "google_new_resource" "original" {
+                    provider = google-local
+                }`,
+			PlanOnly: true,
+		},
+		{
+			Config: `resource "google_example_widget" "foo" {
+  provider = google
+
+  name = "dummy"
+}`,
+		},
+		{
+			Config: `resource "google_example_widget" "foo" {
+  provider = google-local
+
+  name = "dummy"
+}`,
+			PlanOnly: true,
+		},
+	}
+
+	if len(dummyCase.Steps) != len(expectedSteps) {
+		t.Fatalf("Expected %d steps, but got %d", len(expectedSteps), len(dummyCase.Steps))
+	}
+	for i, step := range dummyCase.Steps {
+		if step.Config != expectedSteps[i].Config {
+			t.Fatalf("Expected step %d config to be:\n%q\nbut got:\n%q", i, expectedSteps[i].Config, step.Config)
+		}
+		if step.PlanOnly != expectedSteps[i].PlanOnly {
+			t.Fatalf("Expected step %d to have PlanOnly set to %v, but got %v", i, expectedSteps[i].PlanOnly, step.PlanOnly)
+		}
+		if step.ExpectNonEmptyPlan {
+			t.Fatalf("Expected step %d to expect an empty plan", i)
+		}
+	}
+}
@@ -0,0 +1,286 @@
+package acctest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dnaeon/go-vcr/cassette"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// RedactedValue replaces the values of redacted fields and headers in
+// recorded cassettes.
+const RedactedValue = "REDACTED"
+
+// VcrMatchConfig configures how the requests of a VCR test are matched with
+// the interactions recorded in its cassette, for requests containing values
+// that change between runs, and which values are redacted from the cassette
+// when it's recorded.
+//
+// Paths are JSON pointers (RFC 6901) into request bodies, such as
+// `/metadata/requestId`, where a `*` token matches any object key or array
+// index, such as `/rules/*/id`. An empty path refers to the whole body.
+type VcrMatchConfig struct {
+	// IgnoreBodyPaths are fields of request bodies that are ignored when
+	// matching, such as generated request IDs or timestamps.
+	IgnoreBodyPaths []string
+	// UnorderedBodyPaths are repeated fields of request bodies compared as
+	// sets, whose order doesn't matter.
+	UnorderedBodyPaths []string
+	// IgnoreQueryParams are query parameters that are ignored when matching.
+	// The order of query parameters is always ignored.
+	IgnoreQueryParams []string
+	// RedactBodyPaths are fields of request and response bodies replaced by
+	// RedactedValue when recording, such as secrets. They're ignored when
+	// matching.
+	RedactBodyPaths []string
+	// RedactHeaders are request and response headers replaced by
+	// RedactedValue when recording.
+	RedactHeaders []string
+}
+
+var matchConfigsLock = sync.RWMutex{}
+
+var matchConfigs = map[string]VcrMatchConfig{}
+
+// SetVcrMatchConfig sets how the requests of a VCR test are matched with its
+// cassette. It must be called before VcrTest.
+func SetVcrMatchConfig(t *testing.T, c VcrMatchConfig) {
+	matchConfigsLock.Lock()
+	matchConfigs[t.Name()] = c
+	matchConfigsLock.Unlock()
+
+	t.Cleanup(func() {
+		matchConfigsLock.Lock()
+		delete(matchConfigs, t.Name())
+		matchConfigsLock.Unlock()
+	})
+}
+
+func vcrMatchConfig(testName string) VcrMatchConfig {
+	matchConfigsLock.RLock()
+	defer matchConfigsLock.RUnlock()
+	return matchConfigs[testName]
+}
+
+// NewVcrMatcherFuncWithConfig returns a function used for matching HTTP
+// requests with data recorded in VCR cassettes, following the rules of c.
+func NewVcrMatcherFuncWithConfig(ctx context.Context, c VcrMatchConfig) func(r *http.Request, i cassette.Request) bool {
+	return func(r *http.Request, i cassette.Request) bool {
+		if r.Method != i.Method || !urlsMatch(r.URL, i.URL, c.IgnoreQueryParams) {
+			return false
+		}
+		if r.Body == nil {
+			return true
+		}
+		contentType := r.Header.Get("Content-Type")
+		// If body contains media, don't try to compare
+		if strings.Contains(contentType, "multipart/related") {
+			return true
+		}
+
+		var b bytes.Buffer
+		if _, err := b.ReadFrom(r.Body); err != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Failed to read request body from cassette: %v", err))
+			return false
+		}
+		r.Body = ioutil.NopCloser(&b)
+		reqBody := b.String()
+		// If body matches identically, we are done
+		if reqBody == i.Body {
+			return true
+		}
+
+		// JSON might be the same, but reordered or with ignored fields. Try
+		// parsing json and comparing
+		if strings.Contains(contentType, "application/json") {
+			var reqJson, cassetteJson interface{}
+			if err := json.Unmarshal([]byte(reqBody), &reqJson); err != nil {
+				tflog.Debug(ctx, fmt.Sprintf("Failed to unmarshal request json: %v", err))
+				return false
+			}
+			if err := json.Unmarshal([]byte(i.Body), &cassetteJson); err != nil {
+				tflog.Debug(ctx, fmt.Sprintf("Failed to unmarshal cassette json: %v", err))
+				return false
+			}
+			return reflect.DeepEqual(c.normalizeBody(reqJson), c.normalizeBody(cassetteJson))
+		}
+		return false
+	}
+}
+
+// urlsMatch compares a request URL with a recorded one, regardless of the
+// order of their query parameters.
+func urlsMatch(u *url.URL, recorded string, ignoreQueryParams []string) bool {
+	ru, err := url.Parse(recorded)
+	if err != nil {
+		return false
+	}
+	query, recordedQuery := u.Query(), ru.Query()
+	withoutQuery, recordedWithoutQuery := *u, *ru
+	withoutQuery.RawQuery, recordedWithoutQuery.RawQuery = "", ""
+	if withoutQuery.String() != recordedWithoutQuery.String() {
+		return false
+	}
+
+	for _, p := range ignoreQueryParams {
+		query.Del(p)
+		recordedQuery.Del(p)
+	}
+	return reflect.DeepEqual(query, recordedQuery)
+}
+
+// normalizeBody removes the ignored and redacted fields of a parsed JSON body,
+// and sorts its unordered fields.
+func (c VcrMatchConfig) normalizeBody(body interface{}) interface{} {
+	for _, p := range append(append([]string{}, c.IgnoreBodyPaths...), c.RedactBodyPaths...) {
+		body = rewriteJsonPointer(body, jsonPointerTokens(p), func(interface{}) interface{} {
+			return removedJsonValue{}
+		})
+	}
+
+	// Nested unordered fields are sorted first, as the order of their
+	// elements changes how the fields containing them sort.
+	unordered := append([]string{}, c.UnorderedBodyPaths...)
+	sort.SliceStable(unordered, func(i, j int) bool {
+		return len(jsonPointerTokens(unordered[i])) > len(jsonPointerTokens(unordered[j]))
+	})
+	for _, p := range unordered {
+		body = rewriteJsonPointer(body, jsonPointerTokens(p), func(v interface{}) interface{} {
+			elems, ok := v.([]interface{})
+			if !ok {
+				return v
+			}
+			sort.SliceStable(elems, func(i, j int) bool {
+				return canonicalJson(elems[i]) < canonicalJson(elems[j])
+			})
+			return elems
+		})
+	}
+	return body
+}
+
+// redactBody replaces the redacted fields of a JSON body by RedactedValue.
+// Bodies without any are returned unchanged.
+func (c VcrMatchConfig) redactBody(body string) string {
+	var v interface{}
+	if len(c.RedactBodyPaths) == 0 || json.Unmarshal([]byte(body), &v) != nil {
+		return body
+	}
+	redacted := false
+	for _, p := range c.RedactBodyPaths {
+		v = rewriteJsonPointer(v, jsonPointerTokens(p), func(interface{}) interface{} {
+			redacted = true
+			return RedactedValue
+		})
+	}
+	if !redacted {
+		return body
+	}
+	return canonicalJson(v)
+}
+
+func (c VcrMatchConfig) redactHeaders(h http.Header) {
+	for _, name := range c.RedactHeaders {
+		if h.Get(name) != "" {
+			h.Set(name, RedactedValue)
+		}
+	}
+}
+
+// redactCassette redacts the interactions of a recorded cassette once it's
+// saved. Interactions can't be redacted while recording, as the recorder
+// returns their recorded responses to the test.
+func redactCassette(path string, c VcrMatchConfig) error {
+	if len(c.RedactBodyPaths) == 0 && len(c.RedactHeaders) == 0 {
+		return nil
+	}
+	cas, err := cassette.Load(path)
+	if os.IsNotExist(err) {
+		// Cassettes without interactions aren't saved
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("loading cassette %s for redaction: %w", path, err)
+	}
+	for _, i := range cas.Interactions {
+		i.Request.Body = c.redactBody(i.Request.Body)
+		i.Response.Body = c.redactBody(i.Response.Body)
+		c.redactHeaders(i.Request.Headers)
+		c.redactHeaders(i.Response.Headers)
+	}
+	return cas.Save()
+}
+
+// removedJsonValue marks a value removed by rewriteJsonPointer.
+type removedJsonValue struct{}
+
+// jsonPointerTokens splits a JSON pointer into its unescaped reference tokens.
+func jsonPointerTokens(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, t := range tokens {
+		tokens[i] = strings.ReplaceAll(strings.ReplaceAll(t, "~1", "/"), "~0", "~")
+	}
+	return tokens
+}
+
+// rewriteJsonPointer replaces the values referenced by the tokens of a JSON
+// pointer by the result of f. Object fields replaced by removedJsonValue are
+// deleted, and array elements are set to null to keep the indices of the
+// others.
+func rewriteJsonPointer(v interface{}, tokens []string, f func(interface{}) interface{}) interface{} {
+	if len(tokens) == 0 {
+		return f(v)
+	}
+	token, rest := tokens[0], tokens[1:]
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if token != "*" && token != k {
+				continue
+			}
+			nv := rewriteJsonPointer(child, rest, f)
+			if _, removed := nv.(removedJsonValue); removed {
+				delete(t, k)
+			} else {
+				t[k] = nv
+			}
+		}
+	case []interface{}:
+		for i, child := range t {
+			if token != "*" && token != strconv.Itoa(i) {
+				continue
+			}
+			nv := rewriteJsonPointer(child, rest, f)
+			if _, removed := nv.(removedJsonValue); removed {
+				t[i] = nil
+			} else {
+				t[i] = nv
+			}
+		}
+	}
+	return v
+}
+
+func canonicalJson(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
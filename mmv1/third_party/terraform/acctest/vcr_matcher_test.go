@@ -0,0 +1,214 @@
+package acctest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-provider-google/google/acctest"
+)
+
+func TestNewVcrMatcherFuncWithConfig(t *testing.T) {
+	jsonHeaders := map[string]string{
+		"Content-Type": "application/json",
+	}
+
+	cases := map[string]struct {
+		config          acctest.VcrMatchConfig
+		httpRequest     requestDescription
+		cassetteRequest requestDescription
+		expectMatch     bool
+	}{
+		"matches reordered query parameters": {
+			httpRequest: requestDescription{
+				scheme: "https",
+				method: "GET",
+				host:   "example.com",
+				path:   "foobar",
+				query:  "alt=json&pageSize=10",
+			},
+			cassetteRequest: requestDescription{
+				scheme: "https",
+				method: "GET",
+				host:   "example.com",
+				path:   "foobar",
+				query:  "pageSize=10&alt=json",
+			},
+			expectMatch: true,
+		},
+		"doesn't match different query parameters": {
+			httpRequest: requestDescription{
+				scheme: "https",
+				method: "GET",
+				host:   "example.com",
+				path:   "foobar",
+				query:  "pageToken=abc",
+			},
+			cassetteRequest: requestDescription{
+				scheme: "https",
+				method: "GET",
+				host:   "example.com",
+				path:   "foobar",
+				query:  "pageToken=def",
+			},
+		},
+		"matches ignored query parameters": {
+			config: acctest.VcrMatchConfig{
+				IgnoreQueryParams: []string{"requestId"},
+			},
+			httpRequest: requestDescription{
+				scheme: "https",
+				method: "DELETE",
+				host:   "example.com",
+				path:   "foobar",
+				query:  "requestId=1234",
+			},
+			cassetteRequest: requestDescription{
+				scheme: "https",
+				method: "DELETE",
+				host:   "example.com",
+				path:   "foobar",
+				query:  "requestId=5678",
+			},
+			expectMatch: true,
+		},
+		"matches ignored body fields": {
+			config: acctest.VcrMatchConfig{
+				IgnoreBodyPaths: []string{"/requestId", "/rules/*/createTime"},
+			},
+			httpRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "example.com",
+				path:    "foobar",
+				headers: jsonHeaders,
+				body:    `{"requestId":"1234","rules":[{"name":"a","createTime":"2025-01-01T00:00:00Z"}]}`,
+			},
+			cassetteRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "example.com",
+				path:    "foobar",
+				headers: jsonHeaders,
+				body:    `{"requestId":"5678","rules":[{"name":"a","createTime":"2024-01-01T00:00:00Z"}]}`,
+			},
+			expectMatch: true,
+		},
+		"doesn't match other body fields than the ignored ones": {
+			config: acctest.VcrMatchConfig{
+				IgnoreBodyPaths: []string{"/requestId"},
+			},
+			httpRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "example.com",
+				path:    "foobar",
+				headers: jsonHeaders,
+				body:    `{"requestId":"1234","name":"a"}`,
+			},
+			cassetteRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "example.com",
+				path:    "foobar",
+				headers: jsonHeaders,
+				body:    `{"requestId":"5678","name":"b"}`,
+			},
+		},
+		"matches reordered unordered fields": {
+			config: acctest.VcrMatchConfig{
+				UnorderedBodyPaths: []string{"/rules", "/rules/*/ports"},
+			},
+			httpRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "example.com",
+				path:    "foobar",
+				headers: jsonHeaders,
+				body:    `{"rules":[{"name":"a","ports":["80","443"]},{"name":"b"}]}`,
+			},
+			cassetteRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "example.com",
+				path:    "foobar",
+				headers: jsonHeaders,
+				body:    `{"rules":[{"name":"b"},{"name":"a","ports":["443","80"]}]}`,
+			},
+			expectMatch: true,
+		},
+		"doesn't match reordered fields that aren't unordered": {
+			httpRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "example.com",
+				path:    "foobar",
+				headers: jsonHeaders,
+				body:    `{"rules":[{"name":"a"},{"name":"b"}]}`,
+			},
+			cassetteRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "example.com",
+				path:    "foobar",
+				headers: jsonHeaders,
+				body:    `{"rules":[{"name":"b"},{"name":"a"}]}`,
+			},
+		},
+		"matches redacted body fields": {
+			config: acctest.VcrMatchConfig{
+				RedactBodyPaths: []string{"/password"},
+			},
+			httpRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "example.com",
+				path:    "foobar",
+				headers: jsonHeaders,
+				body:    `{"name":"a","password":"hunter2"}`,
+			},
+			cassetteRequest: requestDescription{
+				scheme:  "https",
+				method:  "POST",
+				host:    "example.com",
+				path:    "foobar",
+				headers: jsonHeaders,
+				body:    `{"name":"a","password":"REDACTED"}`,
+			},
+			expectMatch: true,
+		},
+		"matches escaped JSON pointers": {
+			config: acctest.VcrMatchConfig{
+				IgnoreBodyPaths: []string{"/labels/goog~1requestId"},
+			},
+			httpRequest: requestDescription{
+				scheme:  "https",
+				method:  "PATCH",
+				host:    "example.com",
+				path:    "foobar",
+				headers: jsonHeaders,
+				body:    `{"labels":{"goog/requestId":"1234"}}`,
+			},
+			cassetteRequest: requestDescription{
+				scheme:  "https",
+				method:  "PATCH",
+				host:    "example.com",
+				path:    "foobar",
+				headers: jsonHeaders,
+				body:    `{"labels":{"goog/requestId":"5678"}}`,
+			},
+			expectMatch: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			req := prepareHttpRequest(tc.httpRequest)
+			cassetteReq := prepareCassetteRequest(tc.cassetteRequest)
+			matcher := acctest.NewVcrMatcherFuncWithConfig(context.Background(), tc.config)
+
+			if matchDetected := matcher(req, cassetteReq); matchDetected != tc.expectMatch {
+				t.Fatalf("expected match to be %t, got %t", tc.expectMatch, matchDetected)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package acctest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/dnaeon/go-vcr/cassette"
+)
+
+// VcrReplayReport lists the interactions of a cassette that weren't matched
+// by any request when replaying its test, which `magician cassette lint`
+// reports as stale.
+type VcrReplayReport struct {
+	Interactions int   `json:"interactions"`
+	Unmatched    []int `json:"unmatched"`
+}
+
+// vcrReplayTracker records the recorded requests matched while replaying a
+// test.
+type vcrReplayTracker struct {
+	mu      sync.Mutex
+	matched []cassette.Request
+}
+
+var replayTrackersLock = sync.RWMutex{}
+
+var replayTrackers = map[string]*vcrReplayTracker{}
+
+// trackVcrReplay wraps the matcher of a test replaying its cassette, to write
+// a VcrReplayReport to the VCR_REPLAY_REPORT_PATH directory once it's done.
+func trackVcrReplay(testName string, matcher cassette.Matcher) cassette.Matcher {
+	if os.Getenv("VCR_REPLAY_REPORT_PATH") == "" {
+		return matcher
+	}
+	tracker := &vcrReplayTracker{}
+	replayTrackersLock.Lock()
+	replayTrackers[testName] = tracker
+	replayTrackersLock.Unlock()
+
+	return func(r *http.Request, i cassette.Request) bool {
+		if !matcher(r, i) {
+			return false
+		}
+		// A recorded request is only replayed once, when it's the first
+		// unreplayed one matching the request.
+		tracker.mu.Lock()
+		tracker.matched = append(tracker.matched, i)
+		tracker.mu.Unlock()
+		return true
+	}
+}
+
+// closeVcrReplayTracker stops tracking the replay of a test, and writes its
+// VcrReplayReport if writeReport is set. Reports of failed tests aren't
+// written, as they may have stopped before sending all their requests.
+func closeVcrReplayTracker(testName, cassettePath string, writeReport bool) error {
+	replayTrackersLock.Lock()
+	tracker, ok := replayTrackers[testName]
+	delete(replayTrackers, testName)
+	replayTrackersLock.Unlock()
+	if !ok || !writeReport {
+		return nil
+	}
+
+	cas, err := cassette.Load(cassettePath)
+	if err != nil {
+		return fmt.Errorf("loading cassette %s for replay report: %w", cassettePath, err)
+	}
+
+	// Identical requests are interchangeable, so matched requests are
+	// attributed to the first interactions recording them.
+	matched := make(map[string]int)
+	for _, r := range tracker.matched {
+		matched[replayKey(r)]++
+	}
+	report := VcrReplayReport{Interactions: len(cas.Interactions), Unmatched: []int{}}
+	for idx, i := range cas.Interactions {
+		key := replayKey(i.Request)
+		if matched[key] > 0 {
+			matched[key]--
+			continue
+		}
+		report.Unmatched = append(report.Unmatched, idx)
+	}
+
+	data, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+	reportPath := filepath.Join(os.Getenv("VCR_REPLAY_REPORT_PATH"), fmt.Sprintf("%s.json", vcrFileName(testName)))
+	return os.WriteFile(reportPath, data, 0644)
+}
+
+func replayKey(r cassette.Request) string {
+	return r.Method + " " + r.URL + " " + r.Body
+}
@@ -3,16 +3,13 @@ package acctest
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"log"
 	"math/rand"
 	"net/http"
 	"os"
 	"path/filepath"
-	"reflect"
 	"slices"
 	"strconv"
 	"strings"
@@ -201,6 +198,13 @@ func closeRecorder(t *testing.T) {
 				t.Error(err)
 			}
 			envPath := os.Getenv("VCR_PATH")
+			// Cassettes are only redacted when recorded, so replaying leaves them untouched
+			if os.Getenv("VCR_MODE") == "RECORDING" {
+				err = redactCassette(filepath.Join(envPath, vcrFileName(t.Name())), vcrMatchConfig(t.Name()))
+				if err != nil {
+					t.Error(err)
+				}
+			}
 
 			sourcesLock.RLock()
 			vcrSource, ok := sources[t.Name()]
@@ -212,6 +216,11 @@ func closeRecorder(t *testing.T) {
 				}
 			}
 		}
+		err := closeVcrReplayTracker(t.Name(), filepath.Join(os.Getenv("VCR_PATH"), vcrFileName(t.Name())), !t.Failed())
+		if err != nil {
+			t.Error(err)
+		}
+
 		// Clean up test config
 		configsLock.Lock()
 		delete(configs, t.Name())
@@ -256,54 +265,18 @@ func HandleVCRConfiguration(ctx context.Context, testName string, rndTripper htt
 		return pollInterval, rndTripper, diags
 	}
 	// Defines how VCR will match requests to responses.
-	rec.SetMatcher(NewVcrMatcherFunc(ctx))
+	var matcher cassette.Matcher = NewVcrMatcherFuncWithConfig(ctx, vcrMatchConfig(testName))
+	if vcrMode == recorder.ModeReplaying {
+		matcher = trackVcrReplay(testName, matcher)
+	}
+	rec.SetMatcher(matcher)
 
 	return pollInterval, rec, diags
 }
 
 // NewVcrMatcherFunc returns a function used for matching HTTP requests with data recorded in VCR cassettes
 func NewVcrMatcherFunc(ctx context.Context) func(r *http.Request, i cassette.Request) bool {
-	return func(r *http.Request, i cassette.Request) bool {
-		// Default matcher compares method and URL only
-		if !cassette.DefaultMatcher(r, i) {
-			return false
-		}
-		if r.Body == nil {
-			return true
-		}
-		contentType := r.Header.Get("Content-Type")
-		// If body contains media, don't try to compare
-		if strings.Contains(contentType, "multipart/related") {
-			return true
-		}
-
-		var b bytes.Buffer
-		if _, err := b.ReadFrom(r.Body); err != nil {
-			tflog.Debug(ctx, fmt.Sprintf("Failed to read request body from cassette: %v", err))
-			return false
-		}
-		r.Body = ioutil.NopCloser(&b)
-		reqBody := b.String()
-		// If body matches identically, we are done
-		if reqBody == i.Body {
-			return true
-		}
-
-		// JSON might be the same, but reordered. Try parsing json and comparing
-		if strings.Contains(contentType, "application/json") {
-			var reqJson, cassetteJson interface{}
-			if err := json.Unmarshal([]byte(reqBody), &reqJson); err != nil {
-				tflog.Debug(ctx, fmt.Sprintf("Failed to unmarshal request json: %v", err))
-				return false
-			}
-			if err := json.Unmarshal([]byte(i.Body), &cassetteJson); err != nil {
-				tflog.Debug(ctx, fmt.Sprintf("Failed to unmarshal cassette json: %v", err))
-				return false
-			}
-			return reflect.DeepEqual(reqJson, cassetteJson)
-		}
-		return false
-	}
+	return NewVcrMatcherFuncWithConfig(ctx, VcrMatchConfig{})
 }
 
 // MuxedProviders configures the providers, thus, if we want the providers to be configured
@@ -319,15 +319,17 @@ type requestDescription struct {
 	method  string
 	host    string
 	path    string
+	query   string
 	body    string
 	headers map[string]string
 }
 
 func prepareHttpRequest(d requestDescription) *http.Request {
 	url := &url.URL{
-		Scheme: d.scheme,
-		Host:   d.host,
-		Path:   d.path,
+		Scheme:   d.scheme,
+		Host:     d.host,
+		Path:     d.path,
+		RawQuery: d.query,
 	}
 
 	req := &http.Request{
@@ -353,6 +355,9 @@ func prepareHttpRequest(d requestDescription) *http.Request {
 
 func prepareCassetteRequest(d requestDescription) cassette.Request {
 	fullUrl := fmt.Sprintf("%s://%s/%s", d.scheme, d.host, d.path)
+	if d.query != "" {
+		fullUrl += "?" + d.query
+	}
 
 	req := cassette.Request{
 		Method: d.method,
@@ -0,0 +1,529 @@
+// Package fakeapi implements an in-memory fake of the Google Cloud APIs of the
+// generated resources, so that their CRUD logic can be tested end to end
+// without credentials or network access.
+//
+// The fake only knows the URLs, identity, output-only fields, update masks
+// and operations of a resource, as described by its MMv1 definition. It
+// doesn't validate requests, and stores request bodies as they are sent.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Product is a generated product, served under the path of its base URL.
+type Product struct {
+	Name                 string
+	BaseUrl              string
+	CustomEndpointEnvVar string
+}
+
+// Method is an API method of a resource. Its Url is relative to the product
+// base URL, and may contain URL parameters like `{{name}}`.
+type Method struct {
+	Verb  string
+	Url   string
+	Async bool
+}
+
+// Resource is a generated resource, as described by its MMv1 definition.
+type Resource struct {
+	Product string
+	Name    string
+
+	// SelfLink is the URL of the resource, relative to the product base URL.
+	SelfLink string
+	// CollectionUrl is the URL listing the resources.
+	CollectionUrl    string
+	CollectionUrlKey string
+
+	Create Method
+	Read   Method
+	Update Method
+	Delete Method
+	// CustomUpdates are the methods updating some fields of the resource,
+	// such as `setLabels`.
+	CustomUpdates []Method
+
+	// IdentityFields maps the URL parameters of the self link to the body
+	// fields they are read from when creating the resource.
+	IdentityFields map[string]string
+
+	// OutputFields are the output-only fields of the resource, filled in by
+	// the server.
+	OutputFields []Field
+
+	// OperationNamePrefix is prepended to the ids of the operations of the
+	// resource, so that `{product base URL}{operation name}` is their URL.
+	OperationNamePrefix string
+}
+
+// Field is a top-level field of a resource, with its MMv1 type.
+type Field struct {
+	Name string
+	Type string
+}
+
+func (r *Resource) isOutputField(name string) bool {
+	for _, f := range r.OutputFields {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CustomEndpoints returns the environment variables overriding the base URL
+// of each product to be served by the fake at serverUrl.
+func CustomEndpoints(serverUrl string, products []Product) map[string]string {
+	endpoints := make(map[string]string, len(products))
+	for _, p := range products {
+		endpoints[p.CustomEndpointEnvVar] = fmt.Sprintf("%s/%s", strings.TrimSuffix(serverUrl, "/"), hostPath(p.BaseUrl))
+	}
+	return endpoints
+}
+
+const (
+	actionCreate = "create"
+	actionRead   = "read"
+	actionList   = "list"
+	actionUpdate = "update"
+	actionMerge  = "merge"
+	actionDelete = "delete"
+)
+
+type route struct {
+	verb     string
+	action   string
+	method   Method
+	resource *Resource
+	// baseUrl is the host and path of the product base URL.
+	baseUrl string
+	re      *regexp.Regexp
+	params  []string
+	literal int
+}
+
+// Server serves the resources of the products it's created with.
+type Server struct {
+	routes []*route
+
+	mu         sync.Mutex
+	objects    map[string]map[string]interface{}
+	operations map[string]map[string]interface{}
+	lastId     int
+}
+
+var operationPathRegex = regexp.MustCompile(`(?:^|/)operations/([^/]+)$`)
+
+// NewServer returns a fake serving the given resources. Requests are sent to
+// the path `/{host}/{path}` of the product base URL `https://{host}/{path}`,
+// as set by CustomEndpoints.
+func NewServer(products []Product, resources []Resource) *Server {
+	baseUrls := make(map[string]string, len(products))
+	for _, p := range products {
+		baseUrls[p.Name] = hostPath(p.BaseUrl)
+	}
+
+	s := &Server{
+		objects:    make(map[string]map[string]interface{}),
+		operations: make(map[string]map[string]interface{}),
+	}
+	for i := range resources {
+		r := &resources[i]
+		baseUrl, ok := baseUrls[r.Product]
+		if !ok {
+			continue
+		}
+		s.addRoute(baseUrl, r, actionCreate, r.Create)
+		s.addRoute(baseUrl, r, actionRead, r.Read)
+		s.addRoute(baseUrl, r, actionList, Method{Verb: "GET", Url: r.CollectionUrl})
+		s.addRoute(baseUrl, r, actionUpdate, r.Update)
+		s.addRoute(baseUrl, r, actionDelete, r.Delete)
+		for _, m := range r.CustomUpdates {
+			s.addRoute(baseUrl, r, actionMerge, m)
+		}
+	}
+
+	// Routes with the most literal characters are the most specific ones.
+	sort.SliceStable(s.routes, func(i, j int) bool {
+		return s.routes[i].literal > s.routes[j].literal
+	})
+	return s
+}
+
+func (s *Server) addRoute(baseUrl string, r *Resource, action string, m Method) {
+	if m.Verb == "" || m.Url == "" {
+		return
+	}
+	re, params, literal := compileUrl(baseUrl, strings.Split(m.Url, "?")[0])
+	s.routes = append(s.routes, &route{
+		verb:     m.Verb,
+		action:   action,
+		method:   m,
+		resource: r,
+		baseUrl:  baseUrl,
+		re:       re,
+		params:   params,
+		literal:  literal,
+	})
+}
+
+var urlParamRegex = regexp.MustCompile(`{{(%?)(\w+)}}`)
+
+// compileUrl returns a regular expression matching the paths of a URL
+// template relative to a base URL, along with its parameters and number of
+// literal characters.
+func compileUrl(baseUrl, url string) (*regexp.Regexp, []string, int) {
+	pattern := strings.Builder{}
+	pattern.WriteString("^")
+	params, literal := writeUrlPattern(&pattern, baseUrl, false)
+	urlParams, urlLiteral := writeUrlPattern(&pattern, url, true)
+	pattern.WriteString("$")
+	return regexp.MustCompile(pattern.String()), append(params, urlParams...), literal + urlLiteral
+}
+
+func writeUrlPattern(pattern *strings.Builder, url string, relative bool) ([]string, int) {
+	var params []string
+	literal := len(url)
+	last := 0
+	for _, m := range urlParamRegex.FindAllStringSubmatchIndex(url, -1) {
+		pattern.WriteString(regexp.QuoteMeta(url[last:m[0]]))
+		literal -= m[1] - m[0]
+		// Parameters spanning several path segments are either unescaped ones,
+		// or relative resource names like `{{name}}` or `{{parent}}/instances`.
+		if url[m[2]:m[3]] == "%" || (relative && m[0] == 0) {
+			pattern.WriteString("(.+?)")
+		} else {
+			pattern.WriteString("([^/]+?)")
+		}
+		params = append(params, url[m[4]:m[5]])
+		last = m[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(url[last:]))
+	return params, literal
+}
+
+// fillUrl replaces the parameters of a URL template with their values.
+func fillUrl(url string, values map[string]string) string {
+	return urlParamRegex.ReplaceAllStringFunc(url, func(m string) string {
+		return values[urlParamRegex.FindStringSubmatch(m)[2]]
+	})
+}
+
+func hostPath(baseUrl string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(baseUrl, "https://"), "http://")
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	path := strings.TrimPrefix(req.URL.Path, "/")
+
+	if req.Method == "GET" {
+		if m := operationPathRegex.FindStringSubmatch(path); m != nil {
+			s.mu.Lock()
+			op, ok := s.operations[m[1]]
+			s.mu.Unlock()
+			if ok {
+				writeJson(w, http.StatusOK, op)
+				return
+			}
+		}
+	}
+
+	for _, rt := range s.routes {
+		if rt.verb != req.Method {
+			continue
+		}
+		m := rt.re.FindStringSubmatch(path)
+		if m == nil {
+			continue
+		}
+		params := make(map[string]string, len(rt.params))
+		for i, p := range rt.params {
+			params[p] = m[i+1]
+		}
+		s.serveRoute(w, req, rt, params)
+		return
+	}
+
+	writeError(w, http.StatusNotImplemented, "UNIMPLEMENTED", fmt.Sprintf("The fake API doesn't implement %s %s", req.Method, req.URL.Path))
+}
+
+func (s *Server) serveRoute(w http.ResponseWriter, req *http.Request, rt *route, params map[string]string) {
+	var body map[string]interface{}
+	data, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+		return
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &body); err != nil {
+			writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", fmt.Sprintf("Invalid JSON payload: %s", err))
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Objects are keyed by their path, from the host of their base URL.
+	baseUrl := fillUrl(rt.baseUrl, params)
+	switch rt.action {
+	case actionCreate:
+		s.create(w, req, rt, baseUrl, params, body)
+	case actionList:
+		s.list(w, rt, baseUrl+fillUrl(strings.Split(rt.method.Url, "?")[0], params)+"/")
+	default:
+		key := baseUrl + fillUrl(rt.resource.SelfLink, params)
+		obj, ok := s.objects[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("The %s %q was not found", rt.resource.Name, key))
+			return
+		}
+		switch rt.action {
+		case actionRead:
+			writeJson(w, http.StatusOK, obj)
+		case actionDelete:
+			delete(s.objects, key)
+			s.respond(w, req, rt, key, nil)
+		default:
+			s.update(rt, req, obj, body)
+			s.respond(w, req, rt, key, obj)
+		}
+	}
+}
+
+func (s *Server) create(w http.ResponseWriter, req *http.Request, rt *route, baseUrl string, params map[string]string, body map[string]interface{}) {
+	r := rt.resource
+	if body == nil {
+		body = make(map[string]interface{})
+	}
+
+	// Resource ids are either URL parameters of the create URL, query
+	// parameters such as `instanceId`, or fields of the body.
+	var queryId string
+	if parts := strings.SplitN(r.Create.Url, "?", 2); len(parts) == 2 {
+		for k, param := range urlQueryParams(parts[1]) {
+			if v := req.URL.Query().Get(k); v != "" {
+				params[param] = v
+				queryId = v
+			}
+		}
+	}
+
+	var key string
+	if m := urlParamRegex.FindStringSubmatch(r.SelfLink); m != nil && m[0] == r.SelfLink && params[m[2]] == "" {
+		// The self link is the relative resource name of a resource created
+		// in a collection.
+		id := queryId
+		if id == "" {
+			id = s.bodyId(r, m[2], body)
+		}
+		key = strings.TrimPrefix(req.URL.Path, "/") + "/" + id
+	} else {
+		for _, m := range urlParamRegex.FindAllStringSubmatch(r.SelfLink, -1) {
+			if params[m[2]] == "" {
+				params[m[2]] = s.bodyId(r, m[2], body)
+			}
+		}
+		key = baseUrl + fillUrl(r.SelfLink, params)
+	}
+	if _, ok := body["name"]; !ok || r.isOutputField("name") {
+		body["name"] = strings.TrimPrefix(key, baseUrl)
+	}
+	if _, ok := s.objects[key]; ok {
+		writeError(w, http.StatusConflict, "ALREADY_EXISTS", fmt.Sprintf("The %s %q already exists", r.Name, key))
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	for _, f := range r.OutputFields {
+		field, typ := f.Name, f.Type
+		switch {
+		case field == "selfLink":
+			body[field] = serverUrl(req) + "/" + key
+		case field == "id" || typ == "Integer":
+			body[field] = fmt.Sprintf("%d", 1000000+s.lastId)
+		case field == "uid":
+			body[field] = fmt.Sprintf("00000000-0000-0000-0000-%012d", s.lastId)
+		case typ == "Time" || strings.HasSuffix(field, "Time") || strings.HasSuffix(field, "Timestamp"):
+			body[field] = now
+		case field == "etag" || strings.HasSuffix(field, "Fingerprint") || field == "fingerprint":
+			body[field] = fingerprint(s.lastId)
+		}
+	}
+	s.lastId++
+
+	s.objects[key] = body
+	s.respond(w, req, rt, key, body)
+}
+
+func (s *Server) list(w http.ResponseWriter, rt *route, prefix string) {
+	var keys []string
+	for key := range s.objects {
+		if strings.HasPrefix(key, prefix) && !strings.Contains(strings.TrimPrefix(key, prefix), "/") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	items := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		items = append(items, s.objects[key])
+	}
+	writeJson(w, http.StatusOK, map[string]interface{}{rt.resource.CollectionUrlKey: items})
+}
+
+// update updates obj with the fields of body. Fields in the `updateMask`
+// query parameter are replaced, or removed if they aren't in body. Without
+// an update mask, PUT replaces the resource and other verbs merge body into
+// it.
+func (s *Server) update(rt *route, req *http.Request, obj, body map[string]interface{}) {
+	mask := req.URL.Query().Get("updateMask")
+	if mask == "" {
+		mask = req.URL.Query().Get("update_mask")
+	}
+
+	switch {
+	case mask != "":
+		for _, path := range strings.Split(mask, ",") {
+			setPath(obj, body, strings.Split(strings.TrimSpace(path), "."))
+		}
+	case rt.action == actionUpdate && rt.verb == "PUT":
+		for field := range obj {
+			if !rt.resource.isOutputField(field) && field != "name" {
+				delete(obj, field)
+			}
+		}
+		fallthrough
+	default:
+		for field, v := range body {
+			if !rt.resource.isOutputField(field) {
+				obj[field] = v
+			}
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	for _, f := range rt.resource.OutputFields {
+		switch field := f.Name; {
+		case field == "updateTime":
+			obj[field] = now
+		case field == "etag" || strings.HasSuffix(field, "Fingerprint") || field == "fingerprint":
+			obj[field] = fingerprint(s.lastId)
+		}
+	}
+	s.lastId++
+}
+
+// setPath replaces the field at path in dst with its value in src, removing
+// it if it isn't set in src.
+func setPath(dst, src map[string]interface{}, path []string) {
+	field := path[0]
+	if len(path) == 1 {
+		if v, ok := src[field]; ok {
+			dst[field] = v
+		} else {
+			delete(dst, field)
+		}
+		return
+	}
+	srcChild, _ := src[field].(map[string]interface{})
+	dstChild, ok := dst[field].(map[string]interface{})
+	if !ok {
+		dstChild = make(map[string]interface{})
+		dst[field] = dstChild
+	}
+	setPath(dstChild, srcChild, path[1:])
+}
+
+// respond writes the response of a method, which is a done operation if the
+// method is asynchronous.
+func (s *Server) respond(w http.ResponseWriter, req *http.Request, rt *route, key string, obj map[string]interface{}) {
+	if !rt.method.Async {
+		if obj == nil {
+			obj = map[string]interface{}{}
+		}
+		writeJson(w, http.StatusOK, obj)
+		return
+	}
+
+	id := fmt.Sprintf("operation-%d", s.lastId)
+	s.lastId++
+	op := map[string]interface{}{
+		"name":          rt.resource.OperationNamePrefix + id,
+		"done":          true,
+		"status":        "DONE",
+		"operationType": rt.action,
+		"targetLink":    serverUrl(req) + "/" + key,
+	}
+	if obj != nil {
+		op["response"] = obj
+	}
+	s.operations[id] = op
+	writeJson(w, http.StatusOK, op)
+}
+
+// bodyId returns the id of a resource being created for a URL parameter of
+// its self link, from its identity field in body or generated otherwise.
+func (s *Server) bodyId(r *Resource, param string, body map[string]interface{}) string {
+	if v, ok := body[r.IdentityFields[param]].(string); ok && v != "" {
+		return v[strings.LastIndex(v, "/")+1:]
+	}
+	s.lastId++
+	return fmt.Sprintf("fake-%s-%d", strings.ToLower(r.Name), s.lastId)
+}
+
+// urlQueryParams returns the URL parameters of the query parameters of a URL
+// template, keyed by query parameter.
+func urlQueryParams(query string) map[string]string {
+	params := make(map[string]string)
+	for _, kv := range strings.Split(query, "&") {
+		parts := strings.SplitN(kv, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if m := urlParamRegex.FindStringSubmatch(parts[1]); m != nil {
+			params[parts[0]] = m[2]
+		}
+	}
+	return params
+}
+
+func serverUrl(req *http.Request) string {
+	if req.TLS != nil {
+		return "https://" + req.Host
+	}
+	return "http://" + req.Host
+}
+
+func fingerprint(n int) string {
+	return fmt.Sprintf("%016x", 0x5eed0000+n)
+}
+
+func writeJson(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes an error in the format of Google APIs, which is parsed
+// into a googleapi.Error.
+func writeError(w http.ResponseWriter, code int, status, message string) {
+	writeJson(w, code, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+			"status":  status,
+		},
+	})
+}
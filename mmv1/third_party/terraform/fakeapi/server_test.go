@@ -0,0 +1,258 @@
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+var testProducts = []Product{
+	{
+		Name:                 "DNS",
+		BaseUrl:              "https://dns.googleapis.com/dns/v1/",
+		CustomEndpointEnvVar: "GOOGLE_DNS_CUSTOM_ENDPOINT",
+	},
+	{
+		Name:                 "Filestore",
+		BaseUrl:              "https://file.googleapis.com/v1/",
+		CustomEndpointEnvVar: "GOOGLE_FILESTORE_CUSTOM_ENDPOINT",
+	},
+}
+
+var testResources = []Resource{
+	{
+		Product:          "DNS",
+		Name:             "ManagedZone",
+		SelfLink:         "projects/{{project}}/managedZones/{{name}}",
+		CollectionUrl:    "projects/{{project}}/managedZones",
+		CollectionUrlKey: "managedZones",
+		Create:           Method{Verb: "POST", Url: "projects/{{project}}/managedZones"},
+		Read:             Method{Verb: "GET", Url: "projects/{{project}}/managedZones/{{name}}"},
+		Update:           Method{Verb: "PUT", Url: "projects/{{project}}/managedZones/{{name}}"},
+		Delete:           Method{Verb: "DELETE", Url: "projects/{{project}}/managedZones/{{name}}"},
+		IdentityFields: map[string]string{
+			"name": "name",
+		},
+		OutputFields: []Field{
+			{Name: "id", Type: "Integer"},
+			{Name: "creationTime", Type: "Time"},
+		},
+	},
+	{
+		Product:          "Filestore",
+		Name:             "Instance",
+		SelfLink:         "projects/{{project}}/locations/{{location}}/instances/{{name}}",
+		CollectionUrl:    "projects/{{project}}/locations/{{location}}/instances",
+		CollectionUrlKey: "instances",
+		Create:           Method{Verb: "POST", Url: "projects/{{project}}/locations/{{location}}/instances?instanceId={{name}}", Async: true},
+		Read:             Method{Verb: "GET", Url: "projects/{{project}}/locations/{{location}}/instances/{{name}}"},
+		Update:           Method{Verb: "PATCH", Url: "projects/{{project}}/locations/{{location}}/instances/{{name}}", Async: true},
+		Delete:           Method{Verb: "DELETE", Url: "projects/{{project}}/locations/{{location}}/instances/{{name}}", Async: true},
+		IdentityFields: map[string]string{
+			"name": "name",
+		},
+		OutputFields: []Field{
+			{Name: "createTime", Type: "Time"},
+			{Name: "etag", Type: "String"},
+		},
+		OperationNamePrefix: "operations/",
+	},
+	{
+		Product:          "Filestore",
+		Name:             "Backup",
+		SelfLink:         "{{name}}",
+		CollectionUrl:    "projects/{{project}}/locations/{{location}}/backups",
+		CollectionUrlKey: "backups",
+		Create:           Method{Verb: "POST", Url: "projects/{{project}}/locations/{{location}}/backups?backupId={{backup_id}}"},
+		Read:             Method{Verb: "GET", Url: "{{name}}"},
+		Delete:           Method{Verb: "DELETE", Url: "{{name}}"},
+	},
+}
+
+func TestServer(t *testing.T) {
+	ts := httptest.NewServer(NewServer(testProducts, testResources))
+	defer ts.Close()
+	endpoints := CustomEndpoints(ts.URL, testProducts)
+	dns := endpoints["GOOGLE_DNS_CUSTOM_ENDPOINT"]
+	file := endpoints["GOOGLE_FILESTORE_CUSTOM_ENDPOINT"]
+
+	if want := ts.URL + "/dns.googleapis.com/dns/v1/"; dns != want {
+		t.Fatalf("expected DNS endpoint %s, got %s", want, dns)
+	}
+
+	zone := dns + "projects/my-project/managedZones/my-zone"
+	instance := file + "projects/my-project/locations/us-central1-b/instances/my-instance"
+
+	steps := []struct {
+		verb     string
+		url      string
+		body     string
+		wantCode int
+		want     map[string]interface{}
+	}{
+		{
+			verb:     "GET",
+			url:      zone,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			verb:     "POST",
+			url:      dns + "projects/my-project/managedZones",
+			body:     `{"name":"my-zone","dnsName":"example.com."}`,
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"name": "my-zone", "dnsName": "example.com.", "id": "1000000"},
+		},
+		{
+			verb:     "POST",
+			url:      dns + "projects/my-project/managedZones",
+			body:     `{"name":"my-zone","dnsName":"example.org."}`,
+			wantCode: http.StatusConflict,
+		},
+		{
+			verb:     "GET",
+			url:      zone,
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"name": "my-zone", "dnsName": "example.com."},
+		},
+		{
+			verb:     "PUT",
+			url:      zone,
+			body:     `{"name":"my-zone","description":"updated"}`,
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"description": "updated", "dnsName": nil, "id": "1000000"},
+		},
+		{
+			verb:     "GET",
+			url:      dns + "projects/my-project/managedZones",
+			wantCode: http.StatusOK,
+		},
+		{
+			verb:     "DELETE",
+			url:      zone,
+			wantCode: http.StatusOK,
+		},
+		{
+			verb:     "DELETE",
+			url:      zone,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			verb:     "POST",
+			url:      file + "projects/my-project/locations/us-central1-b/instances?instanceId=my-instance",
+			body:     `{"tier":"BASIC_HDD","labels":{"foo":"bar"}}`,
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"done": true},
+		},
+		{
+			verb:     "GET",
+			url:      instance,
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"name": "projects/my-project/locations/us-central1-b/instances/my-instance", "tier": "BASIC_HDD"},
+		},
+		{
+			verb:     "PATCH",
+			url:      instance + "?updateMask=labels,description",
+			body:     `{"tier":"PREMIUM","description":"updated"}`,
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"done": true},
+		},
+		{
+			verb:     "GET",
+			url:      instance,
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"tier": "BASIC_HDD", "description": "updated", "labels": nil},
+		},
+		{
+			verb:     "POST",
+			url:      file + "projects/my-project/locations/us-central1/backups?backupId=my-backup",
+			body:     `{"sourceInstance":"my-instance"}`,
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"name": "projects/my-project/locations/us-central1/backups/my-backup"},
+		},
+		{
+			verb:     "GET",
+			url:      file + "projects/my-project/locations/us-central1/backups",
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"backups": []interface{}{map[string]interface{}{"name": "projects/my-project/locations/us-central1/backups/my-backup", "sourceInstance": "my-instance"}}},
+		},
+		{
+			verb:     "GET",
+			url:      file + "projects/my-project/locations/us-central1/backups/my-backup",
+			wantCode: http.StatusOK,
+			want:     map[string]interface{}{"sourceInstance": "my-instance"},
+		},
+		{
+			verb:     "POST",
+			url:      file + "projects/my-project/locations/us-central1-b/instances/my-instance:restore",
+			wantCode: http.StatusNotImplemented,
+		},
+	}
+
+	for i, step := range steps {
+		req, err := http.NewRequest(step.verb, step.url, strings.NewReader(step.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got map[string]interface{}
+		err = json.NewDecoder(res.Body).Decode(&got)
+		res.Body.Close()
+		if err != nil {
+			t.Fatalf("step %d: error decoding response: %s", i, err)
+		}
+		if res.StatusCode != step.wantCode {
+			t.Fatalf("step %d: expected %s %s to return %d, got %d: %v", i, step.verb, step.url, step.wantCode, res.StatusCode, got)
+		}
+		for k, v := range step.want {
+			if fmt.Sprint(got[k]) != fmt.Sprint(v) {
+				t.Errorf("step %d: expected %s to be %v, got %v", i, k, v, got[k])
+			}
+		}
+	}
+}
+
+func TestServer_operations(t *testing.T) {
+	ts := httptest.NewServer(NewServer(testProducts, testResources))
+	defer ts.Close()
+	file := CustomEndpoints(ts.URL, testProducts)["GOOGLE_FILESTORE_CUSTOM_ENDPOINT"]
+
+	res, err := http.Post(file+"projects/my-project/locations/us-central1-b/instances", "application/json", strings.NewReader(`{"tier":"BASIC_HDD"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var op map[string]interface{}
+	err = json.NewDecoder(res.Body).Decode(&op)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name, _ := op["name"].(string)
+	if !strings.HasPrefix(name, "operations/") {
+		t.Fatalf("expected operation name to start with operations/, got %q", name)
+	}
+	response, _ := op["response"].(map[string]interface{})
+	if !strings.HasPrefix(fmt.Sprint(response["name"]), "projects/my-project/locations/us-central1-b/instances/fake-instance-") {
+		t.Errorf("expected operation response to be the instance with a generated name, got %v", response)
+	}
+
+	res, err = http.Get(file + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var polled map[string]interface{}
+	err = json.NewDecoder(res.Body).Decode(&polled)
+	res.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK || polled["done"] != true {
+		t.Errorf("expected operation %s to be done, got %d: %v", name, res.StatusCode, polled)
+	}
+}
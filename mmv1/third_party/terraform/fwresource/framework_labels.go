@@ -0,0 +1,113 @@
+package fwresource
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+// FlattenFrameworkLabels flattens the labels or annotations returned by the API
+// into the keys present in prior, so that the field only holds the values
+// managed by Terraform. It is the framework counterpart of tpgresource.SetLabels.
+func FlattenFrameworkLabels(v interface{}, prior types.Map) types.Map {
+	if prior.IsNull() || prior.IsUnknown() {
+		return prior
+	}
+
+	raw, _ := v.(map[string]interface{})
+
+	elems := make(map[string]attr.Value)
+	for k := range prior.Elements() {
+		if lv, ok := raw[k]; ok {
+			elems[k] = types.StringValue(fmt.Sprintf("%v", lv))
+		}
+	}
+	return types.MapValueMust(types.StringType, elems)
+}
+
+// ModifyPlanLabels plans the terraform-managed and effective values of the
+// labels or annotations field named field, the framework counterpart of
+// tpgresource.SetLabelsDiff and tpgresource.SetAnnotationsDiff.
+//
+// terraformField names the field combining the provider default labels with
+// the configured ones and is empty for annotations, which have no provider
+// defaults. effectiveField names the field holding every value on the
+// resource, which is the one sent to the API.
+func ModifyPlanLabels(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, config *transport_tpg.Config, field, terraformField, effectiveField string, attribution bool) {
+	// Nothing to plan when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var configured types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root(field), &configured)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// If the labels are unknown, the managed and effective labels are too.
+	if configured.IsUnknown() {
+		if terraformField != "" {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(terraformField), types.MapUnknown(types.StringType))...)
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(effectiveField), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	var priorEffective, priorManaged types.Map
+	creating := req.State.Raw.IsNull()
+	if !creating {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(effectiveField), &priorEffective)...)
+		managedField := field
+		if terraformField != "" {
+			managedField = terraformField
+		}
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(managedField), &priorManaged)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	managed := make(map[string]attr.Value)
+	if terraformField != "" && config != nil {
+		for k, v := range config.DefaultLabels {
+			managed[k] = types.StringValue(v)
+		}
+
+		// Append optional label indicating the resource was provisioned using Terraform
+		if attribution && config.AddTerraformAttributionLabel {
+			_, hasExistingLabel := priorEffective.Elements()[transport_tpg.AttributionKey]
+			if hasExistingLabel ||
+				config.TerraformAttributionLabelAdditionStrategy == transport_tpg.ProactiveAttributionStrategy ||
+				(config.TerraformAttributionLabelAdditionStrategy == transport_tpg.CreateOnlyAttributionStrategy && creating) {
+				managed[transport_tpg.AttributionKey] = types.StringValue(transport_tpg.AttributionValue)
+			}
+		}
+	}
+	for k, v := range configured.Elements() {
+		managed[k] = v
+	}
+
+	if terraformField != "" {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(terraformField), types.MapValueMust(types.StringType, managed))...)
+	}
+
+	effective := make(map[string]attr.Value)
+	for k, v := range priorEffective.Elements() {
+		effective[k] = v
+	}
+	for k, v := range managed {
+		effective[k] = v
+	}
+	for k := range priorManaged.Elements() {
+		if _, ok := managed[k]; !ok {
+			delete(effective, k)
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(effectiveField), types.MapValueMust(types.StringType, effective))...)
+}
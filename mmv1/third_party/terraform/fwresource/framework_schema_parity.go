@@ -0,0 +1,351 @@
+package fwresource
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// parityField is the part of a field's schema that is compared between the
+// SDK and framework implementations of a resource.
+type parityField struct {
+	Kind      string
+	Required  bool
+	Optional  bool
+	Computed  bool
+	Sensitive bool
+	WriteOnly bool
+	ForceNew  bool
+	Default   bool
+
+	// Elem is the element of a list, set or map of primitives.
+	Elem *parityField
+	// Nested holds the fields of the elements of a list or set of objects.
+	Nested map[string]parityField
+	// TypeOnly is set when only the type of the field is known, as for the
+	// attributes of framework object types.
+	TypeOnly bool
+}
+
+// SchemaParityDiffs compares the schema of the SDK implementation of a resource
+// with its framework implementation and returns a description of each
+// difference, which is empty if the schemas are equivalent. The framework `id`
+// attribute and `timeouts` block are not compared, as the SDK adds them
+// implicitly. Validators, descriptions and diff suppression are not compared.
+func SchemaParityDiffs(sdk map[string]*sdkschema.Schema, fw schema.Schema) []string {
+	ctx := context.Background()
+
+	sdkFields := make(map[string]parityField, len(sdk))
+	for k, s := range sdk {
+		sdkFields[k] = sdkParityField(s)
+	}
+
+	fwFields := make(map[string]parityField, len(fw.Attributes)+len(fw.Blocks))
+	for k, a := range fw.Attributes {
+		if k == "id" {
+			continue
+		}
+		fwFields[k] = fwAttributeParityField(ctx, a)
+	}
+	for k, b := range fw.Blocks {
+		if k == "timeouts" {
+			continue
+		}
+		fwFields[k] = fwBlockParityField(ctx, b)
+	}
+
+	return compareParityFields("", sdkFields, fwFields)
+}
+
+func compareParityFields(prefix string, sdk, fw map[string]parityField) []string {
+	var names []string
+	for k := range sdk {
+		names = append(names, k)
+	}
+	for k := range fw {
+		if _, ok := sdk[k]; !ok {
+			names = append(names, k)
+		}
+	}
+	sort.Strings(names)
+
+	var diffs []string
+	for _, k := range names {
+		p := prefix + k
+		s, inSdk := sdk[k]
+		f, inFw := fw[k]
+		switch {
+		case !inFw:
+			diffs = append(diffs, fmt.Sprintf("%s: only in the SDK schema", p))
+		case !inSdk:
+			diffs = append(diffs, fmt.Sprintf("%s: only in the framework schema", p))
+		default:
+			diffs = append(diffs, compareParityField(p, s, f)...)
+		}
+	}
+	return diffs
+}
+
+func compareParityField(p string, sdk, fw parityField) []string {
+	if sdk.Kind != fw.Kind {
+		return []string{fmt.Sprintf("%s: type is %s in the SDK schema and %s in the framework schema", p, sdk.Kind, fw.Kind)}
+	}
+
+	var diffs []string
+	if !sdk.TypeOnly && !fw.TypeOnly {
+		// Framework attributes with a default value must be computed, while the
+		// SDK only marks them as optional.
+		computed := fw.Computed
+		if fw.Default && sdk.Default && !sdk.Computed {
+			computed = false
+		}
+
+		flags := []struct {
+			name    string
+			sdk, fw bool
+		}{
+			{"required", sdk.Required, fw.Required},
+			{"optional", sdk.Optional, fw.Optional},
+			{"computed", sdk.Computed, computed},
+			{"sensitive", sdk.Sensitive, fw.Sensitive},
+			{"write-only", sdk.WriteOnly, fw.WriteOnly},
+			{"force new", sdk.ForceNew, fw.ForceNew},
+			{"default", sdk.Default, fw.Default},
+		}
+		for _, flag := range flags {
+			if flag.sdk != flag.fw {
+				diffs = append(diffs, fmt.Sprintf("%s: %s is %t in the SDK schema and %t in the framework schema", p, flag.name, flag.sdk, flag.fw))
+			}
+		}
+	}
+
+	switch {
+	case sdk.Nested != nil || fw.Nested != nil:
+		typeOnly := sdk.TypeOnly || fw.TypeOnly
+		diffs = append(diffs, compareParityFields(p+".", markTypeOnly(sdk.Nested, typeOnly), markTypeOnly(fw.Nested, typeOnly))...)
+	case sdk.Elem != nil && fw.Elem != nil:
+		if sdk.Elem.Kind != fw.Elem.Kind {
+			diffs = append(diffs, fmt.Sprintf("%s: element type is %s in the SDK schema and %s in the framework schema", p, sdk.Elem.Kind, fw.Elem.Kind))
+		}
+	}
+	return diffs
+}
+
+func markTypeOnly(fields map[string]parityField, typeOnly bool) map[string]parityField {
+	if !typeOnly {
+		return fields
+	}
+	marked := make(map[string]parityField, len(fields))
+	for k, f := range fields {
+		f.TypeOnly = true
+		marked[k] = f
+	}
+	return marked
+}
+
+func sdkParityField(s *sdkschema.Schema) parityField {
+	f := parityField{
+		Required:  s.Required,
+		Optional:  s.Optional,
+		Computed:  s.Computed,
+		Sensitive: s.Sensitive,
+		WriteOnly: s.WriteOnly,
+		ForceNew:  s.ForceNew,
+		Default:   s.Default != nil,
+	}
+
+	switch s.Type {
+	case sdkschema.TypeString:
+		f.Kind = "string"
+	case sdkschema.TypeBool:
+		f.Kind = "bool"
+	case sdkschema.TypeInt:
+		f.Kind = "int"
+	case sdkschema.TypeFloat:
+		f.Kind = "float"
+	case sdkschema.TypeList:
+		f.Kind = "list"
+	case sdkschema.TypeSet:
+		f.Kind = "set"
+	case sdkschema.TypeMap:
+		f.Kind = "map"
+	default:
+		f.Kind = s.Type.String()
+	}
+
+	switch elem := s.Elem.(type) {
+	case *sdkschema.Schema:
+		e := sdkParityField(elem)
+		f.Elem = &e
+	case *sdkschema.Resource:
+		f.Nested = make(map[string]parityField, len(elem.Schema))
+		for k, ns := range elem.Schema {
+			f.Nested[k] = sdkParityField(ns)
+		}
+	default:
+		if f.Kind == "map" {
+			// SDK maps default to string elements.
+			f.Elem = &parityField{Kind: "string"}
+		}
+	}
+	return f
+}
+
+func fwAttributeParityField(ctx context.Context, a schema.Attribute) parityField {
+	f := fwTypeParityField(a.GetType())
+	f.TypeOnly = false
+	f.Required = a.IsRequired()
+	f.Optional = a.IsOptional()
+	f.Computed = a.IsComputed()
+	f.Sensitive = a.IsSensitive()
+	f.WriteOnly = a.IsWriteOnly()
+
+	var modifiers []interface{ Description(context.Context) string }
+	switch ta := a.(type) {
+	case schema.StringAttribute:
+		f.Default = ta.Default != nil
+		for _, m := range ta.PlanModifiers {
+			modifiers = append(modifiers, m)
+		}
+	case schema.BoolAttribute:
+		f.Default = ta.Default != nil
+		for _, m := range ta.PlanModifiers {
+			modifiers = append(modifiers, m)
+		}
+	case schema.Int64Attribute:
+		f.Default = ta.Default != nil
+		for _, m := range ta.PlanModifiers {
+			modifiers = append(modifiers, m)
+		}
+	case schema.Float64Attribute:
+		f.Default = ta.Default != nil
+		for _, m := range ta.PlanModifiers {
+			modifiers = append(modifiers, m)
+		}
+	case schema.ListAttribute:
+		f.Default = ta.Default != nil
+		for _, m := range ta.PlanModifiers {
+			modifiers = append(modifiers, m)
+		}
+	case schema.SetAttribute:
+		f.Default = ta.Default != nil
+		for _, m := range ta.PlanModifiers {
+			modifiers = append(modifiers, m)
+		}
+	case schema.MapAttribute:
+		f.Default = ta.Default != nil
+		for _, m := range ta.PlanModifiers {
+			modifiers = append(modifiers, m)
+		}
+	}
+	f.ForceNew = requiresReplace(ctx, modifiers)
+	return f
+}
+
+func fwBlockParityField(ctx context.Context, b schema.Block) parityField {
+	f := parityField{Optional: true}
+
+	var nested schema.NestedBlockObject
+	var modifiers []interface{ Description(context.Context) string }
+	var validators []validator.Describer
+	switch tb := b.(type) {
+	case schema.ListNestedBlock:
+		f.Kind = "list"
+		nested = tb.NestedObject
+		for _, m := range tb.PlanModifiers {
+			modifiers = append(modifiers, m)
+		}
+		for _, v := range tb.Validators {
+			validators = append(validators, v)
+		}
+	case schema.SetNestedBlock:
+		f.Kind = "set"
+		nested = tb.NestedObject
+		for _, m := range tb.PlanModifiers {
+			modifiers = append(modifiers, m)
+		}
+		for _, v := range tb.Validators {
+			validators = append(validators, v)
+		}
+	default:
+		f.Kind = fmt.Sprintf("%T", b)
+		return f
+	}
+
+	// Blocks cannot be required, so required blocks are validated instead.
+	for _, v := range validators {
+		if strings.Contains(v.Description(ctx), "block must be configured") {
+			f.Required = true
+			f.Optional = false
+		}
+	}
+	f.ForceNew = requiresReplace(ctx, modifiers)
+
+	f.Nested = make(map[string]parityField, len(nested.Attributes)+len(nested.Blocks))
+	for k, a := range nested.Attributes {
+		f.Nested[k] = fwAttributeParityField(ctx, a)
+	}
+	for k, nb := range nested.Blocks {
+		f.Nested[k] = fwBlockParityField(ctx, nb)
+	}
+	return f
+}
+
+// fwTypeParityField describes a framework type, for which only the kind of
+// value is known.
+func fwTypeParityField(t attr.Type) parityField {
+	f := parityField{TypeOnly: true}
+	switch tt := t.(type) {
+	case types.ListType:
+		f.Kind = "list"
+		f.setElem(tt.ElemType)
+	case types.SetType:
+		f.Kind = "set"
+		f.setElem(tt.ElemType)
+	case types.MapType:
+		f.Kind = "map"
+		f.setElem(tt.ElemType)
+	default:
+		switch {
+		case t.Equal(types.StringType):
+			f.Kind = "string"
+		case t.Equal(types.BoolType):
+			f.Kind = "bool"
+		case t.Equal(types.Int64Type):
+			f.Kind = "int"
+		case t.Equal(types.Float64Type):
+			f.Kind = "float"
+		default:
+			f.Kind = t.String()
+		}
+	}
+	return f
+}
+
+func (f *parityField) setElem(elemType attr.Type) {
+	if ot, ok := elemType.(types.ObjectType); ok {
+		f.Nested = make(map[string]parityField, len(ot.AttrTypes))
+		for k, at := range ot.AttrTypes {
+			f.Nested[k] = fwTypeParityField(at)
+		}
+		return
+	}
+	e := fwTypeParityField(elemType)
+	f.Elem = &e
+}
+
+func requiresReplace(ctx context.Context, modifiers []interface{ Description(context.Context) string }) bool {
+	for _, m := range modifiers {
+		if strings.Contains(m.Description(ctx), "destroy and recreate the resource") {
+			return true
+		}
+	}
+	return false
+}
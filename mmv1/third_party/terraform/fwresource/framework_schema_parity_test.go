@@ -0,0 +1,179 @@
+package fwresource
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-google/google/fwvalidators"
+)
+
+func TestSchemaParityDiffs(t *testing.T) {
+	sdk := map[string]*sdkschema.Schema{
+		"name": {
+			Type:     sdkschema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"mode": {
+			Type:     sdkschema.TypeString,
+			Optional: true,
+			Default:  "AUTO",
+		},
+		"tags": {
+			Type:     sdkschema.TypeList,
+			Optional: true,
+			Elem:     &sdkschema.Schema{Type: sdkschema.TypeString},
+		},
+		"config": {
+			Type:     sdkschema.TypeList,
+			Required: true,
+			ForceNew: true,
+			MaxItems: 1,
+			Elem: &sdkschema.Resource{
+				Schema: map[string]*sdkschema.Schema{
+					"size": {
+						Type:     sdkschema.TypeInt,
+						Optional: true,
+					},
+				},
+			},
+		},
+		"status": {
+			Type:     sdkschema.TypeList,
+			Computed: true,
+			Elem: &sdkschema.Resource{
+				Schema: map[string]*sdkschema.Schema{
+					"state": {
+						Type:     sdkschema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+	}
+
+	fw := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+			},
+			"mode": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("AUTO"),
+			},
+			"tags": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"status": schema.ListAttribute{
+				Computed: true,
+				ElementType: types.ObjectType{AttrTypes: map[string]attr.Type{
+					"state": types.StringType,
+				}},
+			},
+			"id": schema.StringAttribute{
+				Computed: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"config": schema.ListNestedBlock{
+				Validators:    []validator.List{fwvalidators.RequiredListBlock()},
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"size": schema.Int64Attribute{
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		Modify   func(sdk map[string]*sdkschema.Schema, fw *schema.Schema)
+		Expected []string
+	}{
+		"equivalent schemas": {
+			Modify: func(map[string]*sdkschema.Schema, *schema.Schema) {},
+		},
+		"missing field": {
+			Modify: func(_ map[string]*sdkschema.Schema, fw *schema.Schema) {
+				delete(fw.Attributes, "tags")
+			},
+			Expected: []string{"tags: only in the SDK schema"},
+		},
+		"different flags": {
+			Modify: func(_ map[string]*sdkschema.Schema, fw *schema.Schema) {
+				fw.Attributes["name"] = schema.StringAttribute{Optional: true}
+			},
+			Expected: []string{
+				"name: required is true in the SDK schema and false in the framework schema",
+				"name: optional is false in the SDK schema and true in the framework schema",
+				"name: force new is true in the SDK schema and false in the framework schema",
+			},
+		},
+		"different nested type": {
+			Modify: func(_ map[string]*sdkschema.Schema, fw *schema.Schema) {
+				fw.Blocks["config"] = schema.ListNestedBlock{
+					Validators:    []validator.List{fwvalidators.RequiredListBlock()},
+					PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()},
+					NestedObject: schema.NestedBlockObject{
+						Attributes: map[string]schema.Attribute{
+							"size": schema.StringAttribute{Optional: true},
+						},
+					},
+				}
+			},
+			Expected: []string{"config.size: type is int in the SDK schema and string in the framework schema"},
+		},
+		"optional block": {
+			Modify: func(_ map[string]*sdkschema.Schema, fw *schema.Schema) {
+				b := fw.Blocks["config"].(schema.ListNestedBlock)
+				b.Validators = nil
+				fw.Blocks["config"] = b
+			},
+			Expected: []string{
+				"config: required is true in the SDK schema and false in the framework schema",
+				"config: optional is false in the SDK schema and true in the framework schema",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			s := make(map[string]*sdkschema.Schema, len(sdk))
+			for k, v := range sdk {
+				s[k] = v
+			}
+			f := schema.Schema{
+				Attributes: make(map[string]schema.Attribute, len(fw.Attributes)),
+				Blocks:     make(map[string]schema.Block, len(fw.Blocks)),
+			}
+			for k, v := range fw.Attributes {
+				f.Attributes[k] = v
+			}
+			for k, v := range fw.Blocks {
+				f.Blocks[k] = v
+			}
+			tc.Modify(s, &f)
+
+			got := SchemaParityDiffs(s, f)
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Fatalf("Incorrect diffs: got %q, want %q", got, tc.Expected)
+			}
+		})
+	}
+}
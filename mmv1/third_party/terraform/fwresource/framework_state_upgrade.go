@@ -0,0 +1,58 @@
+package fwresource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// StateUpgradeFunc upgrades the raw state of a resource by one schema version.
+// It has the signature of the SDK schema.StateUpgradeFunc, so the upgraders of
+// SDK resources can be reused by their framework implementations.
+type StateUpgradeFunc func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error)
+
+// UpgradeRawState runs upgraders in order on the JSON state of req and sets the
+// result as the upgraded state of resp. Top-level values that are not part of
+// the current schema are dropped, along with the timeouts which the SDK keeps
+// in the private state.
+func UpgradeRawState(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse, meta interface{}, upgraders ...StateUpgradeFunc) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError("Unable to upgrade state", "The prior state is not stored as JSON.")
+		return
+	}
+
+	var rawState map[string]interface{}
+	if err := json.Unmarshal(req.RawState.JSON, &rawState); err != nil {
+		resp.Diagnostics.AddError("Unable to upgrade state", fmt.Sprintf("Error decoding the prior state: %s", err))
+		return
+	}
+
+	for _, upgrade := range upgraders {
+		var err error
+		rawState, err = upgrade(ctx, rawState, meta)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to upgrade state", err.Error())
+			return
+		}
+	}
+
+	attributes := resp.State.Schema.GetAttributes()
+	blocks := resp.State.Schema.GetBlocks()
+	for k := range rawState {
+		_, isAttribute := attributes[k]
+		_, isBlock := blocks[k]
+		if (!isAttribute && !isBlock) || k == "timeouts" {
+			delete(rawState, k)
+		}
+	}
+
+	upgraded, err := json.Marshal(rawState)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to upgrade state", fmt.Sprintf("Error encoding the upgraded state: %s", err))
+		return
+	}
+	resp.DynamicValue = &tfprotov6.DynamicValue{JSON: upgraded}
+}
@@ -0,0 +1,279 @@
+package fwresource
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// ApiField describes how a field of a framework resource maps to its API
+// representation.
+type ApiField struct {
+	// Name of the field in the API.
+	Name string
+
+	// Whether the field is a nested object, which is represented as a list
+	// holding a single element.
+	Single bool
+
+	// Whether empty values of the field are sent to the API rather than
+	// omitted.
+	SendEmptyValue bool
+}
+
+// ApiFields maps the dotted Terraform path of the fields of a resource, such
+// as `foo.bar`, to their ApiField. List indices are omitted from the paths.
+type ApiFields map[string]ApiField
+
+// ExpandFrameworkValue converts a framework value of a primitive, list, set or
+// map type into its JSON API representation. Null and unknown values expand to
+// nil so callers can omit them from the request body.
+func ExpandFrameworkValue(ctx context.Context, v attr.Value, diags *diag.Diagnostics) interface{} {
+	return ExpandFrameworkField(ctx, v, nil, "", diags)
+}
+
+// ExpandFrameworkField converts the framework value of the field at path p into
+// its JSON API representation, using fields to name the attributes of nested
+// objects. Empty nested values are omitted unless the field sends empty values.
+func ExpandFrameworkField(ctx context.Context, v attr.Value, fields ApiFields, p string, diags *diag.Diagnostics) interface{} {
+	if v == nil || v.IsNull() || v.IsUnknown() {
+		return nil
+	}
+
+	switch tv := v.(type) {
+	case types.String:
+		return tv.ValueString()
+	case types.Bool:
+		return tv.ValueBool()
+	case types.Int64:
+		return tv.ValueInt64()
+	case types.Float64:
+		return tv.ValueFloat64()
+	case types.List:
+		return expandFrameworkCollection(ctx, tv.Elements(), fields, p, diags)
+	case types.Set:
+		return expandFrameworkCollection(ctx, tv.Elements(), fields, p, diags)
+	case types.Map:
+		m := make(map[string]interface{})
+		for k, e := range tv.Elements() {
+			m[k] = ExpandFrameworkField(ctx, e, fields, p, diags)
+		}
+		return m
+	case types.Object:
+		m := make(map[string]interface{})
+		for k, e := range tv.Attributes() {
+			ExpandFrameworkFieldInto(ctx, m, e, fields, joinFieldPath(p, k), diags)
+		}
+		return m
+	}
+
+	diags.AddError("unsupported value type", fmt.Sprintf("cannot expand framework value of type %T", v))
+	return nil
+}
+
+// ExpandFrameworkFieldInto expands the framework value of the field at path p
+// into obj, keyed by the API name of the field. As in SDK resources, empty
+// values are omitted unless the field sends empty values.
+func ExpandFrameworkFieldInto(ctx context.Context, obj map[string]interface{}, v attr.Value, fields ApiFields, p string, diags *diag.Diagnostics) {
+	field, ok := fields[p]
+	if !ok {
+		field = ApiField{Name: p[strings.LastIndex(p, ".")+1:]}
+	}
+	ev := ExpandFrameworkField(ctx, v, fields, p, diags)
+	if ev == nil || (!field.SendEmptyValue && isEmptyApiValue(ev)) {
+		return
+	}
+	obj[field.Name] = ev
+}
+
+// expandFrameworkCollection expands the elements of a list or set. The single
+// element of a nested object field is unwrapped.
+func expandFrameworkCollection(ctx context.Context, elems []attr.Value, fields ApiFields, p string, diags *diag.Diagnostics) interface{} {
+	l := make([]interface{}, 0, len(elems))
+	for _, e := range elems {
+		l = append(l, ExpandFrameworkField(ctx, e, fields, p, diags))
+	}
+	if fields[p].Single {
+		if len(l) == 0 {
+			return nil
+		}
+		return l[0]
+	}
+	return l
+}
+
+// FlattenFrameworkValue converts a value decoded from a JSON API response into
+// a framework value of type t. Missing values flatten to a null value of t.
+// Integers are accepted both as JSON numbers and as the decimal strings used by
+// Google APIs for int64 fields.
+func FlattenFrameworkValue(ctx context.Context, v interface{}, t attr.Type, diags *diag.Diagnostics) attr.Value {
+	return FlattenFrameworkField(ctx, v, t, nil, "", diags)
+}
+
+// FlattenFrameworkField converts the API value of the field at path p into a
+// framework value of type t, using fields to find the attributes of nested
+// objects. A missing list of nested objects flattens to an empty list, as
+// Terraform represents absent blocks.
+func FlattenFrameworkField(ctx context.Context, v interface{}, t attr.Type, fields ApiFields, p string, diags *diag.Diagnostics) attr.Value {
+	switch tt := t.(type) {
+	case types.ListType:
+		elems, absent := flattenFrameworkCollection(ctx, v, tt.ElemType, fields, p, diags)
+		if absent {
+			return types.ListNull(tt.ElemType)
+		}
+		lv, ds := types.ListValue(tt.ElemType, elems)
+		diags.Append(ds...)
+		return lv
+	case types.SetType:
+		elems, absent := flattenFrameworkCollection(ctx, v, tt.ElemType, fields, p, diags)
+		if absent {
+			return types.SetNull(tt.ElemType)
+		}
+		sv, ds := types.SetValue(tt.ElemType, elems)
+		diags.Append(ds...)
+		return sv
+	case types.MapType:
+		raw, ok := v.(map[string]interface{})
+		if !ok {
+			return types.MapNull(tt.ElemType)
+		}
+		elems := make(map[string]attr.Value, len(raw))
+		for k, e := range raw {
+			elems[k] = FlattenFrameworkField(ctx, e, tt.ElemType, fields, p, diags)
+		}
+		mv, ds := types.MapValue(tt.ElemType, elems)
+		diags.Append(ds...)
+		return mv
+	case types.ObjectType:
+		raw, ok := v.(map[string]interface{})
+		if !ok {
+			return types.ObjectNull(tt.AttrTypes)
+		}
+		attrs := make(map[string]attr.Value, len(tt.AttrTypes))
+		for k, at := range tt.AttrTypes {
+			ap := joinFieldPath(p, k)
+			name := k
+			if field, ok := fields[ap]; ok {
+				name = field.Name
+			}
+			attrs[k] = FlattenFrameworkField(ctx, raw[name], at, fields, ap, diags)
+		}
+		ov, ds := types.ObjectValue(tt.AttrTypes, attrs)
+		diags.Append(ds...)
+		return ov
+	}
+
+	switch {
+	case t.Equal(types.StringType):
+		if v == nil {
+			return types.StringNull()
+		}
+		if s, ok := v.(string); ok {
+			return types.StringValue(s)
+		}
+		return types.StringValue(fmt.Sprintf("%v", v))
+	case t.Equal(types.BoolType):
+		if b, ok := v.(bool); ok {
+			return types.BoolValue(b)
+		}
+		return types.BoolNull()
+	case t.Equal(types.Int64Type):
+		switch n := v.(type) {
+		case float64:
+			return types.Int64Value(int64(n))
+		case int64:
+			return types.Int64Value(n)
+		case int:
+			return types.Int64Value(int64(n))
+		case string:
+			i, err := strconv.ParseInt(n, 10, 64)
+			if err != nil {
+				diags.AddError("invalid integer value", err.Error())
+				return types.Int64Null()
+			}
+			return types.Int64Value(i)
+		}
+		return types.Int64Null()
+	case t.Equal(types.Float64Type):
+		switch n := v.(type) {
+		case float64:
+			return types.Float64Value(n)
+		case string:
+			f, err := strconv.ParseFloat(n, 64)
+			if err != nil {
+				diags.AddError("invalid number value", err.Error())
+				return types.Float64Null()
+			}
+			return types.Float64Value(f)
+		}
+		return types.Float64Null()
+	}
+
+	diags.AddError("unsupported value type", fmt.Sprintf("cannot flatten API value into framework type %s", t))
+	return nil
+}
+
+// flattenFrameworkCollection flattens a JSON array into framework values of
+// elemType. A nested object field flattens into a list holding its single
+// element. The boolean result reports whether v was absent and the
+// collection should be null.
+func flattenFrameworkCollection(ctx context.Context, v interface{}, elemType attr.Type, fields ApiFields, p string, diags *diag.Diagnostics) ([]attr.Value, bool) {
+	_, isObject := elemType.(types.ObjectType)
+
+	var raw []interface{}
+	if fields[p].Single {
+		if m, ok := v.(map[string]interface{}); ok && len(m) > 0 {
+			raw = []interface{}{m}
+		}
+	} else if l, ok := v.([]interface{}); ok {
+		raw = l
+	} else if !isObject {
+		return nil, true
+	}
+
+	elems := make([]attr.Value, 0, len(raw))
+	for _, e := range raw {
+		elems = append(elems, FlattenFrameworkField(ctx, e, elemType, fields, p, diags))
+	}
+	return elems, false
+}
+
+// FlattenFrameworkFieldInto flattens the API value of the field at path p into
+// target, using the type of the current value of target.
+func FlattenFrameworkFieldInto[T attr.Value](ctx context.Context, target *T, v interface{}, fields ApiFields, p string, diags *diag.Diagnostics) {
+	if fv, ok := FlattenFrameworkField(ctx, v, (*target).Type(ctx), fields, p, diags).(T); ok {
+		*target = fv
+	}
+}
+
+func joinFieldPath(p, name string) string {
+	if p == "" {
+		return name
+	}
+	return p + "." + name
+}
+
+// isEmptyApiValue reports whether an expanded value is the zero value of its
+// type, which the SDK resources omit from request bodies.
+func isEmptyApiValue(v interface{}) bool {
+	switch tv := v.(type) {
+	case string:
+		return tv == ""
+	case bool:
+		return !tv
+	case int64:
+		return tv == 0
+	case float64:
+		return tv == 0
+	case []interface{}:
+		return len(tv) == 0
+	case map[string]interface{}:
+		return len(tv) == 0
+	}
+	return v == nil
+}
@@ -0,0 +1,183 @@
+package fwresource
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFlattenFrameworkValue(t *testing.T) {
+	cases := map[string]struct {
+		Value         interface{}
+		Type          attr.Type
+		Expected      attr.Value
+		ExpectedError bool
+	}{
+		"string": {
+			Value:    "foo",
+			Type:     types.StringType,
+			Expected: types.StringValue("foo"),
+		},
+		"missing string is null": {
+			Type:     types.StringType,
+			Expected: types.StringNull(),
+		},
+		"int64 encoded as a string": {
+			Value:    "1234",
+			Type:     types.Int64Type,
+			Expected: types.Int64Value(1234),
+		},
+		"int64 encoded as a number": {
+			Value:    float64(42),
+			Type:     types.Int64Type,
+			Expected: types.Int64Value(42),
+		},
+		"invalid int64 string": {
+			Value:         "abc",
+			Type:          types.Int64Type,
+			ExpectedError: true,
+		},
+		"bool": {
+			Value:    true,
+			Type:     types.BoolType,
+			Expected: types.BoolValue(true),
+		},
+		"list of strings": {
+			Value:    []interface{}{"a", "b"},
+			Type:     types.ListType{ElemType: types.StringType},
+			Expected: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a"), types.StringValue("b")}),
+		},
+		"missing list is null": {
+			Type:     types.ListType{ElemType: types.StringType},
+			Expected: types.ListNull(types.StringType),
+		},
+		"map of strings": {
+			Value:    map[string]interface{}{"k": "v"},
+			Type:     types.MapType{ElemType: types.StringType},
+			Expected: types.MapValueMust(types.StringType, map[string]attr.Value{"k": types.StringValue("v")}),
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			got := FlattenFrameworkValue(context.Background(), tc.Value, tc.Type, &diags)
+
+			if diags.HasError() {
+				if tc.ExpectedError {
+					return
+				}
+				t.Fatalf("Got %d unexpected error(s) during test: %s", diags.ErrorsCount(), diags.Errors())
+			}
+			if tc.ExpectedError {
+				t.Fatalf("Expected an error, got %s", got)
+			}
+			if !got.Equal(tc.Expected) {
+				t.Fatalf("Incorrect value: got %s, want %s", got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestExpandFrameworkValue(t *testing.T) {
+	cases := map[string]struct {
+		Value    attr.Value
+		Expected interface{}
+	}{
+		"null is omitted": {
+			Value:    types.StringNull(),
+			Expected: nil,
+		},
+		"string": {
+			Value:    types.StringValue("foo"),
+			Expected: "foo",
+		},
+		"int64": {
+			Value:    types.Int64Value(3),
+			Expected: int64(3),
+		},
+		"list of strings": {
+			Value:    types.ListValueMust(types.StringType, []attr.Value{types.StringValue("a")}),
+			Expected: []interface{}{"a"},
+		},
+		"map of strings": {
+			Value:    types.MapValueMust(types.StringType, map[string]attr.Value{"k": types.StringValue("v")}),
+			Expected: map[string]interface{}{"k": "v"},
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			got := ExpandFrameworkValue(context.Background(), tc.Value, &diags)
+
+			if diags.HasError() {
+				t.Fatalf("Got %d unexpected error(s) during test: %s", diags.ErrorsCount(), diags.Errors())
+			}
+			if !reflect.DeepEqual(got, tc.Expected) {
+				t.Fatalf("Incorrect value: got %#v, want %#v", got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestFrameworkFieldRoundTrip(t *testing.T) {
+	fields := ApiFields{
+		"config":             {Name: "config", Single: true},
+		"config.disk_size":   {Name: "diskSizeGb"},
+		"config.enable_ipv6": {Name: "enableIpv6", SendEmptyValue: true},
+		"config.tags":        {Name: "tags"},
+		"display_name":       {Name: "displayName"},
+	}
+	configType := types.ObjectType{AttrTypes: map[string]attr.Type{
+		"disk_size":   types.Int64Type,
+		"enable_ipv6": types.BoolType,
+		"tags":        types.ListType{ElemType: types.StringType},
+	}}
+	config := types.ListValueMust(configType, []attr.Value{
+		types.ObjectValueMust(configType.AttrTypes, map[string]attr.Value{
+			"disk_size":   types.Int64Value(10),
+			"enable_ipv6": types.BoolValue(false),
+			"tags":        types.ListNull(types.StringType),
+		}),
+	})
+
+	var diags diag.Diagnostics
+	obj := make(map[string]interface{})
+	ExpandFrameworkFieldInto(context.Background(), obj, config, fields, "config", &diags)
+	ExpandFrameworkFieldInto(context.Background(), obj, types.StringValue(""), fields, "display_name", &diags)
+	if diags.HasError() {
+		t.Fatalf("Got %d unexpected error(s) during expand: %s", diags.ErrorsCount(), diags.Errors())
+	}
+
+	expected := map[string]interface{}{
+		"config": map[string]interface{}{
+			"diskSizeGb": int64(10),
+			"enableIpv6": false,
+		},
+	}
+	if !reflect.DeepEqual(obj, expected) {
+		t.Fatalf("Incorrect expanded value: got %#v, want %#v", obj, expected)
+	}
+
+	got := types.ListNull(configType)
+	FlattenFrameworkFieldInto(context.Background(), &got, map[string]interface{}{
+		"diskSizeGb": "10",
+		"enableIpv6": false,
+	}, fields, "config", &diags)
+	if diags.HasError() {
+		t.Fatalf("Got %d unexpected error(s) during flatten: %s", diags.ErrorsCount(), diags.Errors())
+	}
+	if !got.Equal(config) {
+		t.Fatalf("Incorrect flattened value: got %s, want %s", got, config)
+	}
+
+	FlattenFrameworkFieldInto(context.Background(), &got, nil, fields, "config", &diags)
+	if !got.Equal(types.ListValueMust(configType, []attr.Value{})) {
+		t.Fatalf("Incorrect flattened value for an absent block: got %s", got)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
@@ -241,6 +242,9 @@ func BuildReplacementFunc(ctx context.Context, re *regexp.Regexp, req interface{
 		case resource.DeleteRequest:
 			sReq := req.(resource.DeleteRequest)
 			diagInfo = sReq.State.GetAttribute(ctx, path.Root("project_id"), &projectID)
+		case ephemeral.OpenRequest:
+			oReq := req.(ephemeral.OpenRequest)
+			diagInfo = oReq.Config.GetAttribute(ctx, path.Root("project_id"), &projectID)
 		}
 		diags.Append(diagInfo...)
 		if diags.HasError() {
@@ -312,6 +316,9 @@ func BuildReplacementFunc(ctx context.Context, re *regexp.Regexp, req interface{
 			case resource.DeleteRequest:
 				sReq := req.(resource.DeleteRequest)
 				diagInfo = sReq.State.GetAttribute(ctx, path.Root(m[1:]), &v)
+			case ephemeral.OpenRequest:
+				oReq := req.(ephemeral.OpenRequest)
+				diagInfo = oReq.Config.GetAttribute(ctx, path.Root(m[1:]), &v)
 			}
 			//an error here means the attribute was not found, we want to do nothing in that case
 			if !diagInfo.HasError() {
@@ -340,6 +347,9 @@ func BuildReplacementFunc(ctx context.Context, re *regexp.Regexp, req interface{
 			case resource.DeleteRequest:
 				sReq := req.(resource.DeleteRequest)
 				diagInfo = sReq.State.GetAttribute(ctx, path.Root(m), &v)
+			case ephemeral.OpenRequest:
+				oReq := req.(ephemeral.OpenRequest)
+				diagInfo = oReq.Config.GetAttribute(ctx, path.Root(m), &v)
 			}
 			//an error here means the attribute was not found, we want to do nothing in that case
 			if !diagInfo.HasError() {
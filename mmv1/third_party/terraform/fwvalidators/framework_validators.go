@@ -9,6 +9,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
@@ -342,3 +346,228 @@ func (v TopicPrefixValidator) ValidateString(ctx context.Context, req validator.
 func NewTopicPrefixValidator() validator.String {
 	return TopicPrefixValidator{}
 }
+
+// requiredBlockValidator validates that a list or set nested block is
+// configured, as blocks cannot be marked as required in the framework schema.
+type requiredBlockValidator struct{}
+
+func (v requiredBlockValidator) Description(_ context.Context) string {
+	return "block must be configured"
+}
+
+func (v requiredBlockValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v requiredBlockValidator) validate(ctx context.Context, p path.Path, value attr.Value, count int, diags *diag.Diagnostics) {
+	if value.IsUnknown() {
+		return
+	}
+
+	if value.IsNull() || count == 0 {
+		diags.AddAttributeError(
+			p,
+			"Missing Required Block",
+			fmt.Sprintf("The %s %s.", p, v.Description(ctx)),
+		)
+	}
+}
+
+func (v requiredBlockValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	v.validate(ctx, req.Path, req.ConfigValue, len(req.ConfigValue.Elements()), &resp.Diagnostics)
+}
+
+func (v requiredBlockValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	v.validate(ctx, req.Path, req.ConfigValue, len(req.ConfigValue.Elements()), &resp.Diagnostics)
+}
+
+// RequiredListBlock validates that a list nested block is configured.
+func RequiredListBlock() validator.List {
+	return requiredBlockValidator{}
+}
+
+// RequiredSetBlock validates that a set nested block is configured.
+func RequiredSetBlock() validator.Set {
+	return requiredBlockValidator{}
+}
+
+// FieldRelationValidator checks how a field relates to other fields of the
+// resource, like the ConflictsWith, ExactlyOneOf, AtLeastOneOf and
+// RequiredWith settings of SDK schemas. Unlike the relationship validators of
+// terraform-plugin-framework-validators, it treats empty lists, sets and maps
+// as unset, so absent nested blocks are not counted as configured.
+type FieldRelationValidator interface {
+	validator.Bool
+	validator.Float64
+	validator.Int64
+	validator.List
+	validator.Map
+	validator.Set
+	validator.String
+}
+
+type fieldRelation int
+
+const (
+	conflictsWith fieldRelation = iota
+	exactlyOneOf
+	atLeastOneOf
+	requiredWith
+)
+
+type fieldRelationValidator struct {
+	relation    fieldRelation
+	expressions path.Expressions
+}
+
+func (v fieldRelationValidator) Description(_ context.Context) string {
+	switch v.relation {
+	case conflictsWith:
+		return fmt.Sprintf("conflicts with %s", v.expressions)
+	case exactlyOneOf:
+		return fmt.Sprintf("exactly one of %s must be configured", v.expressions)
+	case atLeastOneOf:
+		return fmt.Sprintf("at least one of %s must be configured", v.expressions)
+	}
+	return fmt.Sprintf("requires %s to be configured", v.expressions)
+}
+
+func (v fieldRelationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v fieldRelationValidator) validate(ctx context.Context, p path.Path, expr path.Expression, config tfsdk.Config, value attr.Value, diags *diag.Diagnostics) {
+	// Delay validation until every involved field is known.
+	if value.IsUnknown() {
+		return
+	}
+	set := isConfiguredValue(value)
+
+	var configured, missing path.Paths
+	for _, e := range expr.MergeExpressions(v.expressions...) {
+		matches, ds := config.PathMatches(ctx, e)
+		diags.Append(ds...)
+		if ds.HasError() {
+			continue
+		}
+		if len(matches) == 0 {
+			missing.Append(path.Empty())
+		}
+		for _, mp := range matches {
+			if mp.Equal(p) {
+				continue
+			}
+			var mv attr.Value
+			ds := config.GetAttribute(ctx, mp, &mv)
+			diags.Append(ds...)
+			if ds.HasError() {
+				continue
+			}
+			if mv.IsUnknown() {
+				return
+			}
+			if isConfiguredValue(mv) {
+				configured.Append(mp)
+			} else {
+				missing.Append(mp)
+			}
+		}
+	}
+
+	count := len(configured)
+	if set {
+		count++
+	}
+
+	switch v.relation {
+	case conflictsWith:
+		if set && len(configured) > 0 {
+			diags.AddAttributeError(p, "Invalid Attribute Combination",
+				fmt.Sprintf("%s cannot be specified when %s is specified", p, configured))
+		}
+	case exactlyOneOf:
+		if count != 1 {
+			diags.AddAttributeError(p, "Invalid Attribute Combination",
+				fmt.Sprintf("%d of %s were specified, but exactly one of them must be", count, v.expressions))
+		}
+	case atLeastOneOf:
+		if count == 0 {
+			diags.AddAttributeError(p, "Invalid Attribute Combination",
+				fmt.Sprintf("At least one of %s must be specified", v.expressions))
+		}
+	case requiredWith:
+		if set && len(missing) > 0 {
+			diags.AddAttributeError(p, "Invalid Attribute Combination",
+				fmt.Sprintf("%s must be specified when %s is specified", v.expressions, p))
+		}
+	}
+}
+
+// isConfiguredValue reports whether a configuration value is set, treating
+// empty collections such as absent nested blocks as unset.
+func isConfiguredValue(v attr.Value) bool {
+	if v == nil || v.IsNull() {
+		return false
+	}
+	switch tv := v.(type) {
+	case types.List:
+		return len(tv.Elements()) > 0
+	case types.Set:
+		return len(tv.Elements()) > 0
+	case types.Map:
+		return len(tv.Elements()) > 0
+	}
+	return true
+}
+
+func (v fieldRelationValidator) ValidateBool(ctx context.Context, req validator.BoolRequest, resp *validator.BoolResponse) {
+	v.validate(ctx, req.Path, req.PathExpression, req.Config, req.ConfigValue, &resp.Diagnostics)
+}
+
+func (v fieldRelationValidator) ValidateFloat64(ctx context.Context, req validator.Float64Request, resp *validator.Float64Response) {
+	v.validate(ctx, req.Path, req.PathExpression, req.Config, req.ConfigValue, &resp.Diagnostics)
+}
+
+func (v fieldRelationValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	v.validate(ctx, req.Path, req.PathExpression, req.Config, req.ConfigValue, &resp.Diagnostics)
+}
+
+func (v fieldRelationValidator) ValidateList(ctx context.Context, req validator.ListRequest, resp *validator.ListResponse) {
+	v.validate(ctx, req.Path, req.PathExpression, req.Config, req.ConfigValue, &resp.Diagnostics)
+}
+
+func (v fieldRelationValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	v.validate(ctx, req.Path, req.PathExpression, req.Config, req.ConfigValue, &resp.Diagnostics)
+}
+
+func (v fieldRelationValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	v.validate(ctx, req.Path, req.PathExpression, req.Config, req.ConfigValue, &resp.Diagnostics)
+}
+
+func (v fieldRelationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	v.validate(ctx, req.Path, req.PathExpression, req.Config, req.ConfigValue, &resp.Diagnostics)
+}
+
+// ConflictsWith validates that none of the fields matching expressions are
+// configured alongside the field.
+func ConflictsWith(expressions ...path.Expression) FieldRelationValidator {
+	return fieldRelationValidator{relation: conflictsWith, expressions: expressions}
+}
+
+// ExactlyOneOf validates that exactly one of the field and the fields matching
+// expressions is configured.
+func ExactlyOneOf(expressions ...path.Expression) FieldRelationValidator {
+	return fieldRelationValidator{relation: exactlyOneOf, expressions: expressions}
+}
+
+// AtLeastOneOf validates that at least one of the field and the fields
+// matching expressions is configured.
+func AtLeastOneOf(expressions ...path.Expression) FieldRelationValidator {
+	return fieldRelationValidator{relation: atLeastOneOf, expressions: expressions}
+}
+
+// RequiredWith validates that the fields matching expressions are configured
+// when the field is.
+func RequiredWith(expressions ...path.Expression) FieldRelationValidator {
+	return fieldRelationValidator{relation: requiredWith, expressions: expressions}
+}
@@ -5,10 +5,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 
 	"github.com/hashicorp/terraform-provider-google/google/acctest"
 	"github.com/hashicorp/terraform-provider-google/google/fwvalidators"
@@ -445,3 +449,178 @@ func TestTopicPrefixValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestRequiredListBlockValidator(t *testing.T) {
+	t.Parallel()
+
+	elemType := types.ObjectType{AttrTypes: map[string]attr.Type{"name": types.StringType}}
+	block := types.ObjectValueMust(elemType.AttrTypes, map[string]attr.Value{"name": types.StringValue("foo")})
+
+	cases := map[string]struct {
+		ConfigValue        types.List
+		ExpectedErrorCount int
+	}{
+		"configured block is valid": {
+			ConfigValue:        types.ListValueMust(elemType, []attr.Value{block}),
+			ExpectedErrorCount: 0,
+		},
+		"unknown block is valid": {
+			ConfigValue:        types.ListUnknown(elemType),
+			ExpectedErrorCount: 0,
+		},
+		"missing block is invalid": {
+			ConfigValue:        types.ListValueMust(elemType, []attr.Value{}),
+			ExpectedErrorCount: 1,
+		},
+		"null block is invalid": {
+			ConfigValue:        types.ListNull(elemType),
+			ExpectedErrorCount: 1,
+		},
+	}
+
+	for tn, tc := range cases {
+		tn, tc := tn, tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			req := validator.ListRequest{
+				Path:        path.Root("test_block"),
+				ConfigValue: tc.ConfigValue,
+			}
+			resp := &validator.ListResponse{
+				Diagnostics: diag.Diagnostics{},
+			}
+
+			fwvalidators.RequiredListBlock().ValidateList(context.Background(), req, resp)
+
+			if resp.Diagnostics.ErrorsCount() != tc.ExpectedErrorCount {
+				t.Errorf("Expected %d errors, but got %d. Errors: %v", tc.ExpectedErrorCount, resp.Diagnostics.ErrorsCount(), resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
+
+func TestFieldRelationValidators(t *testing.T) {
+	t.Parallel()
+
+	s := schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"foo": schema.StringAttribute{Optional: true},
+			"bar": schema.StringAttribute{Optional: true},
+		},
+		Blocks: map[string]schema.Block{
+			"baz": schema.ListNestedBlock{
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"qux": schema.StringAttribute{Optional: true},
+					},
+				},
+			},
+		},
+	}
+	bazType := tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"qux": tftypes.String}}}
+	objType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"foo": tftypes.String,
+		"bar": tftypes.String,
+		"baz": bazType,
+	}}
+	config := func(foo, bar interface{}, baz []tftypes.Value) tfsdk.Config {
+		return tfsdk.Config{
+			Schema: s,
+			Raw: tftypes.NewValue(objType, map[string]tftypes.Value{
+				"foo": tftypes.NewValue(tftypes.String, foo),
+				"bar": tftypes.NewValue(tftypes.String, bar),
+				"baz": tftypes.NewValue(bazType, baz),
+			}),
+		}
+	}
+	baz := []tftypes.Value{tftypes.NewValue(bazType.ElementType, map[string]tftypes.Value{"qux": tftypes.NewValue(tftypes.String, "qux")})}
+
+	cases := map[string]struct {
+		Validator          fwvalidators.FieldRelationValidator
+		Config             tfsdk.Config
+		ExpectedErrorCount int
+	}{
+		"conflicts with unset fields": {
+			Validator: fwvalidators.ConflictsWith(path.MatchRoot("bar"), path.MatchRoot("baz")),
+			Config:    config("foo", nil, []tftypes.Value{}),
+		},
+		"conflicts with a set field": {
+			Validator:          fwvalidators.ConflictsWith(path.MatchRoot("bar")),
+			Config:             config("foo", "bar", []tftypes.Value{}),
+			ExpectedErrorCount: 1,
+		},
+		"conflicts with a configured block": {
+			Validator:          fwvalidators.ConflictsWith(path.MatchRoot("baz")),
+			Config:             config("foo", nil, baz),
+			ExpectedErrorCount: 1,
+		},
+		"conflicts with unset field when unset": {
+			Validator: fwvalidators.ConflictsWith(path.MatchRoot("bar")),
+			Config:    config(nil, "bar", []tftypes.Value{}),
+		},
+		"exactly one of with one set": {
+			Validator: fwvalidators.ExactlyOneOf(path.MatchRoot("foo"), path.MatchRoot("bar")),
+			Config:    config("foo", nil, []tftypes.Value{}),
+		},
+		"exactly one of with none set": {
+			Validator:          fwvalidators.ExactlyOneOf(path.MatchRoot("foo"), path.MatchRoot("bar")),
+			Config:             config(nil, nil, []tftypes.Value{}),
+			ExpectedErrorCount: 1,
+		},
+		"exactly one of with both set": {
+			Validator:          fwvalidators.ExactlyOneOf(path.MatchRoot("foo"), path.MatchRoot("bar")),
+			Config:             config("foo", "bar", []tftypes.Value{}),
+			ExpectedErrorCount: 1,
+		},
+		"exactly one of with unknown value": {
+			Validator: fwvalidators.ExactlyOneOf(path.MatchRoot("foo"), path.MatchRoot("bar")),
+			Config:    config("foo", tftypes.UnknownValue, []tftypes.Value{}),
+		},
+		"at least one of with a nested field set": {
+			Validator: fwvalidators.AtLeastOneOf(path.MatchRoot("bar"), path.MatchRoot("baz").AtListIndex(0).AtName("qux")),
+			Config:    config(nil, nil, baz),
+		},
+		"at least one of with none set": {
+			Validator:          fwvalidators.AtLeastOneOf(path.MatchRoot("bar"), path.MatchRoot("baz").AtListIndex(0).AtName("qux")),
+			Config:             config(nil, nil, []tftypes.Value{}),
+			ExpectedErrorCount: 1,
+		},
+		"required with set field": {
+			Validator: fwvalidators.RequiredWith(path.MatchRoot("bar")),
+			Config:    config("foo", "bar", []tftypes.Value{}),
+		},
+		"required with unset field": {
+			Validator:          fwvalidators.RequiredWith(path.MatchRoot("bar")),
+			Config:             config("foo", nil, []tftypes.Value{}),
+			ExpectedErrorCount: 1,
+		},
+	}
+
+	for tn, tc := range cases {
+		tn, tc := tn, tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			var foo types.String
+			if diags := tc.Config.GetAttribute(context.Background(), path.Root("foo"), &foo); diags.HasError() {
+				t.Fatalf("Unexpected error reading foo: %v", diags)
+			}
+			req := validator.StringRequest{
+				Path:           path.Root("foo"),
+				PathExpression: path.MatchRoot("foo"),
+				Config:         tc.Config,
+				ConfigValue:    foo,
+			}
+			resp := &validator.StringResponse{
+				Diagnostics: diag.Diagnostics{},
+			}
+
+			tc.Validator.ValidateString(context.Background(), req, resp)
+
+			if resp.Diagnostics.ErrorsCount() != tc.ExpectedErrorCount {
+				t.Errorf("Expected %d errors, but got %d. Errors: %v", tc.ExpectedErrorCount, resp.Diagnostics.ErrorsCount(), resp.Diagnostics.Errors())
+			}
+		})
+	}
+}
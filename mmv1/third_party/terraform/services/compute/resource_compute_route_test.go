@@ -50,6 +50,29 @@ func TestAccComputeRoute_hopInstance(t *testing.T) {
 	})
 }
 
+// Routes and subnetworks are created concurrently, and not while the network's
+// peering is created. They reference the network by its id and self link, which
+// must lock the same key as the peering.
+func TestAccComputeRoute_networkPeering(t *testing.T) {
+	t.Parallel()
+
+	acctest.VcrTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		CheckDestroy:             testAccCheckComputeRouteDestroyProducer(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccComputeRoute_networkPeering(acctest.RandString(t, 10)),
+			},
+			{
+				ResourceName:      "google_compute_route.foobar.0",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccComputeRoute_resourceManagerTags(t *testing.T) {
 
 	org := envvar.GetTestOrgFromEnv(t)
@@ -111,6 +134,42 @@ resource "google_compute_route" "foobar" {
 `, suffix)
 }
 
+func testAccComputeRoute_networkPeering(suffix string) string {
+	return fmt.Sprintf(`
+resource "google_compute_network" "network" {
+  name                    = "tf-test-network-%s"
+  auto_create_subnetworks = false
+}
+
+resource "google_compute_network" "peer" {
+  name                    = "tf-test-peer-%s"
+  auto_create_subnetworks = false
+}
+
+resource "google_compute_network_peering" "peering" {
+  name         = "tf-test-peering-%s"
+  network      = google_compute_network.network.self_link
+  peer_network = google_compute_network.peer.self_link
+}
+
+resource "google_compute_subnetwork" "subnetwork" {
+  count         = 3
+  name          = "tf-test-subnetwork-%s-${count.index}"
+  ip_cidr_range = "10.${count.index}.0.0/16"
+  region        = "us-central1"
+  network       = google_compute_network.network.self_link
+}
+
+resource "google_compute_route" "foobar" {
+  count            = 3
+  name             = "tf-test-route-%s-${count.index}"
+  dest_range       = "192.168.${count.index}.0/24"
+  network          = google_compute_network.network.id
+  next_hop_gateway = "default-internet-gateway"
+}
+`, suffix, suffix, suffix, suffix, suffix)
+}
+
 func testAccComputeRoute_hopInstance(instanceName, zone, suffix string) string {
 	return fmt.Sprintf(`
 data "google_compute_image" "my_image" {
@@ -85,6 +85,38 @@ func TestAccPubsubSubscription_basic(t *testing.T) {
 	})
 }
 
+// Subscriptions of the same project are created and deleted in batches, where
+// each subscription must read its own response.
+func TestAccPubsubSubscription_batched(t *testing.T) {
+	t.Parallel()
+
+	topic := fmt.Sprintf("tf-test-topic-%s", acctest.RandString(t, 10))
+	subscription := fmt.Sprintf("tf-test-sub-%s", acctest.RandString(t, 10))
+
+	acctest.VcrTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		CheckDestroy:             testAccCheckPubsubSubscriptionDestroyProducer(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPubsubSubscription_batched(topic, subscription),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("google_pubsub_subscription.foo.0", "name", subscription+"-0"),
+					resource.TestCheckResourceAttr("google_pubsub_subscription.foo.0", "ack_deadline_seconds", "10"),
+					resource.TestCheckResourceAttr("google_pubsub_subscription.foo.4", "name", subscription+"-4"),
+					resource.TestCheckResourceAttr("google_pubsub_subscription.foo.4", "ack_deadline_seconds", "14"),
+				),
+			},
+			{
+				ResourceName:      "google_pubsub_subscription.foo.2",
+				ImportStateId:     subscription + "-2",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccPubsubSubscription_update(t *testing.T) {
 	t.Parallel()
 
@@ -857,6 +889,21 @@ resource "google_pubsub_subscription" "foo" {
 `, topic, subscription, label, deadline, exactlyOnceDelivery)
 }
 
+func testAccPubsubSubscription_batched(topic, subscription string) string {
+	return fmt.Sprintf(`
+resource "google_pubsub_topic" "foo" {
+  name = "%s"
+}
+
+resource "google_pubsub_subscription" "foo" {
+  count                = 5
+  name                 = "%s-${count.index}"
+  topic                = google_pubsub_topic.foo.id
+  ack_deadline_seconds = 10 + count.index
+}
+`, topic, subscription)
+}
+
 func testAccPubsubSubscriptionBigQuery_basic(dataset, table, topic, subscription string, useTableSchema bool, serviceAccountId string) string {
 	serviceAccountEmailField := ""
 	serviceAccountResource := ""
@@ -0,0 +1,86 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+// A create that times out before its operation is done keeps the operation in
+// state and succeeds, and the next refresh waits for the operation.
+func TestResourceSQLSourceRepresentationInstanceResumeCreate(t *testing.T) {
+	var mu sync.Mutex
+	opStatus := "RUNNING"
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method + " " + r.URL.Path {
+		case "POST /sql/v1beta4/projects/my-project/instances":
+			fmt.Fprint(w, `{"kind": "sql#operation", "name": "create-op", "status": "PENDING"}`)
+		case "GET /sql/v1beta4/projects/my-project/operations/create-op":
+			fmt.Fprintf(w, `{"kind": "sql#operation", "name": "create-op", "status": %q}`, opStatus)
+		case "GET /sql/v1beta4/projects/my-project/instances/my-instance":
+			fmt.Fprint(w, `{"name": "my-instance", "region": "us-central1", "databaseVersion": "MYSQL_8_0", "onPremisesConfiguration": {"hostPort": "10.20.30.40:3306"}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	config := &transport_tpg.Config{
+		Project:     "my-project",
+		Client:      server.Client(),
+		Context:     context.Background(),
+		SQLBasePath: server.URL + "/sql/v1beta4/",
+	}
+
+	r := ResourceSQLSourceRepresentationInstance()
+	timeout := time.Second
+	r.Timeouts.Create = &timeout
+	d := r.Data(nil)
+	for k, v := range map[string]interface{}{
+		"name":             "my-instance",
+		"region":           "us-central1",
+		"database_version": "MYSQL_8_0",
+		"host":             "10.20.30.40",
+		"port":             3306,
+	} {
+		if err := d.Set(k, v); err != nil {
+			t.Fatalf("error setting %s: %s", k, err)
+		}
+	}
+
+	if err := resourceSQLSourceRepresentationInstanceCreate(d, config); err != nil {
+		t.Fatalf("expected a create that timed out to succeed, got %s", err)
+	}
+	if got, want := d.Id(), "projects/my-project/instances/my-instance"; got != want {
+		t.Errorf("expected id %q after the create timed out, got %q", want, got)
+	}
+	if got, want := d.Get("operation").(string), "create-op"; got != want {
+		t.Errorf("expected operation %q after the create timed out, got %q", want, got)
+	}
+
+	mu.Lock()
+	opStatus = "DONE"
+	mu.Unlock()
+
+	if err := resourceSQLSourceRepresentationInstanceRead(d, config); err != nil {
+		t.Fatalf("expected the refresh to resume the create, got %s", err)
+	}
+	if got, want := d.Id(), "projects/my-project/instances/my-instance"; got != want {
+		t.Errorf("expected id %q after resuming the create, got %q", want, got)
+	}
+	if got := d.Get("operation").(string); got != "" {
+		t.Errorf("expected no operation after resuming the create, got %q", got)
+	}
+	if got, want := d.Get("host").(string), "10.20.30.40"; got != want {
+		t.Errorf("expected host %q after resuming the create, got %q", want, got)
+	}
+}
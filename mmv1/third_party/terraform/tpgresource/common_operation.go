@@ -1,6 +1,8 @@
 package tpgresource
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -90,6 +92,22 @@ func (w *CommonOperationWaiter) OpName() string {
 	return w.Op.Name
 }
 
+// Progress returns the percentage of the operation that's done, from the
+// progressPercent of its metadata.
+func (w *CommonOperationWaiter) Progress() (int, bool) {
+	if w == nil || len(w.Op.Metadata) == 0 {
+		return 0, false
+	}
+
+	var metadata struct {
+		ProgressPercent *int `json:"progressPercent"`
+	}
+	if err := json.Unmarshal(w.Op.Metadata, &metadata); err != nil || metadata.ProgressPercent == nil {
+		return 0, false
+	}
+	return *metadata.ProgressPercent, true
+}
+
 func (w *CommonOperationWaiter) PendingStates() []string {
 	return []string{"done: false"}
 }
@@ -98,6 +116,15 @@ func (w *CommonOperationWaiter) TargetStates() []string {
 	return []string{"done: true"}
 }
 
+// ProgressWaiter is a Waiter of operations reporting how much of them is
+// done, which is logged while waiting.
+type ProgressWaiter interface {
+	Waiter
+
+	// Progress returns the percentage of the operation that's done, if known.
+	Progress() (int, bool)
+}
+
 func OperationDone(w Waiter) bool {
 	for _, s := range w.TargetStates() {
 		if s == w.State() {
@@ -108,6 +135,7 @@ func OperationDone(w Waiter) bool {
 }
 
 func CommonRefreshFunc(w Waiter) retry.StateRefreshFunc {
+	lastProgress := -1
 	return func() (interface{}, string, error) {
 		op, err := w.QueryOp()
 		if err != nil {
@@ -131,6 +159,13 @@ func CommonRefreshFunc(w Waiter) retry.StateRefreshFunc {
 			return nil, "", err
 		}
 
+		if pw, ok := w.(ProgressWaiter); ok {
+			if progress, ok := pw.Progress(); ok && progress != lastProgress {
+				log.Printf("[INFO] Operation %s is %d%% done", w.OpName(), progress)
+				lastProgress = progress
+			}
+		}
+
 		log.Printf("[DEBUG] Got %v while polling for operation %s's status", w.State(), w.OpName())
 		return op, w.State(), nil
 	}
@@ -162,6 +197,18 @@ func OperationWait(w Waiter, activity string, timeout time.Duration, pollInterva
 	return w.Error()
 }
 
+// IsOperationWaitInterrupted returns whether waiting for an operation stopped
+// before the operation was done, because the wait timed out or the provider
+// is shutting down, rather than because the operation failed. The operation
+// can still succeed, and waiting for it can be resumed.
+func IsOperationWaitInterrupted(config *transport_tpg.Config, err error) bool {
+	var timeoutErr *retry.TimeoutError
+	if errors.As(err, &timeoutErr) {
+		return true
+	}
+	return config.Context != nil && config.Context.Err() != nil
+}
+
 // The cloud resource manager API operation is an example of one of many
 // interchangeable API operations. Choose it somewhat arbitrarily to represent
 // the "common" operation.
@@ -1,10 +1,16 @@
 package tpgresource
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"net/url"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"google.golang.org/api/googleapi"
+
 	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
 )
 
@@ -67,3 +73,73 @@ func TestOperationWait_TimeoutsShouldRetry(t *testing.T) {
 			expectedRunCount, testWaiter.runCount)
 	}
 }
+
+func TestCommonOperationWaiter_Progress(t *testing.T) {
+	cases := map[string]struct {
+		metadata         string
+		expectedProgress int
+		expectedOk       bool
+	}{
+		"no metadata": {},
+		"no progress": {
+			metadata: `{"verb": "create"}`,
+		},
+		"progress": {
+			metadata:         `{"verb": "create", "progressPercent": 42}`,
+			expectedProgress: 42,
+			expectedOk:       true,
+		},
+		"no progress yet": {
+			metadata:   `{"progressPercent": 0}`,
+			expectedOk: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			w := &CommonOperationWaiter{}
+			if tc.metadata != "" {
+				w.Op.Metadata = googleapi.RawMessage(tc.metadata)
+			}
+			progress, ok := w.Progress()
+			if progress != tc.expectedProgress || ok != tc.expectedOk {
+				t.Errorf("expected (%d, %t), got (%d, %t)", tc.expectedProgress, tc.expectedOk, progress, ok)
+			}
+		})
+	}
+}
+
+func TestIsOperationWaitInterrupted(t *testing.T) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cases := map[string]struct {
+		ctx      context.Context
+		err      error
+		expected bool
+	}{
+		"operation failed": {
+			ctx: context.Background(),
+			err: &CommonOpError{},
+		},
+		"timed out": {
+			ctx:      context.Background(),
+			err:      fmt.Errorf("Error waiting for my-activity: %w", &retry.TimeoutError{}),
+			expected: true,
+		},
+		"shutting down": {
+			ctx:      cancelled,
+			err:      errors.New("context canceled"),
+			expected: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			config := &transport_tpg.Config{Context: tc.ctx}
+			if got := IsOperationWaitInterrupted(config, tc.err); got != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, got)
+			}
+		})
+	}
+}
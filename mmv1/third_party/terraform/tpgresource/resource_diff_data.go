@@ -0,0 +1,59 @@
+package tpgresource
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+// ResourceDiffData is a TerraformResourceData reading the planned values of a
+// resource, so the request of a planned create or update can be built with
+// the same expanders as at apply time. Setting values isn't supported and is
+// ignored.
+type ResourceDiffData struct {
+	*schema.ResourceDiff
+}
+
+var _ TerraformResourceData = ResourceDiffData{}
+
+func NewResourceDiffData(diff *schema.ResourceDiff) ResourceDiffData {
+	return ResourceDiffData{ResourceDiff: diff}
+}
+
+func (d ResourceDiffData) Set(string, interface{}) error {
+	return nil
+}
+
+func (d ResourceDiffData) SetId(string) {}
+
+// GetProviderMeta leaves the provider meta unset, as it isn't available when
+// planning.
+func (d ResourceDiffData) GetProviderMeta(interface{}) error {
+	return nil
+}
+
+func (d ResourceDiffData) Timeout(string) time.Duration {
+	return transport_tpg.DefaultRequestTimeout
+}
+
+// FirstUnknown returns the first of the given top-level fields whose
+// configured value isn't wholly known, such as when it references a resource
+// that isn't created yet, or "" if they are all known. Fields that aren't
+// configured are known, even if they're computed.
+func (d ResourceDiffData) FirstUnknown(keys ...string) string {
+	config := d.GetRawConfig()
+	if config.IsNull() || len(keys) == 0 {
+		return ""
+	}
+	if !config.IsKnown() {
+		return keys[0]
+	}
+	for _, k := range keys {
+		if config.Type().HasAttribute(k) && !config.GetAttr(k).IsWhollyKnown() {
+			return k
+		}
+	}
+	return ""
+}
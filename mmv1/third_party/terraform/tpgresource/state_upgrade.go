@@ -0,0 +1,87 @@
+package tpgresource
+
+import (
+	"strings"
+)
+
+// MoveRawStateField moves the value of a field in the raw state of a resource
+// to another path, for state upgraders of renamed, flattened or nested
+// fields. Paths are dot-separated field names, such as `settings.tier`. The
+// value is moved within each element of the deepest block both paths share;
+// the other blocks along the paths hold a single element, as nested objects
+// do, and are created if needed.
+func MoveRawStateField(rawState map[string]interface{}, from, to string) {
+	fromParts, toParts := strings.Split(from, "."), strings.Split(to, ".")
+	i := 0
+	for i < len(fromParts)-1 && i < len(toParts)-1 && fromParts[i] == toParts[i] {
+		i++
+	}
+
+	for _, obj := range rawStateObjects(rawState, fromParts[:i]) {
+		if v, ok := takeRawStateValue(obj, fromParts[i:]); ok {
+			setRawStateValue(obj, toParts[i:], v)
+		}
+	}
+}
+
+// DeleteRawStateField removes a field from the raw state of a resource, within
+// each element of the blocks along its dot-separated path.
+func DeleteRawStateField(rawState map[string]interface{}, path string) {
+	parts := strings.Split(path, ".")
+	for _, obj := range rawStateObjects(rawState, parts[:len(parts)-1]) {
+		delete(obj, parts[len(parts)-1])
+	}
+}
+
+// rawStateObjects returns the elements of the block at path in obj, across
+// every element of the blocks along the path.
+func rawStateObjects(obj map[string]interface{}, path []string) []map[string]interface{} {
+	if len(path) == 0 {
+		return []map[string]interface{}{obj}
+	}
+
+	var objs []map[string]interface{}
+	elems, _ := obj[path[0]].([]interface{})
+	for _, elem := range elems {
+		if m, ok := elem.(map[string]interface{}); ok {
+			objs = append(objs, rawStateObjects(m, path[1:])...)
+		}
+	}
+	return objs
+}
+
+// takeRawStateValue removes the value at path from obj and returns it. It
+// returns false if the value isn't set.
+func takeRawStateValue(obj map[string]interface{}, path []string) (interface{}, bool) {
+	if len(path) == 1 {
+		v, ok := obj[path[0]]
+		delete(obj, path[0])
+		return v, ok && v != nil
+	}
+
+	elems, _ := obj[path[0]].([]interface{})
+	if len(elems) != 1 {
+		return nil, false
+	}
+	m, ok := elems[0].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	return takeRawStateValue(m, path[1:])
+}
+
+func setRawStateValue(obj map[string]interface{}, path []string, v interface{}) {
+	if len(path) == 1 {
+		obj[path[0]] = v
+		return
+	}
+
+	elems, _ := obj[path[0]].([]interface{})
+	if len(elems) == 0 {
+		elems = []interface{}{map[string]interface{}{}}
+		obj[path[0]] = elems
+	}
+	if m, ok := elems[0].(map[string]interface{}); ok {
+		setRawStateValue(m, path[1:], v)
+	}
+}
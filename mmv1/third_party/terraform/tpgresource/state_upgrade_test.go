@@ -0,0 +1,100 @@
+package tpgresource
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMoveRawStateField(t *testing.T) {
+	cases := map[string]struct {
+		rawState map[string]interface{}
+		from     string
+		to       string
+		expected map[string]interface{}
+	}{
+		"rename": {
+			rawState: map[string]interface{}{"size": 1},
+			from:     "size",
+			to:       "size_gb",
+			expected: map[string]interface{}{"size_gb": 1},
+		},
+		"flatten": {
+			rawState: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{"tier": "STANDARD", "zone": "a"}},
+			},
+			from: "settings.tier",
+			to:   "tier",
+			expected: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{"zone": "a"}},
+				"tier":     "STANDARD",
+			},
+		},
+		"nest": {
+			rawState: map[string]interface{}{"tier": "STANDARD"},
+			from:     "tier",
+			to:       "settings.tier",
+			expected: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{"tier": "STANDARD"}},
+			},
+		},
+		"nest unset": {
+			rawState: map[string]interface{}{"tier": nil},
+			from:     "tier",
+			to:       "settings.tier",
+			expected: map[string]interface{}{},
+		},
+		"rename in repeated block": {
+			rawState: map[string]interface{}{
+				"networks": []interface{}{
+					map[string]interface{}{"modes": []interface{}{"IPV4"}},
+					map[string]interface{}{"modes": []interface{}{"IPV6"}},
+				},
+			},
+			from: "networks.modes",
+			to:   "networks.connect_modes",
+			expected: map[string]interface{}{
+				"networks": []interface{}{
+					map[string]interface{}{"connect_modes": []interface{}{"IPV4"}},
+					map[string]interface{}{"connect_modes": []interface{}{"IPV6"}},
+				},
+			},
+		},
+		"missing block": {
+			rawState: map[string]interface{}{"name": "foo"},
+			from:     "settings.tier",
+			to:       "tier",
+			expected: map[string]interface{}{"name": "foo"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			MoveRawStateField(tc.rawState, tc.from, tc.to)
+			if !reflect.DeepEqual(tc.rawState, tc.expected) {
+				t.Errorf("expected %#v, got %#v", tc.expected, tc.rawState)
+			}
+		})
+	}
+}
+
+func TestDeleteRawStateField(t *testing.T) {
+	rawState := map[string]interface{}{
+		"name": "foo",
+		"networks": []interface{}{
+			map[string]interface{}{"network": "a", "reserved_ip_range": "10.0.0.0/29"},
+			map[string]interface{}{"network": "b"},
+		},
+	}
+	DeleteRawStateField(rawState, "networks.reserved_ip_range")
+	DeleteRawStateField(rawState, "name")
+
+	expected := map[string]interface{}{
+		"networks": []interface{}{
+			map[string]interface{}{"network": "a"},
+			map[string]interface{}{"network": "b"},
+		},
+	}
+	if !reflect.DeepEqual(rawState, expected) {
+		t.Errorf("expected %#v, got %#v", expected, rawState)
+	}
+}
@@ -47,6 +47,12 @@ type TerraformResourceData interface {
 	Timeout(key string) time.Duration
 }
 
+// TerraformResourceRawConfig is implemented by both schema.ResourceData and
+// schema.ResourceDiff.
+type TerraformResourceRawConfig interface {
+	GetRawConfigAt(valPath cty.Path) (cty.Value, diag.Diagnostics)
+}
+
 type TerraformResourceDiff interface {
 	HasChange(string) bool
 	GetChange(string) (interface{}, interface{})
@@ -99,6 +105,30 @@ func GetBillingProject(d TerraformResourceData, config *transport_tpg.Config) (s
 	return GetBillingProjectFromSchema("billing_project", d, config)
 }
 
+// SetListParameterDefaults fills the project, region and zone parameters of a
+// list resource that aren't set in params from the given resource data, falling
+// back to the provider's values the same way the managed resource does. If a
+// value is set on neither, an error is returned.
+func SetListParameterDefaults(d TerraformResourceData, config *transport_tpg.Config, params map[string]string, names ...string) error {
+	getters := map[string]func(TerraformResourceData, *transport_tpg.Config) (string, error){
+		"project": GetProject,
+		"region":  GetRegion,
+		"zone":    GetZone,
+	}
+	for _, name := range names {
+		get, ok := getters[name]
+		if _, set := params[name]; set || !ok {
+			continue
+		}
+		v, err := get(d, config)
+		if err != nil {
+			return err
+		}
+		params[name] = v
+	}
+	return nil
+}
+
 // GetProjectFromDiff reads the "project" field from the given diff and falls
 // back to the provider's value if not given. If the provider's value is not
 // given, an error is returned.
@@ -701,6 +731,22 @@ func ReplaceVarsForId(d TerraformResourceData, config *transport_tpg.Config, lin
 	return ReplaceVarsRecursive(d, config, linkTmpl, true, 0)
 }
 
+// ReplaceLockKeys replaces the variables in the keys of a resource's locks.
+// Variables are shortened like ReplaceVarsForId, so a key like
+// projects/{{project}}/global/networks/{{network}}/peerings is the same
+// relative link whether network was set to a name or a self link.
+func ReplaceLockKeys(d TerraformResourceData, config *transport_tpg.Config, keys []transport_tpg.LockKey) ([]transport_tpg.LockKey, error) {
+	replaced := make([]transport_tpg.LockKey, 0, len(keys))
+	for _, k := range keys {
+		key, err := ReplaceVarsForId(d, config, k.Key)
+		if err != nil {
+			return nil, err
+		}
+		replaced = append(replaced, transport_tpg.LockKey{Key: key, Shared: k.Shared})
+	}
+	return replaced, nil
+}
+
 // ReplaceVars must be done recursively because there are baseUrls that can contain references to regions
 // (eg cloudrun service) there aren't any cases known for 2+ recursion but we will track a run away
 // substitution as 10+ calls to allow for future use cases.
@@ -916,7 +962,7 @@ func ReducedPrefixedUniqueId(prefix string) string {
 // GetRawConfigAttributeAsString retrieves an attribute directly from the raw config
 // This is useful for retrieving values that are not directly accessible via the
 // standard schema.ResourceData.Get method, such as write-only attributes.
-func GetRawConfigAttributeAsString(d *schema.ResourceData, key string) string {
+func GetRawConfigAttributeAsString(d TerraformResourceRawConfig, key string) string {
 	// see https://developer.hashicorp.com/terraform/plugin/sdkv2/resources/write-only-arguments#retrieving-write-only-values
 	parts := strings.Split(key, ".")
 
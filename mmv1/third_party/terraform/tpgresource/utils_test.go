@@ -283,6 +283,77 @@ func TestGetProject(t *testing.T) {
 	}
 }
 
+func TestSetListParameterDefaults(t *testing.T) {
+	cases := map[string]struct {
+		Params         map[string]string
+		ProviderConfig map[string]string
+		ExpectedParams map[string]string
+		ExpectedError  bool
+	}{
+		"parameters are pulled from provider config when not set on the list resource": {
+			Params: map[string]string{},
+			ProviderConfig: map[string]string{
+				"project": "provider-project",
+				"region":  "provider-region",
+				"zone":    "provider-zone-a",
+			},
+			ExpectedParams: map[string]string{
+				"project": "provider-project",
+				"region":  "provider-region",
+				"zone":    "provider-zone-a",
+			},
+		},
+		"parameters set on the list resource are kept": {
+			Params: map[string]string{
+				"project": "list-project",
+			},
+			ProviderConfig: map[string]string{
+				"project": "provider-project",
+				"region":  "provider-region",
+				"zone":    "provider-zone-a",
+			},
+			ExpectedParams: map[string]string{
+				"project": "list-project",
+				"region":  "provider-region",
+				"zone":    "provider-zone-a",
+			},
+		},
+		"error returned when project not set on either provider or list resource": {
+			Params:        map[string]string{},
+			ExpectedError: true,
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			config := transport_tpg.Config{
+				Project: tc.ProviderConfig["project"],
+				Region:  tc.ProviderConfig["region"],
+				Zone:    tc.ProviderConfig["zone"],
+			}
+			resourceConfig := make(map[string]interface{})
+			for k, v := range tc.Params {
+				resourceConfig[k] = v
+			}
+			d := tpgresource.SetupTestResourceDataFromConfigMap(t, fictionalSchema, resourceConfig)
+
+			err := tpgresource.SetListParameterDefaults(d, &config, tc.Params, "project", "region", "zone", "name")
+			if err != nil {
+				if tc.ExpectedError {
+					return
+				}
+				t.Fatalf("Unexpected error using test: %s", err)
+			}
+			if tc.ExpectedError {
+				t.Fatal("Expected an error, got none")
+			}
+
+			if !reflect.DeepEqual(tc.Params, tc.ExpectedParams) {
+				t.Fatalf("Incorrect parameters: got %v, want %v", tc.Params, tc.ExpectedParams)
+			}
+		})
+	}
+}
+
 func TestGetLocation(t *testing.T) {
 	cases := map[string]struct {
 		ResourceConfig   map[string]interface{}
@@ -1167,6 +1238,36 @@ func TestReplaceVars(t *testing.T) {
 	}
 }
 
+func TestReplaceLockKeys(t *testing.T) {
+	config := &transport_tpg.Config{Project: "default-project"}
+	keys := []transport_tpg.LockKey{
+		{Key: "projects/{{project}}/global/networks/{{network}}/peerings", Shared: true},
+	}
+	expected := []transport_tpg.LockKey{
+		{Key: "projects/default-project/global/networks/my-network/peerings", Shared: true},
+	}
+
+	for tn, network := range map[string]string{
+		"name":          "my-network",
+		"relative link": "projects/default-project/global/networks/my-network",
+		"self link":     "https://www.googleapis.com/compute/v1/projects/default-project/global/networks/my-network",
+	} {
+		t.Run(tn, func(t *testing.T) {
+			d := &tpgresource.ResourceDataMock{
+				FieldsInSchema: map[string]interface{}{"network": network},
+			}
+
+			replaced, err := tpgresource.ReplaceLockKeys(d, config, keys)
+			if err != nil {
+				t.Fatalf("bad: %s; unexpected error %s", tn, err)
+			}
+			if !reflect.DeepEqual(replaced, expected) {
+				t.Errorf("bad: %s; expected %v, got %v", tn, expected, replaced)
+			}
+		})
+	}
+}
+
 func TestNormalizeIamPrincipalCasing(t *testing.T) {
 	cases := map[string]struct {
 		Principal string
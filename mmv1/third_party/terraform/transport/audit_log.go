@@ -0,0 +1,323 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// AuditLogPathEnvVar enables the audit log when the provider doesn't
+// configure it.
+const AuditLogPathEnvVar = "GOOGLE_AUDIT_LOG_PATH"
+
+const auditLogRedacted = "REDACTED"
+
+// auditLogCredentialParams are the query parameters carrying credentials.
+var auditLogCredentialParams = []string{"access_token", "key", "oauth_token"}
+
+// auditLogCredentialFields are the body fields carrying credentials, at any
+// depth.
+var auditLogCredentialFields = []string{
+	"accessToken", "access_token",
+	"clientSecret", "client_secret",
+	"idToken", "id_token",
+	"privateKey", "privateKeyData", "private_key",
+	"refreshToken", "refresh_token",
+}
+
+// AuditLogConfig contains user configuration for the audit log, which has a
+// JSON line per API call made by the provider.
+type AuditLogConfig struct {
+	// Path is the file lines are appended to.
+	Path string
+	// IncludeBodies adds the request and response bodies to the lines, with
+	// credentials and sensitive fields redacted.
+	IncludeBodies bool
+}
+
+// AuditResource identifies the resource an API call is made for in the audit
+// log.
+type AuditResource struct {
+	// Type is the Terraform resource type, such as google_dns_managed_zone.
+	Type string
+	// Id is the Terraform ID of the resource, empty until it's created.
+	Id string
+	// SensitiveFields are the API paths of the resource's sensitive fields,
+	// such as "settings.password", redacted from logged bodies.
+	SensitiveFields []string
+}
+
+type auditResourceKey struct{}
+
+type auditRetriesKey struct{}
+
+// ContextWithAuditResource returns a context whose requests are logged as
+// made for a resource.
+func ContextWithAuditResource(ctx context.Context, r *AuditResource) context.Context {
+	return context.WithValue(ctx, auditResourceKey{}, r)
+}
+
+// recordAuditRetries records the retries of a request for its audit log
+// line, if it's logged.
+func recordAuditRetries(ctx context.Context, retries int) {
+	if p, ok := ctx.Value(auditRetriesKey{}).(*int); ok {
+		*p = retries
+	}
+}
+
+// auditLogEntry is a line of the audit log.
+type auditLogEntry struct {
+	Time         time.Time `json:"time"`
+	Method       string    `json:"method"`
+	Url          string    `json:"url"`
+	Status       int       `json:"status,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	LatencyMs    int64     `json:"latency_ms"`
+	Retries      int       `json:"retries"`
+	ResourceType string    `json:"resource_type,omitempty"`
+	ResourceId   string    `json:"resource_id,omitempty"`
+	UserProject  string    `json:"user_project,omitempty"`
+	RequestBody  any       `json:"request_body,omitempty"`
+	ResponseBody any       `json:"response_body,omitempty"`
+}
+
+// auditLogWriter appends lines to an audit log file. It's shared by the
+// provider configurations logging to the same file, so lines aren't
+// interleaved.
+type auditLogWriter struct {
+	sync.Mutex
+	f *os.File
+}
+
+var (
+	auditLogWritersMu sync.Mutex
+	auditLogWriters   = make(map[string]*auditLogWriter)
+)
+
+func openAuditLog(path string) (*auditLogWriter, error) {
+	auditLogWritersMu.Lock()
+	defer auditLogWritersMu.Unlock()
+	if w, ok := auditLogWriters[path]; ok {
+		return w, nil
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening audit log: %w", err)
+	}
+	w := &auditLogWriter{f: f}
+	auditLogWriters[path] = w
+	return w, nil
+}
+
+func (w *auditLogWriter) write(e *auditLogEntry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	w.Lock()
+	defer w.Unlock()
+	_, err = w.f.Write(append(b, '\n'))
+	return err
+}
+
+// auditLogTransport is a http.RoundTripper writing a line to the audit log
+// for each request, after its retries.
+type auditLogTransport struct {
+	internal      http.RoundTripper
+	w             *auditLogWriter
+	includeBodies bool
+}
+
+// NewAuditLogTransport wraps a transport to write the audit log, or returns
+// it unchanged if the audit log isn't enabled.
+func NewAuditLogTransport(t http.RoundTripper, config *AuditLogConfig) (http.RoundTripper, error) {
+	if config == nil || config.Path == "" {
+		return t, nil
+	}
+	w, err := openAuditLog(config.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &auditLogTransport{internal: t, w: w, includeBodies: config.IncludeBodies}, nil
+}
+
+func (t *auditLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r, _ := req.Context().Value(auditResourceKey{}).(*AuditResource)
+	e := &auditLogEntry{
+		Time:        time.Now().UTC(),
+		Method:      req.Method,
+		Url:         redactAuditUrl(req.URL),
+		UserProject: req.Header.Get("X-Goog-User-Project"),
+	}
+	var sensitiveFields []string
+	if r != nil {
+		e.ResourceType = r.Type
+		e.ResourceId = r.Id
+		sensitiveFields = r.SensitiveFields
+	}
+	if t.includeBodies {
+		body, err := peekRequestBody(req)
+		if err != nil {
+			return nil, err
+		}
+		e.RequestBody = redactAuditBody(body, sensitiveFields)
+	}
+
+	var retries int
+	req = req.WithContext(context.WithValue(req.Context(), auditRetriesKey{}, &retries))
+	resp, err := t.internal.RoundTrip(req)
+	e.LatencyMs = time.Since(e.Time).Milliseconds()
+	e.Retries = retries
+	if err != nil {
+		e.Error = err.Error()
+	}
+	if resp != nil {
+		e.Status = resp.StatusCode
+		if t.includeBodies && resp.Body != nil {
+			body, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			if readErr != nil {
+				return resp, readErr
+			}
+			e.ResponseBody = redactAuditBody(body, sensitiveFields)
+		}
+	}
+
+	if writeErr := t.w.write(e); writeErr != nil {
+		// The API call was made, so failing to log it doesn't fail the request.
+		log.Printf("[WARN] Audit Log: error writing %s %s: %s", e.Method, e.Url, writeErr)
+	}
+	return resp, err
+}
+
+// peekRequestBody returns the body of a request without consuming it.
+func peekRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer body.Close()
+		return io.ReadAll(body)
+	}
+	b, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(b))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	}
+	return b, nil
+}
+
+func redactAuditUrl(u *url.URL) string {
+	redacted := *u
+	q := redacted.Query()
+	for _, p := range auditLogCredentialParams {
+		if q.Has(p) {
+			q.Set(p, auditLogRedacted)
+		}
+	}
+	redacted.RawQuery = q.Encode()
+	return redacted.String()
+}
+
+// redactAuditBody decodes a JSON body and redacts its credentials and
+// sensitive fields. Bodies that aren't JSON are omitted.
+func redactAuditBody(b []byte, sensitiveFields []string) any {
+	if len(b) == 0 {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
+		return nil
+	}
+	redactAuditCredentials(v)
+	for _, f := range sensitiveFields {
+		redactAuditPath(v, strings.Split(f, "."))
+	}
+	return v
+}
+
+func redactAuditCredentials(v any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, e := range v {
+			redactAuditCredentials(e)
+			for _, f := range auditLogCredentialFields {
+				if k == f {
+					v[k] = auditLogRedacted
+				}
+			}
+		}
+	case []any:
+		for _, e := range v {
+			redactAuditCredentials(e)
+		}
+	}
+}
+
+// redactAuditPath redacts a field given by its path. Lists along the path
+// have the field redacted in each of their elements.
+func redactAuditPath(v any, path []string) {
+	switch v := v.(type) {
+	case map[string]any:
+		e, ok := v[path[0]]
+		if !ok {
+			return
+		}
+		if len(path) == 1 {
+			v[path[0]] = auditLogRedacted
+			return
+		}
+		redactAuditPath(e, path[1:])
+	case []any:
+		for _, e := range v {
+			redactAuditPath(e, path)
+		}
+	}
+}
+
+// ExpandProviderAuditLogConfig expands the audit log configuration, falling
+// back to GOOGLE_AUDIT_LOG_PATH. It returns nil if the audit log isn't
+// enabled.
+func ExpandProviderAuditLogConfig(v interface{}) (*AuditLogConfig, error) {
+	config := &AuditLogConfig{
+		Path: os.Getenv(AuditLogPathEnvVar),
+	}
+
+	ls, _ := v.([]interface{})
+	if len(ls) == 0 || ls[0] == nil {
+		if config.Path == "" {
+			return nil, nil
+		}
+		return config, nil
+	}
+
+	cfgV := ls[0].(map[string]interface{})
+	if path, ok := cfgV["path"]; ok && path.(string) != "" {
+		config.Path = path.(string)
+	}
+	if includeBodies, ok := cfgV["include_bodies"]; ok {
+		config.IncludeBodies = includeBodies.(bool)
+	}
+	if config.Path == "" {
+		return nil, fmt.Errorf("'path' must be set for the audit log, or %s", AuditLogPathEnvVar)
+	}
+	return config, nil
+}
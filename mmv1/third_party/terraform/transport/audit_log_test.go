@@ -0,0 +1,140 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestAuditLogTransport(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"name": "my-db", "settings": [{"password": "hunter2", "tier": "small"}], "accessToken": "ya29"}`))
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLogTransport, err := NewAuditLogTransport(NewTransportWithDefaultRetries(http.DefaultTransport), &AuditLogConfig{Path: path, IncludeBodies: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := ts.Client()
+	client.Transport = auditLogTransport
+
+	body := `{"name": "my-db", "settings": [{"password": "hunter2", "tier": "small"}]}`
+	req, err := http.NewRequest("POST", ts.URL+"/v1/projects/my-project/databases?key=secret&alt=json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("X-Goog-User-Project", "billing-project")
+	req = req.WithContext(ContextWithAuditResource(req.Context(), &AuditResource{
+		Type:            "google_sql_database",
+		SensitiveFields: []string{"settings.password", "missing.field"},
+	}))
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	respBody, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !bytes.Contains(respBody, []byte("hunter2")) {
+		t.Errorf("expected the response body to be unchanged, got %s", respBody)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected a line per request, got %d: %s", len(lines), b)
+	}
+	var e map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &e); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"method":        "POST",
+		"url":           ts.URL + "/v1/projects/my-project/databases?alt=json&key=REDACTED",
+		"status":        float64(200),
+		"retries":       float64(1),
+		"resource_type": "google_sql_database",
+		"user_project":  "billing-project",
+		"request_body": map[string]interface{}{
+			"name":     "my-db",
+			"settings": []interface{}{map[string]interface{}{"password": "REDACTED", "tier": "small"}},
+		},
+		"response_body": map[string]interface{}{
+			"name":        "my-db",
+			"settings":    []interface{}{map[string]interface{}{"password": "REDACTED", "tier": "small"}},
+			"accessToken": "REDACTED",
+		},
+	}
+	for k, v := range expected {
+		if !reflect.DeepEqual(e[k], v) {
+			t.Errorf("expected %s to be %v, got %v", k, v, e[k])
+		}
+	}
+	if _, ok := e["resource_id"]; ok {
+		t.Errorf("expected no resource_id before the resource is created, got %v", e["resource_id"])
+	}
+}
+
+func TestExpandProviderAuditLogConfig(t *testing.T) {
+	cases := map[string]struct {
+		v           interface{}
+		env         string
+		expected    *AuditLogConfig
+		expectError bool
+	}{
+		"unset": {
+			v: []interface{}{},
+		},
+		"env var": {
+			v:        []interface{}{},
+			env:      "/tmp/env.jsonl",
+			expected: &AuditLogConfig{Path: "/tmp/env.jsonl"},
+		},
+		"block": {
+			v:        []interface{}{map[string]interface{}{"path": "/tmp/audit.jsonl", "include_bodies": true}},
+			env:      "/tmp/env.jsonl",
+			expected: &AuditLogConfig{Path: "/tmp/audit.jsonl", IncludeBodies: true},
+		},
+		"block with env var path": {
+			v:        []interface{}{map[string]interface{}{"path": "", "include_bodies": true}},
+			env:      "/tmp/env.jsonl",
+			expected: &AuditLogConfig{Path: "/tmp/env.jsonl", IncludeBodies: true},
+		},
+		"block without path": {
+			v:           []interface{}{map[string]interface{}{"path": "", "include_bodies": true}},
+			expectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			t.Setenv(AuditLogPathEnvVar, tc.env)
+			config, err := ExpandProviderAuditLogConfig(tc.v)
+			if (err != nil) != tc.expectError {
+				t.Fatalf("expected error %t, got %v", tc.expectError, err)
+			}
+			if !reflect.DeepEqual(config, tc.expected) {
+				t.Errorf("expected %+v, got %+v", tc.expected, config)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
+	"slices"
 	"sync"
 	"time"
 
@@ -334,3 +336,131 @@ func (req *BatchRequest) send() batchResponse {
 	v, err := req.SendF(req.ResourceName, req.Body)
 	return batchResponse{v, err}
 }
+
+// BatchedRequestsSendFunc sends the combined requests of a batch of a
+// generated resource, and returns the response of each request in the same
+// order. An error is returned if the batch as a whole failed, in which case its
+// requests are retried one by one.
+type BatchedRequestsSendFunc func(batchKey string, reqs []SendRequestOptions) ([]BatchedResponse, error)
+
+// BatchedResponse is the response to a single request of a batch.
+type BatchedResponse struct {
+	Body map[string]interface{}
+	Err  error
+}
+
+// batchedResponses holds the responses to the requests of a sent batch, so
+// that each request can find its own.
+type batchedResponses struct {
+	reqs      []SendRequestOptions
+	responses []BatchedResponse
+}
+
+// SendBatchedRequest sends a request of a generated resource through a
+// batcher, as part of the batch of requests with the same batchKey. The body of
+// the batch is the []SendRequestOptions of its requests, combined by combineF
+// and sent by sendF, and the response to this request is returned.
+func SendBatchedRequest(b *RequestBatcher, batchKey string, combineF BatcherCombineFunc, sendF BatchedRequestsSendFunc, opt SendRequestOptions) (map[string]interface{}, error) {
+	req := &BatchRequest{
+		ResourceName: batchKey,
+		Body:         []SendRequestOptions{opt},
+		CombineF:     combineF,
+		SendF: func(resourceName string, body interface{}) (interface{}, error) {
+			reqs, ok := body.([]SendRequestOptions)
+			if !ok {
+				return nil, fmt.Errorf("Expected batch body type to be []SendRequestOptions, got %T. This is a provider error.", body)
+			}
+			responses, err := sendF(resourceName, reqs)
+			if err != nil {
+				return nil, err
+			}
+			if len(responses) != len(reqs) {
+				return nil, fmt.Errorf("Expected %d responses to batch %q, got %d. This is a provider error.", len(reqs), resourceName, len(responses))
+			}
+			return batchedResponses{reqs: reqs, responses: responses}, nil
+		},
+		DebugId: fmt.Sprintf("%s %s", opt.Method, opt.RawURL),
+	}
+
+	var v interface{}
+	var err error
+	if b == nil {
+		// Configs that weren't loaded, such as in unit tests, have no batchers
+		v, err = req.SendF(req.ResourceName, req.Body)
+	} else {
+		v, err = b.SendRequestWithTimeout(batchKey, req, opt.Timeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	batch, ok := v.(batchedResponses)
+	if !ok {
+		return nil, fmt.Errorf("Expected batch response type to be batchedResponses, got %T. This is a provider error.", v)
+	}
+	i := slices.IndexFunc(batch.reqs, func(r SendRequestOptions) bool {
+		return sameBatchedRequest(r, opt)
+	})
+	if i < 0 {
+		return nil, fmt.Errorf("Request %q was not found in batch %q. This is a provider error.", req.DebugId, batchKey)
+	}
+	res := batch.responses[i]
+	if res.Err != nil {
+		return nil, res.Err
+	}
+	if res.Body == nil {
+		return map[string]interface{}{}, nil
+	}
+	return res.Body, nil
+}
+
+// SendBatchedRequestsInOrder is a BatchedRequestsSendFunc for APIs without a
+// batch method. It sends the requests of a batch one after another, so that
+// resources created or deleted in large numbers don't send their requests all
+// at once.
+func SendBatchedRequestsInOrder(_ string, reqs []SendRequestOptions) ([]BatchedResponse, error) {
+	responses := make([]BatchedResponse, len(reqs))
+	for i, req := range reqs {
+		responses[i].Body, responses[i].Err = SendRequest(req)
+	}
+	return responses, nil
+}
+
+// sameBatchedRequest reports whether two requests of a batch are identical,
+// and so get the same response.
+func sameBatchedRequest(a, b SendRequestOptions) bool {
+	return a.Method == b.Method && a.RawURL == b.RawURL && reflect.DeepEqual(a.Body, b.Body)
+}
+
+// AppendBatchedRequests is a BatcherCombineFunc keeping all of the requests
+// of a batch.
+func AppendBatchedRequests(body interface{}, toAdd interface{}) (interface{}, error) {
+	reqs, ok := body.([]SendRequestOptions)
+	if !ok {
+		return nil, fmt.Errorf("Expected batch body type to be []SendRequestOptions, got %T. This is a provider error.", body)
+	}
+	toAddReqs, ok := toAdd.([]SendRequestOptions)
+	if !ok {
+		return nil, fmt.Errorf("Expected new request body type to be []SendRequestOptions, got %T. This is a provider error.", toAdd)
+	}
+	return append(reqs, toAddReqs...), nil
+}
+
+// DedupeBatchedRequests is a BatcherCombineFunc keeping a single request out
+// of identical requests to the same URL, such as requests enabling the same
+// feature for several resources.
+func DedupeBatchedRequests(body interface{}, toAdd interface{}) (interface{}, error) {
+	combined, err := AppendBatchedRequests(body, toAdd)
+	if err != nil {
+		return nil, err
+	}
+	reqs := combined.([]SendRequestOptions)
+	var deduped []SendRequestOptions
+	for _, req := range reqs {
+		if !slices.ContainsFunc(deduped, func(d SendRequestOptions) bool {
+			return sameBatchedRequest(d, req)
+		}) {
+			deduped = append(deduped, req)
+		}
+	}
+	return deduped, nil
+}
@@ -292,3 +292,97 @@ func testBasicCountBatches(t *testing.T, testName string, numBatches int) {
 		}(i)
 	}
 }
+
+func TestSendBatchedRequest(t *testing.T) {
+	testBatcher := NewRequestBatcher(
+		"testBatcher",
+		context.Background(),
+		&BatchingConfig{
+			SendAfter:      time.Duration(1) * time.Second,
+			EnableBatching: true,
+		})
+
+	var sent [][]SendRequestOptions
+	var sentMu sync.Mutex
+	testSendBatch := func(batchKey string, reqs []SendRequestOptions) ([]BatchedResponse, error) {
+		sentMu.Lock()
+		defer sentMu.Unlock()
+		sent = append(sent, reqs)
+		var responses []BatchedResponse
+		for _, req := range reqs {
+			id := req.Body["id"].(int)
+			if id == 2 {
+				responses = append(responses, BatchedResponse{Err: fmt.Errorf("widget %d is invalid", id)})
+				continue
+			}
+			responses = append(responses, BatchedResponse{Body: map[string]interface{}{"name": fmt.Sprintf("widgets/%d", id)}})
+		}
+		return responses, nil
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(3)
+	for i := 0; i < 3; i++ {
+		go func(idx int) {
+			defer wg.Done()
+			res, err := SendBatchedRequest(testBatcher, "projects/p/widgets:create", AppendBatchedRequests, testSendBatch, SendRequestOptions{
+				Method:  "POST",
+				RawURL:  "https://widgets.googleapis.com/v1/projects/p/widgets",
+				Body:    map[string]interface{}{"id": idx},
+				Timeout: 5 * time.Second,
+			})
+			if idx == 2 {
+				if err == nil || !strings.Contains(err.Error(), "widget 2 is invalid") {
+					t.Errorf("expected the error of request %d, got %v", idx, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("got unexpected error %s", err)
+				return
+			}
+			if want := fmt.Sprintf("widgets/%d", idx); res["name"] != want {
+				t.Errorf("expected the response of request %d to be %q, got %v", idx, want, res)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(sent) != 1 || len(sent[0]) != 3 {
+		t.Errorf("expected a single batch of 3 requests, got %v", sent)
+	}
+}
+
+func TestSendBatchedRequest_missingResponses(t *testing.T) {
+	testSendBatch := func(batchKey string, reqs []SendRequestOptions) ([]BatchedResponse, error) {
+		return nil, nil
+	}
+	_, err := SendBatchedRequest(nil, "projects/p/widgets:create", AppendBatchedRequests, testSendBatch, SendRequestOptions{
+		Method: "POST",
+		RawURL: "https://widgets.googleapis.com/v1/projects/p/widgets",
+	})
+	if err == nil {
+		t.Errorf("expected an error for a batch without a response for each request")
+	}
+}
+
+func TestDedupeBatchedRequests(t *testing.T) {
+	enable := SendRequestOptions{Method: "POST", RawURL: "https://example.com/v1/a:enable", Body: map[string]interface{}{"feature": "x"}}
+	other := SendRequestOptions{Method: "POST", RawURL: "https://example.com/v1/a:enable", Body: map[string]interface{}{"feature": "y"}}
+
+	body := interface{}([]SendRequestOptions{enable})
+	for _, req := range []SendRequestOptions{enable, other, enable} {
+		var err error
+		body, err = DedupeBatchedRequests(body, []SendRequestOptions{req})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if reqs := body.([]SendRequestOptions); len(reqs) != 2 {
+		t.Errorf("expected 2 distinct requests, got %d", len(reqs))
+	}
+
+	if _, err := DedupeBatchedRequests([]string{"a"}, []SendRequestOptions{enable}); err == nil {
+		t.Errorf("expected an error combining a body of the wrong type")
+	}
+}
@@ -3,12 +3,18 @@ package transport
 import (
 	"fmt"
 	"log"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
 )
 
+// pollingProgressInterval is the interval at which PollingWaitTime logs that
+// it's still waiting.
+const pollingProgressInterval = time.Minute
+
 type (
 	// Function handling for polling for a resource
 	PollReadFunc func() (resp map[string]interface{}, respErr error)
@@ -36,16 +42,21 @@ func PollingWaitTime(pollF PollReadFunc, checkResponse PollCheckResponseFunc, ac
 	timeout time.Duration, targetOccurrences int) error {
 	log.Printf("[DEBUG] %s: Polling until expected state is read", activity)
 	log.Printf("[DEBUG] Target occurrences: %d", targetOccurrences)
+	start := time.Now()
+	lastLogged := start
+	poll := func() *retry.RetryError {
+		readResp, readErr := pollF()
+		result := checkResponse(readResp, readErr)
+		if result != nil && result.Retryable && time.Since(lastLogged) >= pollingProgressInterval {
+			log.Printf("[INFO] %s: Still waiting after %s: %s", activity, time.Since(start).Round(time.Second), result.Err)
+			lastLogged = time.Now()
+		}
+		return result
+	}
 	if targetOccurrences == 1 {
-		return retry.Retry(timeout, func() *retry.RetryError {
-			readResp, readErr := pollF()
-			return checkResponse(readResp, readErr)
-		})
+		return retry.Retry(timeout, poll)
 	}
-	return RetryWithTargetOccurrences(timeout, targetOccurrences, func() *retry.RetryError {
-		readResp, readErr := pollF()
-		return checkResponse(readResp, readErr)
-	})
+	return RetryWithTargetOccurrences(timeout, targetOccurrences, poll)
 }
 
 // RetryWithTargetOccurrences is a basic wrapper around StateChangeConf that will retry
@@ -152,3 +163,142 @@ func PollCheckForAbsence(_ map[string]interface{}, respErr error) PollResult {
 	}
 	return PendingStatusPollResult("found")
 }
+
+// PollFieldCondition is a condition on a field of a polled resource.
+type PollFieldCondition struct {
+	// Field is the dotted path of the field in the API response, such as
+	// "status.state".
+	Field string
+	// Values are the values the field has to be one of, compared with its
+	// string representation.
+	Values []string
+	// Absent is whether the field has to be unset instead.
+	Absent bool
+}
+
+// PollConditions describe the state of a polled resource through conditions
+// on its fields, for APIs whose resources are read before they are ready.
+type PollConditions struct {
+	// Done are the conditions that all hold when the resource reached the
+	// state polled for.
+	Done []PollFieldCondition
+	// Failed are the conditions any of which holds when the resource won't
+	// reach the state polled for, which stops polling with an error.
+	Failed []PollFieldCondition
+	// NotFoundCodes are the error codes of a read of a resource that doesn't
+	// exist. Defaults to 404.
+	NotFoundCodes []int
+}
+
+func (c PollFieldCondition) holds(resp map[string]interface{}) bool {
+	v, ok := pollFieldValue(resp, c.Field)
+	if c.Absent {
+		return !ok
+	}
+	return ok && slices.Contains(c.Values, fmt.Sprint(v))
+}
+
+func (c PollFieldCondition) String() string {
+	if c.Absent {
+		return fmt.Sprintf("%q is unset", c.Field)
+	}
+	if len(c.Values) == 1 {
+		return fmt.Sprintf("%q is %q", c.Field, c.Values[0])
+	}
+	return fmt.Sprintf("%q is one of %q", c.Field, c.Values)
+}
+
+// pollFieldValue returns the value of a field of a response given by its
+// dotted path, and whether it's set.
+func pollFieldValue(resp map[string]interface{}, field string) (interface{}, bool) {
+	var v interface{} = resp
+	for _, name := range strings.Split(field, ".") {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[name]; !ok || v == nil {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func (c PollConditions) isNotFound(resp map[string]interface{}, respErr error) bool {
+	if respErr == nil {
+		// Nested object 404 appears as nil response
+		return resp == nil
+	}
+	codes := c.NotFoundCodes
+	if len(codes) == 0 {
+		codes = []int{404}
+	}
+	for _, code := range codes {
+		if IsGoogleApiErrorWithCode(respErr, code) {
+			return true
+		}
+	}
+	return false
+}
+
+// failed returns the Failed condition holding for a resource, if any.
+func (c PollConditions) failed(resp map[string]interface{}) (PollFieldCondition, bool) {
+	for _, cond := range c.Failed {
+		if cond.holds(resp) {
+			return cond, true
+		}
+	}
+	return PollFieldCondition{}, false
+}
+
+// check returns the result of polling a resource that exists.
+func (c PollConditions) check(resp map[string]interface{}) PollResult {
+	if cond, ok := c.failed(resp); ok {
+		return ErrorPollResult(fmt.Errorf("resource failed: %s", cond))
+	}
+	for _, cond := range c.Done {
+		if !cond.holds(resp) {
+			v, _ := pollFieldValue(resp, cond.Field)
+			return PendingStatusPollResult(fmt.Sprintf("%q is %v, waiting until %s", cond.Field, v, cond))
+		}
+	}
+	return SuccessPollResult()
+}
+
+// PollCheckForExistenceConditions returns a PollCheckResponseFunc waiting for
+// a resource to exist and satisfy the Done conditions. It continues polling
+// while the resource isn't found, and returns an error if a Failed condition
+// holds.
+func PollCheckForExistenceConditions(c PollConditions) PollCheckResponseFunc {
+	return func(resp map[string]interface{}, respErr error) PollResult {
+		if c.isNotFound(resp, respErr) {
+			return PendingStatusPollResult("not found")
+		}
+		if respErr != nil {
+			return ErrorPollResult(respErr)
+		}
+		return c.check(resp)
+	}
+}
+
+// PollCheckForAbsenceConditions returns a PollCheckResponseFunc waiting for a
+// resource to not be found, or, if there are Done conditions, to satisfy them
+// such as when it's soft deleted. It returns an error if a Failed condition
+// holds.
+func PollCheckForAbsenceConditions(c PollConditions) PollCheckResponseFunc {
+	return func(resp map[string]interface{}, respErr error) PollResult {
+		if c.isNotFound(resp, respErr) {
+			return SuccessPollResult()
+		}
+		if respErr != nil {
+			return ErrorPollResult(respErr)
+		}
+		if len(c.Done) == 0 {
+			if cond, ok := c.failed(resp); ok {
+				return ErrorPollResult(fmt.Errorf("resource failed: %s", cond))
+			}
+			return PendingStatusPollResult("found")
+		}
+		return c.check(resp)
+	}
+}
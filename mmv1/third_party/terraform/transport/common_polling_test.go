@@ -0,0 +1,132 @@
+package transport
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/api/googleapi"
+)
+
+func TestPollCheckForConditions(t *testing.T) {
+	conditions := PollConditions{
+		Done: []PollFieldCondition{
+			{Field: "status.state", Values: []string{"READY"}},
+			{Field: "reconciling", Absent: true},
+		},
+		Failed: []PollFieldCondition{
+			{Field: "status.state", Values: []string{"FAILED", "ERROR"}},
+		},
+		NotFoundCodes: []int{404, 403},
+	}
+	softDeleted := PollConditions{
+		Done: []PollFieldCondition{
+			{Field: "state", Values: []string{"DELETED"}},
+		},
+	}
+
+	cases := map[string]struct {
+		check             PollCheckResponseFunc
+		resp              map[string]interface{}
+		respErr           error
+		expectedSuccess   bool
+		expectedRetryable bool
+	}{
+		"existence ready": {
+			check:           PollCheckForExistenceConditions(conditions),
+			resp:            map[string]interface{}{"status": map[string]interface{}{"state": "READY"}},
+			expectedSuccess: true,
+		},
+		"existence pending state": {
+			check:             PollCheckForExistenceConditions(conditions),
+			resp:              map[string]interface{}{"status": map[string]interface{}{"state": "CREATING"}},
+			expectedRetryable: true,
+		},
+		"existence pending field": {
+			check:             PollCheckForExistenceConditions(conditions),
+			resp:              map[string]interface{}{"status": map[string]interface{}{"state": "READY"}, "reconciling": true},
+			expectedRetryable: true,
+		},
+		"existence missing parent field": {
+			check:             PollCheckForExistenceConditions(conditions),
+			resp:              map[string]interface{}{"status": "READY"},
+			expectedRetryable: true,
+		},
+		"existence failed": {
+			check: PollCheckForExistenceConditions(conditions),
+			resp:  map[string]interface{}{"status": map[string]interface{}{"state": "ERROR"}},
+		},
+		"existence not found": {
+			check:             PollCheckForExistenceConditions(conditions),
+			respErr:           &googleapi.Error{Code: 403},
+			expectedRetryable: true,
+		},
+		"existence error": {
+			check:   PollCheckForExistenceConditions(conditions),
+			respErr: &googleapi.Error{Code: 500},
+		},
+		"existence without conditions": {
+			check:           PollCheckForExistenceConditions(PollConditions{}),
+			resp:            map[string]interface{}{"name": "foo"},
+			expectedSuccess: true,
+		},
+		"existence non-string value": {
+			check: PollCheckForExistenceConditions(PollConditions{
+				Done: []PollFieldCondition{{Field: "ready", Values: []string{"true"}}},
+			}),
+			resp:            map[string]interface{}{"ready": true},
+			expectedSuccess: true,
+		},
+		"absence not found": {
+			check:           PollCheckForAbsenceConditions(PollConditions{}),
+			respErr:         &googleapi.Error{Code: 404},
+			expectedSuccess: true,
+		},
+		"absence nested object not found": {
+			check:           PollCheckForAbsenceConditions(PollConditions{}),
+			expectedSuccess: true,
+		},
+		"absence found": {
+			check:             PollCheckForAbsenceConditions(PollConditions{}),
+			resp:              map[string]interface{}{"name": "foo"},
+			expectedRetryable: true,
+		},
+		"absence error": {
+			check:   PollCheckForAbsenceConditions(PollConditions{}),
+			respErr: errors.New("connection reset"),
+		},
+		"absence soft deleted": {
+			check:           PollCheckForAbsenceConditions(softDeleted),
+			resp:            map[string]interface{}{"state": "DELETED"},
+			expectedSuccess: true,
+		},
+		"absence deleting": {
+			check:             PollCheckForAbsenceConditions(softDeleted),
+			resp:              map[string]interface{}{"state": "DELETING"},
+			expectedRetryable: true,
+		},
+		"absence failed": {
+			check: PollCheckForAbsenceConditions(PollConditions{
+				Failed: []PollFieldCondition{{Field: "state", Values: []string{"ACTIVE"}}},
+			}),
+			resp: map[string]interface{}{"state": "ACTIVE"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			result := tc.check(tc.resp, tc.respErr)
+			if tc.expectedSuccess {
+				if result != nil {
+					t.Fatalf("expected success, got %v", result.Err)
+				}
+				return
+			}
+			if result == nil {
+				t.Fatal("expected an error or pending result, got success")
+			}
+			if result.Retryable != tc.expectedRetryable {
+				t.Errorf("expected retryable %t, got %t: %v", tc.expectedRetryable, result.Retryable, result.Err)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package transport
 
 import (
 	"log"
+	"sort"
 	"sync"
+	"time"
 )
 
 // MutexKV is a simple key/value store for arbitrary mutexes. It can be used to
@@ -20,8 +22,9 @@ type MutexKV struct {
 // for the same key
 func (m *MutexKV) Lock(key string) {
 	log.Printf("[DEBUG] Locking %q", key)
+	start := time.Now()
 	m.get(key).Lock()
-	log.Printf("[DEBUG] Locked %q", key)
+	log.Printf("[DEBUG] Locked %q after waiting %s", key, time.Since(start))
 }
 
 // Unlock the mutex for the given key. Caller must have called Lock for the same key first
@@ -35,8 +38,9 @@ func (m *MutexKV) Unlock(key string) {
 // for the same key
 func (m *MutexKV) RLock(key string) {
 	log.Printf("[DEBUG] RLocking %q", key)
+	start := time.Now()
 	m.get(key).RLock()
-	log.Printf("[DEBUG] RLocked %q", key)
+	log.Printf("[DEBUG] RLocked %q after waiting %s", key, time.Since(start))
 }
 
 // Releases a read-lock on the mutex for the given key. Caller must have called RLock for the same key first
@@ -46,6 +50,47 @@ func (m *MutexKV) RUnlock(key string) {
 	log.Printf("[DEBUG] RUnlocked %q", key)
 }
 
+// LockKey is a key of a MutexKV to lock. Shared keys are read-locked, so that
+// they're only serialized with exclusive locks of the same key.
+type LockKey struct {
+	Key    string
+	Shared bool
+}
+
+// Locks the mutexes for the given keys, and returns a function unlocking them.
+// Keys are locked in a consistent order, so that callers locking overlapping
+// keys don't deadlock. Keys that are both shared and exclusive are locked
+// exclusively.
+func (m *MutexKV) LockKeys(keys []LockKey) (unlock func()) {
+	shared := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		s, ok := shared[k.Key]
+		shared[k.Key] = k.Shared && (s || !ok)
+	}
+	sorted := make([]string, 0, len(shared))
+	for key := range shared {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	for _, key := range sorted {
+		if shared[key] {
+			m.RLock(key)
+		} else {
+			m.Lock(key)
+		}
+	}
+	return func() {
+		for i := len(sorted) - 1; i >= 0; i-- {
+			if shared[sorted[i]] {
+				m.RUnlock(sorted[i])
+			} else {
+				m.Unlock(sorted[i])
+			}
+		}
+	}
+}
+
 // Returns a mutex for the given key, no guarantee of its lock status
 func (m *MutexKV) get(key string) *sync.RWMutex {
 	m.lock.Lock()
@@ -0,0 +1,107 @@
+package transport
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMutexKV_LockKeys(t *testing.T) {
+	cases := map[string]struct {
+		keys []LockKey
+		// Keys expected to be locked exclusively, or shared with readers
+		exclusive []string
+		shared    []string
+	}{
+		"exclusive keys": {
+			keys:      []LockKey{{Key: "a"}, {Key: "b"}},
+			exclusive: []string{"a", "b"},
+		},
+		"shared keys": {
+			keys:   []LockKey{{Key: "a", Shared: true}, {Key: "b", Shared: true}},
+			shared: []string{"a", "b"},
+		},
+		"shared and exclusive keys": {
+			keys:      []LockKey{{Key: "a", Shared: true}, {Key: "b"}},
+			exclusive: []string{"b"},
+			shared:    []string{"a"},
+		},
+		"key both shared and exclusive is exclusive": {
+			keys:      []LockKey{{Key: "a", Shared: true}, {Key: "a"}, {Key: "a", Shared: true}},
+			exclusive: []string{"a"},
+		},
+		"duplicate shared keys": {
+			keys:   []LockKey{{Key: "a", Shared: true}, {Key: "a", Shared: true}},
+			shared: []string{"a"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			m := NewMutexKV()
+			unlock := m.LockKeys(tc.keys)
+
+			for _, k := range []string{"a", "b", "c"} {
+				rlockable := !contains(tc.exclusive, k)
+				if ok := m.get(k).TryRLock(); ok != rlockable {
+					t.Errorf("expected read-locking %q to be %t, got %t", k, rlockable, ok)
+				} else if ok {
+					m.get(k).RUnlock()
+				}
+				lockable := rlockable && !contains(tc.shared, k)
+				if ok := m.get(k).TryLock(); ok != lockable {
+					t.Errorf("expected locking %q to be %t, got %t", k, lockable, ok)
+				} else if ok {
+					m.get(k).Unlock()
+				}
+			}
+
+			unlock()
+			for _, k := range []string{"a", "b", "c"} {
+				if !m.get(k).TryLock() {
+					t.Errorf("expected %q to be unlocked", k)
+				}
+			}
+		})
+	}
+}
+
+func TestMutexKV_LockKeysOrder(t *testing.T) {
+	m := NewMutexKV()
+	done := make(chan struct{})
+
+	// Callers locking the same keys in a different order would deadlock
+	// without a consistent lock order.
+	var wg sync.WaitGroup
+	for _, keys := range [][]LockKey{
+		{{Key: "a"}, {Key: "b"}},
+		{{Key: "b"}, {Key: "a"}},
+	} {
+		wg.Add(1)
+		go func(keys []LockKey) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				m.LockKeys(keys)()
+			}
+		}(keys)
+	}
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out locking keys")
+	}
+}
+
+func contains(s []string, v string) bool {
+	for _, e := range s {
+		if e == v {
+			return true
+		}
+	}
+	return false
+}
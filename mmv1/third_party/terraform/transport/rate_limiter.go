@@ -0,0 +1,420 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+const (
+	// DefaultRateLimitBurst is the number of requests that can be sent at once
+	// before being rate limited.
+	DefaultRateLimitBurst = 10
+
+	// minRequestsPerSecond is the lowest rate requests are throttled to after
+	// being rate limited by an API.
+	minRequestsPerSecond = 0.1
+
+	// rateLimitRecoveryInterval is the interval at which the rate of requests
+	// throttled after being rate limited by an API increases again.
+	rateLimitRecoveryInterval = time.Second
+)
+
+// RateLimitingConfig contains user configuration for limiting the rate of
+// requests sent to Google APIs.
+type RateLimitingConfig struct {
+	// RequestsPerSecond is the maximum rate of requests to each service and
+	// quota metric, or 0 for no maximum rate.
+	RequestsPerSecond float64
+	Burst             int
+	// Adaptive lowers the rate of requests to a service and quota metric when
+	// the API reports that its quota is exhausted, and raises it again as
+	// requests succeed.
+	Adaptive bool
+}
+
+// RateLimiter limits the rate of requests sent to Google APIs with a token
+// bucket per service host and quota metric, and keeps track of the requests
+// sent through it. It's shared by the retry transports of a provider.
+type RateLimiter struct {
+	sync.Mutex
+
+	*RateLimitingConfig
+	buckets map[string]*tokenBucket
+	// metrics are the quota metrics requests were found to count against,
+	// keyed by host and HTTP method.
+	metrics map[string]string
+
+	rateLimiterStats
+}
+
+// rateLimiterStats counts the requests sent through a rate limiter.
+type rateLimiterStats struct {
+	requests    int
+	retries     int
+	rateLimited int
+	waited      time.Duration
+
+	// slowed counts the requests that waited for the rate limit or to be
+	// retried, and slowest is the longest any of them waited for.
+	slowed  int
+	slowest time.Duration
+}
+
+func (s rateLimiterStats) String() string {
+	return fmt.Sprintf("%d request attempts, %d retries, %d rate limited responses, %s spent waiting, %d requests slowed down by up to %s", s.requests, s.retries, s.rateLimited, s.waited.Round(time.Millisecond), s.slowed, s.slowest.Round(time.Millisecond))
+}
+
+func (s *rateLimiterStats) add(other rateLimiterStats) {
+	s.requests += other.requests
+	s.retries += other.retries
+	s.rateLimited += other.rateLimited
+	s.waited += other.waited
+	s.slowed += other.slowed
+	s.slowest = max(s.slowest, other.slowest)
+}
+
+// tokenBucket limits the rate of the requests counting against a quota
+// metric.
+type tokenBucket struct {
+	// rate is the current rate of requests per second, or 0 for no limit.
+	rate       float64
+	tokens     float64
+	last       time.Time
+	pauseUntil time.Time
+
+	// unlimitedRate is the rate at which a bucket without a maximum rate was
+	// rate limited, after which it's unlimited again.
+	unlimitedRate float64
+	recovered     time.Time
+
+	// sent counts the requests of the current minute, to estimate the rate of
+	// unlimited buckets.
+	sent      int
+	sentSince time.Time
+}
+
+// rateLimiters are the rate limiters of the provider configurations, which
+// are summarized once the provider stops.
+var (
+	rateLimitersMu     sync.Mutex
+	rateLimiters       []*RateLimiter
+	rateLimiterSummary sync.Once
+)
+
+// NewRateLimiter creates a rate limiter.
+func NewRateLimiter(config *RateLimitingConfig) *RateLimiter {
+	l := &RateLimiter{
+		RateLimitingConfig: config,
+		buckets:            make(map[string]*tokenBucket),
+		metrics:            make(map[string]string),
+	}
+
+	rateLimitersMu.Lock()
+	defer rateLimitersMu.Unlock()
+	rateLimiters = append(rateLimiters, l)
+	return l
+}
+
+// LogRateLimiterSummary logs a single summary of the requests sent through
+// the rate limiters of every provider configuration. It's called when the
+// provider stops at the end of a Terraform operation, such as an apply, and
+// only logs the first time it's called.
+func LogRateLimiterSummary() {
+	rateLimiterSummary.Do(func() {
+		rateLimitersMu.Lock()
+		defer rateLimitersMu.Unlock()
+		if len(rateLimiters) == 0 {
+			return
+		}
+		var stats rateLimiterStats
+		for _, l := range rateLimiters {
+			l.Lock()
+			stats.add(l.rateLimiterStats)
+			l.Unlock()
+		}
+		log.Printf("[INFO] Rate Limiter: %s", stats)
+	})
+}
+
+// Summary describes the requests sent through the rate limiter.
+func (l *RateLimiter) Summary() string {
+	l.Lock()
+	defer l.Unlock()
+	return l.rateLimiterStats.String()
+}
+
+// bucketKey returns the key of the bucket of a request, which is the service
+// host and the quota metric requests with the same method were found to count
+// against.
+func (l *RateLimiter) bucketKey(req *http.Request) string {
+	host := req.URL.Host
+	return host + "/" + l.metrics[host+" "+req.Method]
+}
+
+func (l *RateLimiter) bucket(key string, now time.Time) *tokenBucket {
+	b, ok := l.buckets[key]
+	if !ok {
+		burst := float64(l.burst())
+		b = &tokenBucket{rate: l.RequestsPerSecond, tokens: burst, last: now, sentSince: now}
+		l.buckets[key] = b
+	}
+	return b
+}
+
+func (l *RateLimiter) burst() int {
+	if l.Burst <= 0 {
+		return DefaultRateLimitBurst
+	}
+	return l.Burst
+}
+
+// Wait blocks until a request can be sent without exceeding the rate of its
+// bucket, or ctx is done, and returns how long it waited for.
+func (l *RateLimiter) Wait(ctx context.Context, req *http.Request) (time.Duration, error) {
+	l.Lock()
+	now := time.Now()
+	key := l.bucketKey(req)
+	delay := l.bucket(key, now).reserve(now, float64(l.burst()))
+	l.requests++
+	l.waited += delay
+	l.Unlock()
+
+	if delay <= 0 {
+		return 0, nil
+	}
+	log.Printf("[DEBUG] Rate Limiter: Waiting %s before sending request to %s", delay, key)
+	select {
+	case <-ctx.Done():
+		return delay, ctx.Err()
+	case <-time.After(delay):
+		return delay, nil
+	}
+}
+
+// reserve takes a token from the bucket, and returns how long to wait for
+// it.
+func (b *tokenBucket) reserve(now time.Time, burst float64) time.Duration {
+	if now.Sub(b.sentSince) > time.Minute {
+		b.sent, b.sentSince = 0, now
+	}
+	b.sent++
+	var delay time.Duration
+	if now.Before(b.pauseUntil) {
+		delay = b.pauseUntil.Sub(now)
+	}
+	if b.rate <= 0 {
+		return delay
+	}
+
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	b.tokens--
+	if b.tokens < 0 {
+		tokenDelay := time.Duration(-b.tokens / b.rate * float64(time.Second))
+		delay = max(delay, tokenDelay)
+	}
+	return delay
+}
+
+// Observe adjusts the bucket of a request from its response. Rate limited
+// responses lower the rate of the bucket and pause it until the time given by
+// their Retry-After header, while successful ones raise it again.
+func (l *RateLimiter) Observe(req *http.Request, resp *http.Response, err error) {
+	l.Lock()
+	defer l.Unlock()
+
+	now := time.Now()
+	limited, metric := isRateLimitedError(err)
+	if !limited {
+		if l.Adaptive {
+			l.bucket(l.bucketKey(req), now).recover(now, l.RequestsPerSecond)
+		}
+		return
+	}
+
+	l.rateLimited++
+	b := l.bucket(l.bucketKey(req), now)
+	if key := req.URL.Host + " " + req.Method; metric != "" && l.metrics[key] != metric {
+		// Requests found to count against a quota metric get a bucket of
+		// their own, starting from the state of the bucket of the host.
+		l.metrics[key] = metric
+		if _, ok := l.buckets[l.bucketKey(req)]; !ok {
+			copied := *b
+			l.buckets[l.bucketKey(req)] = &copied
+		}
+		b = l.buckets[l.bucketKey(req)]
+	}
+	if retryAfter := retryAfterDuration(resp, err, now); retryAfter > 0 {
+		b.pauseUntil = now.Add(retryAfter)
+	}
+	if !l.Adaptive {
+		return
+	}
+
+	rate := b.rate
+	if rate <= 0 {
+		// Buckets without a rate start from the rate of requests sent until
+		// they were rate limited.
+		rate = float64(b.sent) / math.Max(1, now.Sub(b.sentSince).Seconds())
+		b.unlimitedRate = rate
+	}
+	b.rate = math.Max(minRequestsPerSecond, rate/2)
+	b.tokens = math.Min(b.tokens, 0)
+	b.last = now
+	b.recovered = now
+	log.Printf("[DEBUG] Rate Limiter: Rate limited by %s, lowering rate to %.2f requests per second", l.bucketKey(req), b.rate)
+}
+
+// recover raises the rate of a bucket that was lowered after being rate
+// limited by 10% per recovery interval, up to maxRate.
+func (b *tokenBucket) recover(now time.Time, maxRate float64) {
+	if b.rate <= 0 || now.Sub(b.recovered) < rateLimitRecoveryInterval {
+		return
+	}
+	b.recovered = now
+	b.rate *= 1.1
+	switch {
+	case maxRate > 0 && b.rate >= maxRate:
+		b.rate = maxRate
+	case maxRate <= 0 && b.rate >= b.unlimitedRate:
+		b.rate = 0
+	}
+}
+
+// RecordRetry counts a request that's retried after waiting for its backoff.
+func (l *RateLimiter) RecordRetry(backoff time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+	l.retries++
+	l.waited += backoff
+}
+
+// RecordRequest records how long a request waited in total for the rate limit
+// and to be retried, once it's done.
+func (l *RateLimiter) RecordRequest(waited time.Duration) {
+	if waited <= 0 {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	l.slowed++
+	l.slowest = max(l.slowest, waited)
+}
+
+var quotaMetricRegex = regexp.MustCompile(`Quota exceeded for quota metric '([^']*)'`)
+
+// isRateLimitedError returns whether an error is the API rejecting a request
+// because of a rate quota, and the quota metric the request counts against if
+// known.
+func isRateLimitedError(err error) (bool, string) {
+	var gerr *googleapi.Error
+	if !errors.As(err, &gerr) {
+		return false, ""
+	}
+
+	limited := gerr.Code == 429
+	metric := ""
+	for _, d := range gerr.Details {
+		data, ok := d.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if dType, ok := data["@type"].(string); !ok || !strings.Contains(dType, "ErrorInfo") {
+			continue
+		}
+		if reason, _ := data["reason"].(string); reason == "RATE_LIMIT_EXCEEDED" || reason == "RESOURCE_EXHAUSTED" {
+			limited = true
+		}
+		if metadata, ok := data["metadata"].(map[string]interface{}); ok {
+			if m, ok := metadata["quota_metric"].(string); ok && m != "" {
+				metric = m
+			}
+		}
+	}
+
+	// GCE (and possibly other APIs) return a 403 rather than a 429 on rate
+	// limits, see is403QuotaExceededPerMinuteError.
+	if matches := quotaMetricRegex.FindStringSubmatch(gerr.Body); matches != nil {
+		limited = limited || gerr.Code == 403 && strings.Contains(gerr.Body, "per minute")
+		if metric == "" {
+			metric = matches[1]
+		}
+	}
+	if !limited {
+		return false, ""
+	}
+	return true, metric
+}
+
+// retryAfterDuration returns how long the Retry-After header of a response
+// asks to wait for, as a number of seconds or a date.
+func retryAfterDuration(resp *http.Response, err error, now time.Time) time.Duration {
+	var header http.Header
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) && gerr.Header != nil {
+		header = gerr.Header
+	} else if resp != nil {
+		header = resp.Header
+	}
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return t.Sub(now)
+	}
+	return 0
+}
+
+// ExpandProviderRateLimitingConfig expands the rate limiting configuration,
+// which is nil when the `rate_limiting` block isn't set and requests aren't
+// rate limited.
+func ExpandProviderRateLimitingConfig(v interface{}) (*RateLimitingConfig, error) {
+	if v == nil {
+		return nil, nil
+	}
+	ls := v.([]interface{})
+	if len(ls) == 0 {
+		return nil, nil
+	}
+
+	config := &RateLimitingConfig{
+		Burst: DefaultRateLimitBurst,
+	}
+	if ls[0] == nil {
+		return config, nil
+	}
+
+	cfgV := ls[0].(map[string]interface{})
+	if rps, ok := cfgV["requests_per_second"]; ok {
+		config.RequestsPerSecond = rps.(float64)
+		if config.RequestsPerSecond < 0 {
+			return nil, fmt.Errorf("'requests_per_second' must not be negative, got %v", config.RequestsPerSecond)
+		}
+	}
+	if burst, ok := cfgV["burst"]; ok && burst.(int) != 0 {
+		config.Burst = burst.(int)
+		if config.Burst < 0 {
+			return nil, fmt.Errorf("'burst' must not be negative, got %d", config.Burst)
+		}
+	}
+	if adaptive, ok := cfgV["adaptive"]; ok {
+		config.Adaptive = adaptive.(bool)
+	}
+
+	return config, nil
+}
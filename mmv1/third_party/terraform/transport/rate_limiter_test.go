@@ -0,0 +1,250 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+func testRateLimitedError(metric string, retryAfter string) *googleapi.Error {
+	header := make(http.Header)
+	if retryAfter != "" {
+		header.Set("Retry-After", retryAfter)
+	}
+	return &googleapi.Error{
+		Code:   429,
+		Header: header,
+		Details: []interface{}{
+			map[string]interface{}{
+				"@type":  "type.googleapis.com/google.rpc.ErrorInfo",
+				"reason": "RATE_LIMIT_EXCEEDED",
+				"metadata": map[string]interface{}{
+					"quota_metric": metric,
+				},
+			},
+		},
+	}
+}
+
+func TestIsRateLimitedError(t *testing.T) {
+	cases := map[string]struct {
+		err            error
+		expectLimited  bool
+		expectedMetric string
+	}{
+		"429 with quota metric": {
+			err:            testRateLimitedError("compute.googleapis.com/read_requests", ""),
+			expectLimited:  true,
+			expectedMetric: "compute.googleapis.com/read_requests",
+		},
+		"403 quota exceeded per minute": {
+			err: &googleapi.Error{
+				Code: 403,
+				Body: "Quota exceeded for quota metric 'Queries' and limit 'Queries per minute' of service 'compute.googleapis.com'",
+			},
+			expectLimited:  true,
+			expectedMetric: "Queries",
+		},
+		"resource exhausted": {
+			err: &googleapi.Error{
+				Code: 400,
+				Details: []interface{}{
+					map[string]interface{}{"@type": "type.googleapis.com/google.rpc.ErrorInfo", "reason": "RESOURCE_EXHAUSTED"},
+				},
+			},
+			expectLimited: true,
+		},
+		"not found": {
+			err: &googleapi.Error{Code: 404},
+		},
+		"not a googleapi error": {
+			err: context.DeadlineExceeded,
+		},
+	}
+
+	for tn, tc := range cases {
+		limited, metric := isRateLimitedError(tc.err)
+		if limited != tc.expectLimited || metric != tc.expectedMetric {
+			t.Errorf("%s: expected (%t, %q), got (%t, %q)", tn, tc.expectLimited, tc.expectedMetric, limited, metric)
+		}
+	}
+}
+
+func TestRetryAfterDuration(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		retryAfter string
+		expected   time.Duration
+	}{
+		"seconds": {"5", 5 * time.Second},
+		"date":    {now.Add(time.Minute).Format(http.TimeFormat), time.Minute},
+		"invalid": {"soon", 0},
+		"missing": {"", 0},
+	}
+
+	for tn, tc := range cases {
+		if got := retryAfterDuration(nil, testRateLimitedError("", tc.retryAfter), now); got != tc.expected {
+			t.Errorf("%s: expected %s, got %s", tn, tc.expected, got)
+		}
+	}
+}
+
+func TestRateLimiter_adaptive(t *testing.T) {
+	l := NewRateLimiter(&RateLimitingConfig{RequestsPerSecond: 10, Burst: 1, Adaptive: true})
+	get, _ := http.NewRequest("GET", "https://compute.googleapis.com/compute/v1/projects/p/zones", nil)
+	post, _ := http.NewRequest("POST", "https://compute.googleapis.com/compute/v1/projects/p/zones", nil)
+	metric := "compute.googleapis.com/read_requests"
+
+	l.Observe(get, nil, testRateLimitedError(metric, "2"))
+
+	key := l.bucketKey(get)
+	if key != "compute.googleapis.com/"+metric {
+		t.Fatalf("expected GET requests to use the bucket of their quota metric, got %q", key)
+	}
+	if l.bucketKey(post) != "compute.googleapis.com/" {
+		t.Errorf("expected POST requests to keep using the bucket of the host, got %q", l.bucketKey(post))
+	}
+
+	b := l.buckets[key]
+	if b.rate != 5 {
+		t.Errorf("expected the rate to be halved to 5, got %v", b.rate)
+	}
+	if delay := b.reserve(time.Now(), 1); delay < time.Second {
+		t.Errorf("expected requests to wait for Retry-After, got %s", delay)
+	}
+
+	// The rate recovers up to the configured rate as requests succeed
+	for i := 0; i < 20; i++ {
+		b.recovered = b.recovered.Add(-rateLimitRecoveryInterval)
+		l.Observe(get, nil, nil)
+	}
+	if b.rate != 10 {
+		t.Errorf("expected the rate to recover to 10, got %v", b.rate)
+	}
+
+	if l.rateLimited != 1 {
+		t.Errorf("expected 1 rate limited response, got %d", l.rateLimited)
+	}
+}
+
+func TestRateLimiter_unlimitedRecovers(t *testing.T) {
+	l := NewRateLimiter(&RateLimitingConfig{Adaptive: true})
+	req, _ := http.NewRequest("GET", "https://widgets.googleapis.com/v1/widgets", nil)
+
+	for i := 0; i < 20; i++ {
+		if _, err := l.Wait(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	l.Observe(req, nil, testRateLimitedError("", ""))
+
+	b := l.buckets[l.bucketKey(req)]
+	if b.rate != 10 {
+		t.Errorf("expected the rate to be half of the 20 requests sent in the last second, got %v", b.rate)
+	}
+	for i := 0; i < 10 && b.rate > 0; i++ {
+		b.recovered = b.recovered.Add(-rateLimitRecoveryInterval)
+		l.Observe(req, nil, nil)
+	}
+	if b.rate != 0 {
+		t.Errorf("expected the rate to be unlimited again, got %v", b.rate)
+	}
+}
+
+func TestRetryTransport_RateLimitedRetryAfter(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error": {"code": 429, "message": "Quota exceeded", "status": "RESOURCE_EXHAUSTED"}}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	l := NewRateLimiter(&RateLimitingConfig{Adaptive: true})
+	client := ts.Client()
+	client.Transport = NewTransportWithDefaultRetries(http.DefaultTransport).WithRateLimiter(l)
+
+	start := time.Now()
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkSuccess(t, resp, err)
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("expected the retry to wait for Retry-After, took %s", elapsed)
+	}
+	if l.requests != 2 || l.retries != 1 || l.rateLimited != 1 || l.slowed != 1 || l.slowest < time.Second {
+		t.Errorf("unexpected summary %s", l.Summary())
+	}
+}
+
+func TestLogRateLimiterSummary(t *testing.T) {
+	rateLimitersMu.Lock()
+	rateLimiters = nil
+	rateLimiterSummary = sync.Once{}
+	rateLimitersMu.Unlock()
+
+	var out bytes.Buffer
+	log.SetOutput(&out)
+	defer log.SetOutput(os.Stderr)
+
+	for _, waited := range []time.Duration{time.Second, 3 * time.Second} {
+		l := NewRateLimiter(&RateLimitingConfig{})
+		l.RecordRetry(waited)
+		l.RecordRequest(waited)
+	}
+	LogRateLimiterSummary()
+	LogRateLimiterSummary()
+
+	want := "[INFO] Rate Limiter: 0 request attempts, 2 retries, 0 rate limited responses, 4s spent waiting, 2 requests slowed down by up to 3s\n"
+	if got := out.String(); !strings.HasSuffix(got, want) || strings.Count(got, "Rate Limiter") != 1 {
+		t.Errorf("expected a single summary %q, got %q", want, got)
+	}
+}
+
+func TestExpandProviderRateLimitingConfig(t *testing.T) {
+	cases := map[string]struct {
+		v        interface{}
+		expected *RateLimitingConfig
+	}{
+		"unset": {
+			v:        []interface{}{},
+			expected: nil,
+		},
+		"empty block": {
+			v:        []interface{}{nil},
+			expected: &RateLimitingConfig{Burst: DefaultRateLimitBurst},
+		},
+		"adaptive": {
+			v: []interface{}{map[string]interface{}{
+				"requests_per_second": 5.0,
+				"burst":               0,
+				"adaptive":            true,
+			}},
+			expected: &RateLimitingConfig{RequestsPerSecond: 5, Burst: DefaultRateLimitBurst, Adaptive: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			config, err := ExpandProviderRateLimitingConfig(tc.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (config == nil) != (tc.expected == nil) || config != nil && *config != *tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, config)
+			}
+		})
+	}
+}
@@ -74,6 +74,17 @@ func (t *retryTransport) WithAddedPredicates(predicates ...RetryErrorPredicateFu
 type retryTransport struct {
 	retryPredicates []RetryErrorPredicateFunc
 	internal        http.RoundTripper
+	// rateLimiter limits the rate of requests when set, and is shared by the
+	// copies of the transport.
+	rateLimiter *RateLimiter
+}
+
+// WithRateLimiter returns a shallow copy of the retry transport that limits
+// the rate of requests with the given rate limiter.
+func (t *retryTransport) WithRateLimiter(l *RateLimiter) *retryTransport {
+	copyT := *t
+	copyT.rateLimiter = l
+	return &copyT
 }
 
 // RoundTrip implements the RoundTripper interface method.
@@ -94,6 +105,8 @@ func (t *retryTransport) RoundTrip(req *http.Request) (resp *http.Response, resp
 	}
 
 	attempts := 0
+	// waited is how long the request waited for the rate limit and backoffs
+	var waited time.Duration
 	backoff := time.Millisecond * 500
 	nextBackoff := time.Millisecond * 500
 
@@ -119,12 +132,31 @@ Retry:
 			break Retry
 		}
 
+		if t.rateLimiter != nil {
+			delay, err := t.rateLimiter.Wait(ctx, newRequest)
+			waited += delay
+			if err != nil {
+				log.Printf("[DEBUG] Retry Transport: Stopping retries, context done while rate limited: %v", err)
+				if resp == nil && respErr == nil {
+					respErr = fmt.Errorf("waiting for rate limit: %w", err)
+				}
+				break Retry
+			}
+		}
+
 		log.Printf("[DEBUG] Retry Transport: request attempt %d", attempts)
 		// Do the wrapped Roundtrip. This is one request in the retry loop.
 		resp, respErr = t.internal.RoundTrip(newRequest)
 		attempts++
 
 		retryErr := t.checkForRetryableError(resp, respErr)
+		if t.rateLimiter != nil {
+			var err error
+			if retryErr != nil {
+				err = retryErr.Err
+			}
+			t.rateLimiter.Observe(newRequest, resp, err)
+		}
 		if retryErr == nil {
 			log.Printf("[DEBUG] Retry Transport: Stopping retries, last request was successful")
 			break Retry
@@ -134,13 +166,20 @@ Retry:
 			break Retry
 		}
 
-		log.Printf("[DEBUG] Retry Transport: Waiting %s before trying request again", backoff)
+		// Wait for at least as long as the API asks to with Retry-After
+		wait := max(backoff, retryAfterDuration(resp, retryErr.Err, time.Now()))
+		if t.rateLimiter != nil {
+			t.rateLimiter.RecordRetry(wait)
+		}
+
+		log.Printf("[DEBUG] Retry Transport: Waiting %s before trying request again", wait)
 		select {
 		case <-ctx.Done():
 			log.Printf("[DEBUG] Retry Transport: Stopping retries, context done: %v", ctx.Err())
 			break Retry
-		case <-time.After(backoff):
-			log.Printf("[DEBUG] Retry Transport: Finished waiting %s before next retry", backoff)
+		case <-time.After(wait):
+			log.Printf("[DEBUG] Retry Transport: Finished waiting %s before next retry", wait)
+			waited += wait
 
 			// Fibonnaci backoff - 0.5, 1, 1.5, 2.5, 4, 6.5, 10.5, ...
 			lastBackoff := backoff
@@ -150,6 +189,10 @@ Retry:
 		}
 	}
 	log.Printf("[DEBUG] Retry Transport: Returning after %d attempts", attempts)
+	if t.rateLimiter != nil {
+		t.rateLimiter.RecordRequest(waited)
+	}
+	recordAuditRetries(req.Context(), max(attempts-1, 0))
 	return resp, respErr
 }
 
@@ -27,6 +27,8 @@ type SendRequestOptions struct {
 	Headers              http.Header
 	ErrorRetryPredicates []RetryErrorPredicateFunc
 	ErrorAbortPredicates []RetryErrorPredicateFunc
+	// AuditResource is the resource the request is made for, in the audit log.
+	AuditResource *AuditResource
 }
 
 func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
@@ -78,6 +80,9 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 			}
 
 			req.Header = reqHeaders
+			if opt.AuditResource != nil {
+				req = req.WithContext(ContextWithAuditResource(req.Context(), opt.AuditResource))
+			}
 			res, err = opt.Config.Client.Do(req)
 			if err != nil {
 				return err
@@ -0,0 +1,92 @@
+package breaking_changes
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AllowlistEntry acknowledges an intentional breaking change, such as one
+// made on a major release branch, so that it isn't reported. An entry without
+// a field matches changes to the resource and to any of its fields. Entries
+// stop matching after the day they expire.
+type AllowlistEntry struct {
+	Rule          string `yaml:"rule"`
+	Resource      string `yaml:"resource"`
+	Field         string `yaml:"field,omitempty"`
+	Expires       string `yaml:"expires"`
+	Justification string `yaml:"justification"`
+}
+
+// Allowlist is a list of acknowledged breaking changes.
+type Allowlist []AllowlistEntry
+
+// ReadAllowlist reads and validates an allowlist file.
+func ReadAllowlist(path string) (Allowlist, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading allowlist: %w", err)
+	}
+	return ParseAllowlist(data)
+}
+
+// ParseAllowlist parses and validates the YAML content of an allowlist file.
+func ParseAllowlist(data []byte) (Allowlist, error) {
+	var allowlist Allowlist
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&allowlist); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("error parsing allowlist: %w", err)
+	}
+	for i, entry := range allowlist {
+		if err := entry.validate(); err != nil {
+			return nil, fmt.Errorf("allowlist entry %d: %w", i, err)
+		}
+	}
+	return allowlist, nil
+}
+
+func (e AllowlistEntry) validate() error {
+	if e.Rule == "" {
+		return errors.New("rule is required")
+	}
+	if e.Resource == "" {
+		return errors.New("resource is required")
+	}
+	if e.Justification == "" {
+		return errors.New("justification is required")
+	}
+	if _, err := time.Parse(time.DateOnly, e.Expires); err != nil {
+		return fmt.Errorf("expires must be a date formatted as YYYY-MM-DD: %w", err)
+	}
+	return nil
+}
+
+// Match returns the first entry that acknowledges the breaking change, even
+// if it has expired.
+func (a Allowlist) Match(breakingChange BreakingChange) (AllowlistEntry, bool) {
+	for _, entry := range a {
+		if entry.Rule != breakingChange.RuleName || entry.Resource != breakingChange.Resource {
+			continue
+		}
+		if entry.Field == "" || entry.Field == breakingChange.Field {
+			return entry, true
+		}
+	}
+	return AllowlistEntry{}, false
+}
+
+// Expired returns whether the entry expired before now. Entries expire at the
+// end of their expiry date in UTC.
+func (e AllowlistEntry) Expired(now time.Time) bool {
+	expires, err := time.Parse(time.DateOnly, e.Expires)
+	if err != nil {
+		return true
+	}
+	return !now.Before(expires.AddDate(0, 0, 1))
+}
@@ -0,0 +1,175 @@
+package breaking_changes
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseAllowlist(t *testing.T) {
+	cases := []struct {
+		name          string
+		data          string
+		wantAllowlist Allowlist
+		wantErr       string
+	}{
+		{
+			name: "empty",
+			data: "",
+		},
+		{
+			name: "entries",
+			data: `
+- rule: resource-schema-field-removal-or-rename
+  resource: google_x
+  field: field_a
+  expires: "2025-09-30"
+  justification: Removed in the major release.
+- rule: resource-map-resource-removal-or-rename
+  resource: google_y
+  expires: 2025-09-30
+  justification: Removed in the major release.
+`,
+			wantAllowlist: Allowlist{
+				{
+					Rule:          "resource-schema-field-removal-or-rename",
+					Resource:      "google_x",
+					Field:         "field_a",
+					Expires:       "2025-09-30",
+					Justification: "Removed in the major release.",
+				},
+				{
+					Rule:          "resource-map-resource-removal-or-rename",
+					Resource:      "google_y",
+					Expires:       "2025-09-30",
+					Justification: "Removed in the major release.",
+				},
+			},
+		},
+		{
+			name: "missing justification",
+			data: `
+- rule: resource-map-resource-removal-or-rename
+  resource: google_y
+  expires: 2025-09-30
+`,
+			wantErr: "allowlist entry 0: justification is required",
+		},
+		{
+			name: "invalid expiry",
+			data: `
+- rule: resource-map-resource-removal-or-rename
+  resource: google_y
+  expires: next year
+  justification: Removed in the major release.
+`,
+			wantErr: "allowlist entry 0: expires must be a date",
+		},
+		{
+			name: "unknown key",
+			data: `
+- rule: resource-map-resource-removal-or-rename
+  resources: google_y
+`,
+			wantErr: "field resources not found",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			allowlist, err := ParseAllowlist([]byte(tc.data))
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("ParseAllowlist() error = %v, want error containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseAllowlist() error = %v", err)
+			}
+			if diff := cmp.Diff(tc.wantAllowlist, allowlist); diff != "" {
+				t.Errorf("ParseAllowlist() diff(-want, +got) = %s", diff)
+			}
+		})
+	}
+}
+
+func TestAllowlistMatch(t *testing.T) {
+	allowlist := Allowlist{
+		{Rule: "resource-schema-field-removal-or-rename", Resource: "google_x", Field: "field_a", Expires: "2025-09-30", Justification: "beep"},
+		{Rule: "field-changing-type", Resource: "google_y", Expires: "2025-09-30", Justification: "beep"},
+	}
+	cases := []struct {
+		name           string
+		breakingChange BreakingChange
+		wantMatch      bool
+	}{
+		{
+			name:           "matching field",
+			breakingChange: BreakingChange{RuleName: "resource-schema-field-removal-or-rename", Resource: "google_x", Field: "field_a"},
+			wantMatch:      true,
+		},
+		{
+			name:           "other field",
+			breakingChange: BreakingChange{RuleName: "resource-schema-field-removal-or-rename", Resource: "google_x", Field: "field_b"},
+		},
+		{
+			name:           "other rule",
+			breakingChange: BreakingChange{RuleName: "field-changing-type", Resource: "google_x", Field: "field_a"},
+		},
+		{
+			name:           "any field",
+			breakingChange: BreakingChange{RuleName: "field-changing-type", Resource: "google_y", Field: "field_a"},
+			wantMatch:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if _, match := allowlist.Match(tc.breakingChange); match != tc.wantMatch {
+				t.Errorf("Match() = %t, want %t", match, tc.wantMatch)
+			}
+		})
+	}
+}
+
+func TestAllowlistEntryExpired(t *testing.T) {
+	entry := AllowlistEntry{Expires: "2025-09-30"}
+	cases := map[string]struct {
+		now         time.Time
+		wantExpired bool
+	}{
+		"before expiry date": {
+			now: time.Date(2025, 9, 29, 12, 0, 0, 0, time.UTC),
+		},
+		"on expiry date": {
+			now: time.Date(2025, 9, 30, 23, 59, 0, 0, time.UTC),
+		},
+		"after expiry date": {
+			now:         time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+			wantExpired: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			if got := entry.Expired(tc.now); got != tc.wantExpired {
+				t.Errorf("Expired() = %t, want %t", got, tc.wantExpired)
+			}
+		})
+	}
+}
+
+func TestCheckedInAllowlist(t *testing.T) {
+	if _, err := ReadAllowlist("../breaking-changes-allowlist.yaml"); err != nil {
+		t.Errorf("Failed to read the checked-in allowlist: %v", err)
+	}
+}
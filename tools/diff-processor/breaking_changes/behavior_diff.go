@@ -0,0 +1,127 @@
+package breaking_changes
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+)
+
+// FieldBehaviorDiffRule provides structure for rules regarding
+// changes to how generated fields behave that aren't part of their schema
+type FieldBehaviorDiffRule struct {
+	Identifier  string
+	Remediation string
+	Messages    func(resource, field string, fieldDiff diff.FieldMetadataDiff) []string
+}
+
+// FieldBehaviorDiffRules is a list of FieldBehaviorDiffRule warning about
+// changes that may cause permadiffs or reject previously-valid configs
+var FieldBehaviorDiffRules = []FieldBehaviorDiffRule{
+	FieldRemovingEnumValue,
+	FieldChangingValidationRegex,
+	FieldChangingDiffSuppress,
+	FieldChangingStateFunc,
+	FieldChangingSendEmptyValue,
+}
+
+var FieldRemovingEnumValue = FieldBehaviorDiffRule{
+	Identifier:  "field-removing-enum-value",
+	Remediation: "Keep accepting the enum values, even if the API no longer returns them.",
+	Messages:    FieldRemovingEnumValueMessages,
+}
+
+func FieldRemovingEnumValueMessages(resource, field string, fieldDiff diff.FieldMetadataDiff) []string {
+	// A field that's no longer an enum accepts any value
+	if len(fieldDiff.New.EnumValues) == 0 {
+		return nil
+	}
+	var removed []string
+	for _, value := range fieldDiff.Old.EnumValues {
+		if !slices.Contains(fieldDiff.New.EnumValues, value) {
+			removed = append(removed, fmt.Sprintf("`%s`", value))
+		}
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	tmpl := "Field `%s` no longer accepts the values %s on `%s`"
+	return []string{fmt.Sprintf(tmpl, field, strings.Join(removed, ", "), resource)}
+}
+
+var FieldChangingValidationRegex = FieldBehaviorDiffRule{
+	Identifier:  "field-changing-validation-regex",
+	Remediation: "Only loosen the validation regex, so that it still matches every value it matched before.",
+	Messages:    FieldChangingValidationRegexMessages,
+}
+
+func FieldChangingValidationRegexMessages(resource, field string, fieldDiff diff.FieldMetadataDiff) []string {
+	oldRegex, newRegex := fieldDiff.Old.ValidationRegex, fieldDiff.New.ValidationRegex
+	// Removing validation accepts any value
+	if oldRegex == newRegex || newRegex == "" {
+		return nil
+	}
+	if oldRegex == "" {
+		tmpl := "Field `%s` is now validated against the regex `%s` on `%s`"
+		return []string{fmt.Sprintf(tmpl, field, newRegex, resource)}
+	}
+	tmpl := "Field `%s` validation regex changed from `%s` to `%s` on `%s`"
+	return []string{fmt.Sprintf(tmpl, field, oldRegex, newRegex, resource)}
+}
+
+var FieldChangingDiffSuppress = FieldBehaviorDiffRule{
+	Identifier:  "field-changing-diff-suppress",
+	Remediation: "Make sure the new diff suppression suppresses every diff the old one did.",
+	Messages:    FieldChangingDiffSuppressMessages,
+}
+
+func FieldChangingDiffSuppressMessages(resource, field string, fieldDiff diff.FieldMetadataDiff) []string {
+	oldFunc, newFunc := fieldDiff.Old.DiffSuppressFunc, fieldDiff.New.DiffSuppressFunc
+	// Adding diff suppression is safe, and removing it is a breaking change
+	// caught by FieldRemovingDiffSuppress
+	if oldFunc == "" || newFunc == "" || oldFunc == newFunc {
+		return nil
+	}
+	tmpl := "Field `%s` diff suppression changed from `%s` to `%s` on `%s`"
+	return []string{fmt.Sprintf(tmpl, field, oldFunc, newFunc, resource)}
+}
+
+var FieldChangingStateFunc = FieldBehaviorDiffRule{
+	Identifier:  "field-changing-data-format",
+	Remediation: "Keep storing values in state in the same format, or suppress diffs between the old and new formats.",
+	Messages:    FieldChangingStateFuncMessages,
+}
+
+func FieldChangingStateFuncMessages(resource, field string, fieldDiff diff.FieldMetadataDiff) []string {
+	oldFunc, newFunc := fieldDiff.Old.StateFunc, fieldDiff.New.StateFunc
+	switch {
+	case oldFunc == newFunc:
+		return nil
+	case oldFunc == "":
+		tmpl := "Field `%s` state func `%s` was added on `%s`"
+		return []string{fmt.Sprintf(tmpl, field, newFunc, resource)}
+	case newFunc == "":
+		tmpl := "Field `%s` state func `%s` was removed on `%s`"
+		return []string{fmt.Sprintf(tmpl, field, oldFunc, resource)}
+	}
+	tmpl := "Field `%s` state func changed from `%s` to `%s` on `%s`"
+	return []string{fmt.Sprintf(tmpl, field, oldFunc, newFunc, resource)}
+}
+
+var FieldChangingSendEmptyValue = FieldBehaviorDiffRule{
+	Identifier:  "field-changing-send-empty-value",
+	Remediation: "Make sure the API treats an empty value the same as an unset one, or keep send_empty_value unchanged.",
+	Messages:    FieldChangingSendEmptyValueMessages,
+}
+
+func FieldChangingSendEmptyValueMessages(resource, field string, fieldDiff diff.FieldMetadataDiff) []string {
+	if fieldDiff.Old.SendEmptyValue == fieldDiff.New.SendEmptyValue {
+		return nil
+	}
+	tmpl := "Field `%s` now sends empty values to the API on `%s`"
+	if !fieldDiff.New.SendEmptyValue {
+		tmpl = "Field `%s` no longer sends empty values to the API on `%s`"
+	}
+	return []string{fmt.Sprintf(tmpl, field, resource)}
+}
@@ -0,0 +1,212 @@
+package breaking_changes
+
+import (
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+	"github.com/google/go-cmp/cmp"
+)
+
+type fieldBehaviorTestCase struct {
+	name           string
+	old            diff.FieldMetadata
+	new            diff.FieldMetadata
+	wantViolations int
+}
+
+func TestFieldRemovingEnumValue(t *testing.T) {
+	for _, tc := range fieldRemovingEnumValueTestCases {
+		tc.check(FieldRemovingEnumValue, t)
+	}
+}
+
+var fieldRemovingEnumValueTestCases = []fieldBehaviorTestCase{
+	{
+		name:           "control",
+		old:            diff.FieldMetadata{EnumValues: []string{"A", "B"}},
+		new:            diff.FieldMetadata{EnumValues: []string{"A", "B"}},
+		wantViolations: 0,
+	},
+	{
+		name:           "value added",
+		old:            diff.FieldMetadata{EnumValues: []string{"A", "B"}},
+		new:            diff.FieldMetadata{EnumValues: []string{"A", "B", "C"}},
+		wantViolations: 0,
+	},
+	{
+		name:           "values removed",
+		old:            diff.FieldMetadata{EnumValues: []string{"A", "B", "C"}},
+		new:            diff.FieldMetadata{EnumValues: []string{"B"}},
+		wantViolations: 1,
+	},
+	{
+		name:           "enum becoming a string",
+		old:            diff.FieldMetadata{EnumValues: []string{"A", "B"}},
+		new:            diff.FieldMetadata{},
+		wantViolations: 0,
+	},
+	{
+		name:           "string becoming an enum",
+		old:            diff.FieldMetadata{},
+		new:            diff.FieldMetadata{EnumValues: []string{"A", "B"}},
+		wantViolations: 0,
+	},
+}
+
+func TestFieldChangingValidationRegex(t *testing.T) {
+	for _, tc := range fieldChangingValidationRegexTestCases {
+		tc.check(FieldChangingValidationRegex, t)
+	}
+}
+
+var fieldChangingValidationRegexTestCases = []fieldBehaviorTestCase{
+	{
+		name:           "control",
+		old:            diff.FieldMetadata{ValidationRegex: "^[a-z]+$"},
+		new:            diff.FieldMetadata{ValidationRegex: "^[a-z]+$"},
+		wantViolations: 0,
+	},
+	{
+		name:           "regex added",
+		old:            diff.FieldMetadata{},
+		new:            diff.FieldMetadata{ValidationRegex: "^[a-z]+$"},
+		wantViolations: 1,
+	},
+	{
+		name:           "regex changed",
+		old:            diff.FieldMetadata{ValidationRegex: "^[a-z]+$"},
+		new:            diff.FieldMetadata{ValidationRegex: "^[a-z]{1,63}$"},
+		wantViolations: 1,
+	},
+	{
+		name:           "regex removed",
+		old:            diff.FieldMetadata{ValidationRegex: "^[a-z]+$"},
+		new:            diff.FieldMetadata{},
+		wantViolations: 0,
+	},
+}
+
+func TestFieldChangingDiffSuppress(t *testing.T) {
+	for _, tc := range fieldChangingDiffSuppressTestCases {
+		tc.check(FieldChangingDiffSuppress, t)
+	}
+}
+
+var fieldChangingDiffSuppressTestCases = []fieldBehaviorTestCase{
+	{
+		name:           "control",
+		old:            diff.FieldMetadata{DiffSuppressFunc: "tpgresource.CompareResourceNames"},
+		new:            diff.FieldMetadata{DiffSuppressFunc: "tpgresource.CompareResourceNames"},
+		wantViolations: 0,
+	},
+	{
+		name:           "diff suppress added",
+		old:            diff.FieldMetadata{},
+		new:            diff.FieldMetadata{DiffSuppressFunc: "tpgresource.CompareResourceNames"},
+		wantViolations: 0,
+	},
+	{
+		name:           "diff suppress swapped",
+		old:            diff.FieldMetadata{DiffSuppressFunc: "tpgresource.CompareSelfLinkOrResourceName"},
+		new:            diff.FieldMetadata{DiffSuppressFunc: "tpgresource.CompareResourceNames"},
+		wantViolations: 1,
+	},
+	{
+		name:           "diff suppress removed",
+		old:            diff.FieldMetadata{DiffSuppressFunc: "tpgresource.CompareResourceNames"},
+		new:            diff.FieldMetadata{},
+		wantViolations: 0,
+	},
+}
+
+func TestFieldChangingStateFunc(t *testing.T) {
+	for _, tc := range fieldChangingStateFuncTestCases {
+		tc.check(FieldChangingStateFunc, t)
+	}
+}
+
+var fieldChangingStateFuncTestCases = []fieldBehaviorTestCase{
+	{
+		name:           "control",
+		old:            diff.FieldMetadata{StateFunc: "normalize"},
+		new:            diff.FieldMetadata{StateFunc: "normalize"},
+		wantViolations: 0,
+	},
+	{
+		name:           "state func added",
+		old:            diff.FieldMetadata{},
+		new:            diff.FieldMetadata{StateFunc: "normalize"},
+		wantViolations: 1,
+	},
+	{
+		name:           "state func swapped",
+		old:            diff.FieldMetadata{StateFunc: "normalize"},
+		new:            diff.FieldMetadata{StateFunc: "lowercase"},
+		wantViolations: 1,
+	},
+	{
+		name:           "state func removed",
+		old:            diff.FieldMetadata{StateFunc: "normalize"},
+		new:            diff.FieldMetadata{},
+		wantViolations: 1,
+	},
+}
+
+func TestFieldChangingSendEmptyValue(t *testing.T) {
+	for _, tc := range fieldChangingSendEmptyValueTestCases {
+		tc.check(FieldChangingSendEmptyValue, t)
+	}
+}
+
+var fieldChangingSendEmptyValueTestCases = []fieldBehaviorTestCase{
+	{
+		name:           "control",
+		old:            diff.FieldMetadata{SendEmptyValue: true},
+		new:            diff.FieldMetadata{SendEmptyValue: true},
+		wantViolations: 0,
+	},
+	{
+		name:           "send empty value added",
+		old:            diff.FieldMetadata{},
+		new:            diff.FieldMetadata{SendEmptyValue: true},
+		wantViolations: 1,
+	},
+	{
+		name:           "send empty value removed",
+		old:            diff.FieldMetadata{SendEmptyValue: true},
+		new:            diff.FieldMetadata{},
+		wantViolations: 1,
+	},
+}
+
+func (tc *fieldBehaviorTestCase) check(rule FieldBehaviorDiffRule, t *testing.T) {
+	messages := rule.Messages("resource", "field", diff.FieldMetadataDiff{Old: &tc.old, New: &tc.new})
+	if len(messages) != tc.wantViolations {
+		t.Errorf("Test `%s` failed: expected %d violations, got %d: %v", tc.name, tc.wantViolations, len(messages), messages)
+	}
+}
+
+func TestComputeBehaviorChanges(t *testing.T) {
+	metadataDiff := diff.ComputeMetadataDiff(
+		map[string]diff.ResourceMetadata{
+			"google-x": {Fields: []diff.FieldMetadata{{ApiField: "mode", EnumValues: []string{"A", "B"}}}},
+		},
+		map[string]diff.ResourceMetadata{
+			"google-x": {Fields: []diff.FieldMetadata{{ApiField: "mode", EnumValues: []string{"A"}}}},
+		},
+	)
+	want := []BreakingChange{
+		{
+			Resource:               "google-x",
+			Field:                  "mode",
+			Message:                "Field `mode` no longer accepts the values `B` on `google-x`",
+			DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-removing-enum-value",
+			RuleName:               "field-removing-enum-value",
+			Remediation:            FieldRemovingEnumValue.Remediation,
+			Severity:               "warning",
+		},
+	}
+	if diff := cmp.Diff(want, ComputeBehaviorChanges(metadataDiff)); diff != "" {
+		t.Errorf("ComputeBehaviorChanges() diff(-want, +got) = %s", diff)
+	}
+}
@@ -6,29 +6,96 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
 )
 
+// BreakingChange is a violation of a breaking change rule. Resource is the
+// name of the resource, data source, ephemeral resource or provider function
+// that changed, and Field is set if the change is to one of its fields.
+// RuleName is the identifier of the rule, and Remediation suggests how to
+// avoid the breaking change. Changes with a warning severity may break
+// configurations, depending on how the API behaves.
 type BreakingChange struct {
 	Resource               string
 	Field                  string
 	Message                string
 	DocumentationReference string
 	RuleName               string
+	Remediation            string
+	Severity               string
 }
 
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
 const breakingChangesPath = "breaking-changes/breaking-changes"
 
 func NewBreakingChange(message, identifier string) BreakingChange {
 	return BreakingChange{
 		Message:                message,
 		DocumentationReference: fmt.Sprintf("https://googlecloudplatform.github.io/magic-modules/%s#%s", breakingChangesPath, identifier),
+		RuleName:               identifier,
+		Severity:               SeverityError,
 	}
 }
 
+func newRuleBreakingChange(resource, field, message, identifier, remediation string) BreakingChange {
+	breakingChange := NewBreakingChange(message, identifier)
+	breakingChange.Resource = resource
+	breakingChange.Field = field
+	breakingChange.Remediation = remediation
+	return breakingChange
+}
+
+// RuleSet holds the rules the schema diff of one kind of schema, such as
+// resources or data sources, is checked against.
+type RuleSet struct {
+	ResourceConfigDiffRules []ResourceConfigDiffRule
+	ResourceDiffRules       []ResourceDiffRule
+	FieldDiffRules          []FieldDiffRule
+}
+
+// ResourceRules guard resources against breaking changes.
+var ResourceRules = RuleSet{
+	ResourceConfigDiffRules: ResourceConfigDiffRules,
+	ResourceDiffRules:       ResourceDiffRules,
+	FieldDiffRules:          FieldDiffRules,
+}
+
+// DataSourceRules guard data sources against breaking changes.
+var DataSourceRules = RuleSet{
+	ResourceConfigDiffRules: DataSourceConfigDiffRules,
+	ResourceDiffRules:       DataSourceDiffRules,
+	FieldDiffRules:          DataSourceFieldDiffRules,
+}
+
+// EphemeralResourceRules guard ephemeral resources against breaking changes.
+// Like data sources, they're read on every run and not kept in state.
+var EphemeralResourceRules = RuleSet{
+	ResourceConfigDiffRules: EphemeralResourceConfigDiffRules,
+	ResourceDiffRules:       DataSourceDiffRules,
+	FieldDiffRules:          DataSourceFieldDiffRules,
+}
+
 func ComputeBreakingChanges(schemaDiff diff.SchemaDiff) []BreakingChange {
+	return ComputeBreakingChangesWithRules(schemaDiff, ResourceRules)
+}
+
+// ComputeProviderBreakingChanges returns the breaking changes to all the
+// schemas of a provider.
+func ComputeProviderBreakingChanges(providerDiff diff.ProviderDiff) []BreakingChange {
+	breakingChanges := ComputeBreakingChangesWithRules(providerDiff.Resources, ResourceRules)
+	breakingChanges = append(breakingChanges, ComputeBreakingChangesWithRules(providerDiff.DataSources, DataSourceRules)...)
+	breakingChanges = append(breakingChanges, ComputeBreakingChangesWithRules(providerDiff.EphemeralResources, EphemeralResourceRules)...)
+	breakingChanges = append(breakingChanges, ComputeFunctionBreakingChanges(providerDiff.Functions)...)
+	return append(breakingChanges, ComputeBehaviorChanges(providerDiff.ResourceMetadata)...)
+}
+
+func ComputeBreakingChangesWithRules(schemaDiff diff.SchemaDiff, rules RuleSet) []BreakingChange {
 	var breakingChanges []BreakingChange
 	for resource, resourceDiff := range schemaDiff {
-		for _, rule := range ResourceConfigDiffRules {
+		for _, rule := range rules.ResourceConfigDiffRules {
 			for _, message := range rule.Messages(resource, resourceDiff.ResourceConfig) {
-				breakingChanges = append(breakingChanges, NewBreakingChange(message, rule.Identifier))
+				breakingChanges = append(breakingChanges, newRuleBreakingChange(resource, "", message, rule.Identifier, rule.Remediation))
 			}
 		}
 
@@ -37,17 +104,47 @@ func ComputeBreakingChanges(schemaDiff diff.SchemaDiff) []BreakingChange {
 			continue
 		}
 
-		for _, rule := range ResourceDiffRules {
+		for _, rule := range rules.ResourceDiffRules {
 			for _, message := range rule.Messages(resource, resourceDiff) {
-				breakingChanges = append(breakingChanges, NewBreakingChange(message, rule.Identifier))
+				breakingChanges = append(breakingChanges, newRuleBreakingChange(resource, message.Field, message.Message, rule.Identifier, rule.Remediation))
 			}
 		}
 
 		for field, fieldDiff := range resourceDiff.Fields {
-			for _, rule := range FieldDiffRules {
+			for _, rule := range rules.FieldDiffRules {
 				rd := schemaDiff[resource]
 				for _, message := range rule.Messages(resource, field, fieldDiff, rd) {
-					breakingChanges = append(breakingChanges, NewBreakingChange(message, rule.Identifier))
+					breakingChanges = append(breakingChanges, newRuleBreakingChange(resource, field, message, rule.Identifier, rule.Remediation))
+				}
+			}
+		}
+	}
+	return breakingChanges
+}
+
+func ComputeFunctionBreakingChanges(functionDiff diff.FunctionSchemaDiff) []BreakingChange {
+	var breakingChanges []BreakingChange
+	for function, fd := range functionDiff {
+		for _, rule := range FunctionDiffRules {
+			for _, message := range rule.Messages(function, fd) {
+				breakingChanges = append(breakingChanges, newRuleBreakingChange(function, "", message, rule.Identifier, rule.Remediation))
+			}
+		}
+	}
+	return breakingChanges
+}
+
+// ComputeBehaviorChanges returns warnings about changes to the behavior of
+// generated fields that may break configurations.
+func ComputeBehaviorChanges(metadataDiff diff.MetadataDiff) []BreakingChange {
+	var breakingChanges []BreakingChange
+	for resource, fields := range metadataDiff {
+		for field, fieldDiff := range fields {
+			for _, rule := range FieldBehaviorDiffRules {
+				for _, message := range rule.Messages(resource, field, fieldDiff) {
+					breakingChange := newRuleBreakingChange(resource, field, message, rule.Identifier, rule.Remediation)
+					breakingChange.Severity = SeverityWarning
+					breakingChanges = append(breakingChanges, breakingChange)
 				}
 			}
 		}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -93,8 +94,11 @@ func TestComputeBreakingChanges(t *testing.T) {
 			newResourceMap: map[string]*schema.Resource{},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
 					Message:                "Resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-map-resource-removal-or-rename",
+					RuleName:               "resource-map-resource-removal-or-rename",
+					Severity:               "error",
 				},
 			},
 		},
@@ -117,8 +121,12 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-b",
 					Message:                "Field `field-b` within resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-schema-field-removal-or-rename",
+					RuleName:               "resource-schema-field-removal-or-rename",
+					Severity:               "error",
 				},
 			},
 		},
@@ -142,8 +150,12 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-a",
 					Message:                "Field `field-a` changed from optional to required on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-optional-to-required",
+					RuleName:               "field-optional-to-required",
+					Severity:               "error",
 				},
 			},
 		},
@@ -166,12 +178,20 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-a",
 					Message:                "Field `field-a` changed from optional to required on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-optional-to-required",
+					RuleName:               "field-optional-to-required",
+					Severity:               "error",
 				},
 				{
+					Resource:               "google-x",
+					Field:                  "field-b",
 					Message:                "Field `field-b` within resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-schema-field-removal-or-rename",
+					RuleName:               "resource-schema-field-removal-or-rename",
+					Severity:               "error",
 				},
 			},
 		},
@@ -199,16 +219,27 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-a",
 					Message:                "Field `field-a` changed from optional to required on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-optional-to-required",
+					RuleName:               "field-optional-to-required",
+					Severity:               "error",
 				},
 				{
+					Resource:               "google-x",
+					Field:                  "field-b",
 					Message:                "Field `field-b` within resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-schema-field-removal-or-rename",
+					RuleName:               "resource-schema-field-removal-or-rename",
+					Severity:               "error",
 				},
 				{
+					Resource:               "google-y",
 					Message:                "Resource `google-y` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-map-resource-removal-or-rename",
+					RuleName:               "resource-map-resource-removal-or-rename",
+					Severity:               "error",
 				},
 			},
 		},
@@ -253,8 +284,12 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-a.sub-field-2",
 					Message:                "Field `field-a.sub-field-2` within resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-schema-field-removal-or-rename",
+					RuleName:               "resource-schema-field-removal-or-rename",
+					Severity:               "error",
 				},
 			},
 		},
@@ -298,8 +333,12 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-a.sub-field-1",
 					Message:                "Field `field-a.sub-field-1` MaxItems went from 100 to 25 on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-shrinking-max",
+					RuleName:               "field-shrinking-max",
+					Severity:               "error",
 				},
 			},
 		},
@@ -343,8 +382,12 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-a.sub-field-1",
 					Message:                "Field `field-a.sub-field-1` MaxItems went from 100 to 25 on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-shrinking-max",
+					RuleName:               "field-shrinking-max",
+					Severity:               "error",
 				},
 			},
 		},
@@ -374,8 +417,12 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
+					Resource:               "google-x",
+					Field:                  "field-a",
 					Message:                "Field `field-a` MinItems went from 1 to 4 on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-growing-min",
+					RuleName:               "field-growing-min",
+					Severity:               "error",
 				},
 			},
 		},
@@ -395,7 +442,98 @@ func TestComputeBreakingChanges(t *testing.T) {
 			sort.Slice(violations, func(i, j int) bool {
 				return violations[i].Message < violations[j].Message
 			})
-			if diff := cmp.Diff(tc.wantViolations, violations); diff != "" {
+			if diff := cmp.Diff(tc.wantViolations, violations, cmpopts.IgnoreFields(BreakingChange{}, "Remediation")); diff != "" {
+				t.Errorf("Test `%s` failed: violation diff(-want, +got) = %s", tc.name, diff)
+			}
+		})
+	}
+}
+
+func TestComputeBreakingChangesWithDataSourceRules(t *testing.T) {
+	cases := []struct {
+		name             string
+		oldDataSourceMap map[string]*schema.Resource
+		newDataSourceMap map[string]*schema.Resource
+		wantViolations   []BreakingChange
+	}{
+		{
+			name: "data source removed",
+			oldDataSourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {Description: "beep", Optional: true},
+					},
+				},
+			},
+			newDataSourceMap: map[string]*schema.Resource{},
+			wantViolations: []BreakingChange{
+				{
+					Resource:               "google-x",
+					Message:                "Data source `google-x` was either removed or renamed",
+					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-map-resource-removal-or-rename",
+					RuleName:               "resource-map-resource-removal-or-rename",
+					Severity:               "error",
+				},
+			},
+		},
+		{
+			name: "optional argument to required",
+			oldDataSourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {Description: "beep", Optional: true},
+					},
+				},
+			},
+			newDataSourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {Description: "beep", Required: true},
+					},
+				},
+			},
+			wantViolations: []BreakingChange{
+				{
+					Resource:               "google-x",
+					Field:                  "field-a",
+					Message:                "Field `field-a` changed from optional to required on `google-x`",
+					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-optional-to-required",
+					RuleName:               "field-optional-to-required",
+					Severity:               "error",
+				},
+			},
+		},
+		{
+			name: "default and diff suppression changes",
+			oldDataSourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {Description: "beep", Optional: true, Default: "a"},
+						"field-b": {Description: "beep", Optional: true, DiffSuppressFunc: func(_, _, _ string, _ *schema.ResourceData) bool { return false }},
+					},
+				},
+			},
+			newDataSourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {Description: "beep", Optional: true, Default: "b"},
+						"field-b": {Description: "beep", Optional: true},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			schemaDiff := diff.ComputeSchemaDiff(tc.oldDataSourceMap, tc.newDataSourceMap)
+			violations := ComputeBreakingChangesWithRules(schemaDiff, DataSourceRules)
+			sort.Slice(violations, func(i, j int) bool {
+				return violations[i].Message < violations[j].Message
+			})
+			if diff := cmp.Diff(tc.wantViolations, violations, cmpopts.IgnoreFields(BreakingChange{}, "Remediation")); diff != "" {
 				t.Errorf("Test `%s` failed: violation diff(-want, +got) = %s", tc.name, diff)
 			}
 		})
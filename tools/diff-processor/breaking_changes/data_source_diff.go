@@ -0,0 +1,59 @@
+package breaking_changes
+
+import (
+	"fmt"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+)
+
+// DataSourceConfigDiffRules is a list of ResourceConfigDiffRule
+// guarding against data source breaking changes
+var DataSourceConfigDiffRules = []ResourceConfigDiffRule{DataSourceConfigRemovingADataSource}
+
+// EphemeralResourceConfigDiffRules is a list of ResourceConfigDiffRule
+// guarding against ephemeral resource breaking changes
+var EphemeralResourceConfigDiffRules = []ResourceConfigDiffRule{EphemeralResourceConfigRemovingAnEphemeralResource}
+
+// DataSourceDiffRules is a list of ResourceDiffRule guarding against
+// data source and ephemeral resource breaking changes
+var DataSourceDiffRules = []ResourceDiffRule{RemovingAField, AddingExactlyOneOf}
+
+// DataSourceFieldDiffRules is a list of FieldDiffRule guarding against
+// data source and ephemeral resource breaking changes. They only read, so
+// rules about defaults, plan diffs and recreation don't apply to them.
+var DataSourceFieldDiffRules = []FieldDiffRule{
+	FieldChangingType,
+	FieldNewRequired,
+	FieldBecomingRequired,
+	FieldBecomingComputedOnly,
+	FieldGrowingMin,
+	FieldShrinkingMax,
+}
+
+var DataSourceConfigRemovingADataSource = ResourceConfigDiffRule{
+	Identifier:  "resource-map-resource-removal-or-rename",
+	Remediation: "Keep the data source. To rename it, add the new data source alongside the old one and deprecate the old one, then remove it in a major release.",
+	Messages:    DataSourceConfigRemovingADataSourceMessages,
+}
+
+func DataSourceConfigRemovingADataSourceMessages(dataSource string, resourceConfigDiff diff.ResourceConfigDiff) []string {
+	if resourceConfigDiff.New == nil && resourceConfigDiff.Old != nil {
+		tmpl := "Data source `%s` was either removed or renamed"
+		return []string{fmt.Sprintf(tmpl, dataSource)}
+	}
+	return nil
+}
+
+var EphemeralResourceConfigRemovingAnEphemeralResource = ResourceConfigDiffRule{
+	Identifier:  "resource-map-resource-removal-or-rename",
+	Remediation: "Keep the ephemeral resource. To rename it, add the new ephemeral resource alongside the old one and deprecate the old one, then remove it in a major release.",
+	Messages:    EphemeralResourceConfigRemovingAnEphemeralResourceMessages,
+}
+
+func EphemeralResourceConfigRemovingAnEphemeralResourceMessages(ephemeralResource string, resourceConfigDiff diff.ResourceConfigDiff) []string {
+	if resourceConfigDiff.New == nil && resourceConfigDiff.Old != nil {
+		tmpl := "Ephemeral resource `%s` was either removed or renamed"
+		return []string{fmt.Sprintf(tmpl, ephemeralResource)}
+	}
+	return nil
+}
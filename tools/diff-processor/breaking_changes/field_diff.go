@@ -12,8 +12,9 @@ import (
 // FieldDiffRule provides structure for rules
 // regarding field attribute changes
 type FieldDiffRule struct {
-	Identifier string
-	Messages   func(resource, field string, fieldDiff diff.FieldDiff, resourceDiff diff.ResourceDiffInterface) []string
+	Identifier  string
+	Remediation string
+	Messages    func(resource, field string, fieldDiff diff.FieldDiff, resourceDiff diff.ResourceDiffInterface) []string
 }
 
 // FieldDiffRules is a list of FieldDiffRule
@@ -32,17 +33,25 @@ var FieldDiffRules = []FieldDiffRule{
 }
 
 var FieldChangingType = FieldDiffRule{
-	Identifier: "field-changing-type",
-	Messages:   FieldChangingTypeMessages,
+	Identifier:  "field-changing-type",
+	Remediation: "Keep the field's type. Add a new field with the new type and deprecate the old one instead.",
+	Messages:    FieldChangingTypeMessages,
 }
 
-func FieldChangingTypeMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
+func FieldChangingTypeMessages(resource, field string, fieldDiff diff.FieldDiff, resourceDiff diff.ResourceDiffInterface) []string {
 	// Type change doesn't matter for added / removed fields
 	if fieldDiff.Old == nil || fieldDiff.New == nil {
 		return nil
 	}
+	// Plugin framework schemas don't distinguish integers from floats
+	typeChanged := func(oldType, newType schema.ValueType) bool {
+		if resourceDiff != nil && resourceDiff.IsImplementationChanged() && isNumber(oldType) && isNumber(newType) {
+			return false
+		}
+		return oldType != newType
+	}
 	tmpl := "Field `%s` changed from %s to %s on `%s`"
-	if fieldDiff.Old.Type != fieldDiff.New.Type {
+	if typeChanged(fieldDiff.Old.Type, fieldDiff.New.Type) {
 		oldType := getValueType(fieldDiff.Old.Type)
 		newType := getValueType(fieldDiff.New.Type)
 		return []string{fmt.Sprintf(tmpl, field, oldType, newType, resource)}
@@ -50,7 +59,7 @@ func FieldChangingTypeMessages(resource, field string, fieldDiff diff.FieldDiff,
 
 	oldCasted, _ := fieldDiff.Old.Elem.(*schema.Schema)
 	newCasted, _ := fieldDiff.New.Elem.(*schema.Schema)
-	if oldCasted != nil && newCasted != nil && oldCasted.Type != newCasted.Type {
+	if oldCasted != nil && newCasted != nil && typeChanged(oldCasted.Type, newCasted.Type) {
 		oldType := getValueType(fieldDiff.Old.Type) + "." + getValueType(oldCasted.Type)
 		newType := getValueType(fieldDiff.New.Type) + "." + getValueType(newCasted.Type)
 		return []string{fmt.Sprintf(tmpl, field, oldType, newType, resource)}
@@ -60,8 +69,9 @@ func FieldChangingTypeMessages(resource, field string, fieldDiff diff.FieldDiff,
 }
 
 var FieldBecomingRequired = FieldDiffRule{
-	Identifier: "field-optional-to-required",
-	Messages:   FieldBecomingRequiredMessages,
+	Identifier:  "field-optional-to-required",
+	Remediation: "Keep the field optional, and let the API reject requests that need a value.",
+	Messages:    FieldBecomingRequiredMessages,
 }
 
 func FieldBecomingRequiredMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -78,8 +88,9 @@ func FieldBecomingRequiredMessages(resource, field string, fieldDiff diff.FieldD
 }
 
 var FieldBecomingComputedOnly = FieldDiffRule{
-	Identifier: "field-becoming-computed",
-	Messages:   FieldBecomingComputedOnlyMessages,
+	Identifier:  "field-becoming-computed",
+	Remediation: "Keep the field settable. If setting it no longer has an effect, deprecate it instead.",
+	Messages:    FieldBecomingComputedOnlyMessages,
 }
 
 func FieldBecomingComputedOnlyMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -101,8 +112,9 @@ func FieldBecomingComputedOnlyMessages(resource, field string, fieldDiff diff.Fi
 }
 
 var FieldOptionalComputedToOptional = FieldDiffRule{
-	Identifier: "field-oc-to-c",
-	Messages:   FieldOptionalComputedToOptionalMessages,
+	Identifier:  "field-oc-to-c",
+	Remediation: "Keep the field computed, so that values defaulted by the API don't show a diff.",
+	Messages:    FieldOptionalComputedToOptionalMessages,
 }
 
 func FieldOptionalComputedToOptionalMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -118,8 +130,9 @@ func FieldOptionalComputedToOptionalMessages(resource, field string, fieldDiff d
 }
 
 var FieldDefaultModification = FieldDiffRule{
-	Identifier: "field-changing-default-value",
-	Messages:   FieldDefaultModificationMessages,
+	Identifier:  "field-changing-default-value",
+	Remediation: "Keep the previous default value. Consider updating examples and modules instead.",
+	Messages:    FieldDefaultModificationMessages,
 }
 
 func FieldDefaultModificationMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -153,8 +166,9 @@ func formatDefaultValue(value interface{}) string {
 }
 
 var FieldGrowingMin = FieldDiffRule{
-	Identifier: "field-growing-min",
-	Messages:   FieldGrowingMinMessages,
+	Identifier:  "field-growing-min",
+	Remediation: "Keep the previous minimum number of items.",
+	Messages:    FieldGrowingMinMessages,
 }
 
 func FieldGrowingMinMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -175,8 +189,9 @@ func FieldGrowingMinMessages(resource, field string, fieldDiff diff.FieldDiff, _
 }
 
 var FieldShrinkingMax = FieldDiffRule{
-	Identifier: "field-shrinking-max",
-	Messages:   FieldShrinkingMaxMessages,
+	Identifier:  "field-shrinking-max",
+	Remediation: "Keep the previous maximum number of items.",
+	Messages:    FieldShrinkingMaxMessages,
 }
 
 func FieldShrinkingMaxMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -200,8 +215,9 @@ func FieldShrinkingMaxMessages(resource, field string, fieldDiff diff.FieldDiff,
 }
 
 var FieldRemovingDiffSuppress = FieldDiffRule{
-	Identifier: "field-removing-diff-suppress",
-	Messages:   FieldRemovingDiffSuppressMessages,
+	Identifier:  "field-removing-diff-suppress",
+	Remediation: "Keep the diff suppression, so that existing configurations don't show a diff.",
+	Messages:    FieldRemovingDiffSuppressMessages,
 }
 
 func FieldRemovingDiffSuppressMessages(resource, field string, fieldDiff diff.FieldDiff, _ diff.ResourceDiffInterface) []string {
@@ -218,8 +234,9 @@ func FieldRemovingDiffSuppressMessages(resource, field string, fieldDiff diff.Fi
 }
 
 var FieldNewRequired = FieldDiffRule{
-	Identifier: "no-new-required",
-	Messages:   FieldNewRequiredMessages,
+	Identifier:  "no-new-required",
+	Remediation: "Make the new field optional, or add it within a new optional block.",
+	Messages:    FieldNewRequiredMessages,
 }
 
 func FieldNewRequiredMessages(resource, field string, fieldDiff diff.FieldDiff, resourceDiff diff.ResourceDiffInterface) []string {
@@ -238,8 +255,9 @@ func FieldNewRequiredMessages(resource, field string, fieldDiff diff.FieldDiff,
 }
 
 var FieldNewOptionalFieldWithDefault = FieldDiffRule{
-	Identifier: "no-new-optional-default",
-	Messages:   FieldNewOptionalFieldWithDefaultMessages,
+	Identifier:  "no-new-optional-default",
+	Remediation: "Remove the default value, or only add it if it matches the API's default.",
+	Messages:    FieldNewOptionalFieldWithDefaultMessages,
 }
 
 func FieldNewOptionalFieldWithDefaultMessages(resource, field string, fieldDiff diff.FieldDiff, resourceDiff diff.ResourceDiffInterface) []string {
@@ -288,6 +288,52 @@ var FieldChangingTypeTestCases = []fieldTestCase{
 		},
 		expectedViolation: true,
 	},
+	{
+		name: "field transition int -> float",
+		oldField: &schema.Schema{
+			Type: schema.TypeInt,
+		},
+		newField: &schema.Schema{
+			Type: schema.TypeFloat,
+		},
+		resourceDiff:      existingResourceSchemaDiff,
+		expectedViolation: true,
+	},
+	{
+		name: "field transition int -> float when moved to the plugin framework",
+		oldField: &schema.Schema{
+			Type: schema.TypeInt,
+		},
+		newField: &schema.Schema{
+			Type: schema.TypeFloat,
+		},
+		resourceDiff:      MockSchemaDiff{implementationChanged: true},
+		expectedViolation: false,
+	},
+	{
+		name: "field sub-element transition int -> float when moved to the plugin framework",
+		oldField: &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Schema{Type: schema.TypeInt},
+		},
+		newField: &schema.Schema{
+			Type: schema.TypeList,
+			Elem: &schema.Schema{Type: schema.TypeFloat},
+		},
+		resourceDiff:      MockSchemaDiff{implementationChanged: true},
+		expectedViolation: false,
+	},
+	{
+		name: "field transition string -> float when moved to the plugin framework",
+		oldField: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		newField: &schema.Schema{
+			Type: schema.TypeFloat,
+		},
+		resourceDiff:      MockSchemaDiff{implementationChanged: true},
+		expectedViolation: true,
+	},
 	{
 		name: "field transition sub-element type ",
 		oldField: &schema.Schema{
@@ -0,0 +1,109 @@
+package breaking_changes
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+)
+
+// FunctionDiffRule provides structure for rules
+// regarding provider-defined function changes
+type FunctionDiffRule struct {
+	Identifier  string
+	Remediation string
+	Messages    func(function string, functionDiff diff.FunctionDiff) []string
+}
+
+// FunctionDiffRules is a list of FunctionDiffRule
+// guarding against provider breaking changes
+var FunctionDiffRules = []FunctionDiffRule{
+	FunctionRemoval,
+	FunctionChangingParameters,
+	FunctionChangingReturnType,
+}
+
+var FunctionRemoval = FunctionDiffRule{
+	Identifier:  "function-removal-or-rename",
+	Remediation: "Keep the function. To rename it, add the function under its new name and deprecate the old one, then remove it in a major release.",
+	Messages:    FunctionRemovalMessages,
+}
+
+func FunctionRemovalMessages(function string, functionDiff diff.FunctionDiff) []string {
+	if functionDiff.Old != nil && functionDiff.New == nil {
+		tmpl := "Function `%s` was either removed or renamed"
+		return []string{fmt.Sprintf(tmpl, function)}
+	}
+	return nil
+}
+
+var FunctionChangingParameters = FunctionDiffRule{
+	Identifier:  "function-changing-parameters",
+	Remediation: "Keep the function's parameters. Add a new function with the new parameters instead.",
+	Messages:    FunctionChangingParametersMessages,
+}
+
+func FunctionChangingParametersMessages(function string, functionDiff diff.FunctionDiff) []string {
+	// Ignore for added / removed functions
+	if functionDiff.Old == nil || functionDiff.New == nil {
+		return nil
+	}
+
+	oldParameters, newParameters := functionDiff.Old.Parameters, functionDiff.New.Parameters
+	if len(oldParameters) != len(newParameters) {
+		tmpl := "Function `%s` number of parameters changed from %d to %d"
+		return []string{fmt.Sprintf(tmpl, function, len(oldParameters), len(newParameters))}
+	}
+
+	var messages []string
+	for i := range oldParameters {
+		messages = append(messages, functionParameterMessages(function, "parameter", oldParameters[i], newParameters[i])...)
+	}
+
+	oldVariadic, newVariadic := functionDiff.Old.VariadicParameter, functionDiff.New.VariadicParameter
+	if oldVariadic != nil && newVariadic == nil {
+		tmpl := "Function `%s` variadic parameter `%s` was removed"
+		messages = append(messages, fmt.Sprintf(tmpl, function, oldVariadic.Name))
+	} else if oldVariadic != nil {
+		messages = append(messages, functionParameterMessages(function, "variadic parameter", oldVariadic, newVariadic)...)
+	}
+	return messages
+}
+
+// Parameters are passed by position, so renaming one doesn't break callers.
+func functionParameterMessages(function, kind string, oldParameter, newParameter *tfprotov5.FunctionParameter) []string {
+	var messages []string
+	if !oldParameter.Type.Equal(newParameter.Type) {
+		tmpl := "Function `%s` %s `%s` changed from %s to %s"
+		messages = append(messages, fmt.Sprintf(tmpl, function, kind, oldParameter.Name, oldParameter.Type, newParameter.Type))
+	}
+	if oldParameter.AllowNullValue && !newParameter.AllowNullValue {
+		tmpl := "Function `%s` %s `%s` no longer accepts null values"
+		messages = append(messages, fmt.Sprintf(tmpl, function, kind, oldParameter.Name))
+	}
+	if oldParameter.AllowUnknownValues && !newParameter.AllowUnknownValues {
+		tmpl := "Function `%s` %s `%s` no longer accepts unknown values"
+		messages = append(messages, fmt.Sprintf(tmpl, function, kind, oldParameter.Name))
+	}
+	return messages
+}
+
+var FunctionChangingReturnType = FunctionDiffRule{
+	Identifier:  "function-changing-return-type",
+	Remediation: "Keep the function's return type. Add a new function with the new return type instead.",
+	Messages:    FunctionChangingReturnTypeMessages,
+}
+
+func FunctionChangingReturnTypeMessages(function string, functionDiff diff.FunctionDiff) []string {
+	// Ignore for added / removed functions
+	if functionDiff.Old == nil || functionDiff.New == nil {
+		return nil
+	}
+	oldType, newType := diff.FunctionReturnType(functionDiff.Old), diff.FunctionReturnType(functionDiff.New)
+	if oldType == nil || newType == nil || oldType.Equal(newType) {
+		return nil
+	}
+	tmpl := "Function `%s` return type changed from %s to %s"
+	return []string{fmt.Sprintf(tmpl, function, oldType, newType)}
+}
@@ -0,0 +1,181 @@
+package breaking_changes
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+)
+
+type functionTestCase struct {
+	name           string
+	old            *tfprotov5.Function
+	new            *tfprotov5.Function
+	wantViolations int
+}
+
+func TestFunctionRule_Removal(t *testing.T) {
+	for _, tc := range functionRemovalTestCases {
+		tc.check(FunctionRemoval, t)
+	}
+}
+
+var functionRemovalTestCases = []functionTestCase{
+	{
+		name:           "control",
+		old:            &tfprotov5.Function{},
+		new:            &tfprotov5.Function{},
+		wantViolations: 0,
+	},
+	{
+		name:           "function added",
+		old:            nil,
+		new:            &tfprotov5.Function{},
+		wantViolations: 0,
+	},
+	{
+		name:           "function removed",
+		old:            &tfprotov5.Function{},
+		new:            nil,
+		wantViolations: 1,
+	},
+}
+
+func TestFunctionRule_ChangingParameters(t *testing.T) {
+	for _, tc := range functionChangingParametersTestCases {
+		tc.check(FunctionChangingParameters, t)
+	}
+}
+
+var functionChangingParametersTestCases = []functionTestCase{
+	{
+		name: "control",
+		old: &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.String}},
+		},
+		new: &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.String}},
+		},
+		wantViolations: 0,
+	},
+	{
+		name: "parameter renamed",
+		old: &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.String}},
+		},
+		new: &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "name", Type: tftypes.String}},
+		},
+		wantViolations: 0,
+	},
+	{
+		name: "parameter added",
+		old: &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.String}},
+		},
+		new: &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.String}, {Name: "zone", Type: tftypes.String}},
+		},
+		wantViolations: 1,
+	},
+	{
+		name: "parameter type changed",
+		old: &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.String}},
+		},
+		new: &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.List{ElementType: tftypes.String}}},
+		},
+		wantViolations: 1,
+	},
+	{
+		name: "parameter no longer accepts null and unknown values",
+		old: &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.String, AllowNullValue: true, AllowUnknownValues: true}},
+		},
+		new: &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.String}},
+		},
+		wantViolations: 2,
+	},
+	{
+		name: "parameter accepts null values",
+		old: &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.String}},
+		},
+		new: &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.String, AllowNullValue: true}},
+		},
+		wantViolations: 0,
+	},
+	{
+		name: "variadic parameter added",
+		old:  &tfprotov5.Function{},
+		new: &tfprotov5.Function{
+			VariadicParameter: &tfprotov5.FunctionParameter{Name: "ids", Type: tftypes.String},
+		},
+		wantViolations: 0,
+	},
+	{
+		name: "variadic parameter removed",
+		old: &tfprotov5.Function{
+			VariadicParameter: &tfprotov5.FunctionParameter{Name: "ids", Type: tftypes.String},
+		},
+		new:            &tfprotov5.Function{},
+		wantViolations: 1,
+	},
+	{
+		name: "variadic parameter type changed",
+		old: &tfprotov5.Function{
+			VariadicParameter: &tfprotov5.FunctionParameter{Name: "ids", Type: tftypes.String},
+		},
+		new: &tfprotov5.Function{
+			VariadicParameter: &tfprotov5.FunctionParameter{Name: "ids", Type: tftypes.Number},
+		},
+		wantViolations: 1,
+	},
+	{
+		name: "function removed",
+		old: &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.String}},
+		},
+		new:            nil,
+		wantViolations: 0,
+	},
+}
+
+func TestFunctionRule_ChangingReturnType(t *testing.T) {
+	for _, tc := range functionChangingReturnTypeTestCases {
+		tc.check(FunctionChangingReturnType, t)
+	}
+}
+
+var functionChangingReturnTypeTestCases = []functionTestCase{
+	{
+		name:           "control",
+		old:            &tfprotov5.Function{Return: &tfprotov5.FunctionReturn{Type: tftypes.String}},
+		new:            &tfprotov5.Function{Return: &tfprotov5.FunctionReturn{Type: tftypes.String}},
+		wantViolations: 0,
+	},
+	{
+		name:           "return type changed",
+		old:            &tfprotov5.Function{Return: &tfprotov5.FunctionReturn{Type: tftypes.String}},
+		new:            &tfprotov5.Function{Return: &tfprotov5.FunctionReturn{Type: tftypes.Bool}},
+		wantViolations: 1,
+	},
+	{
+		name:           "function added",
+		old:            nil,
+		new:            &tfprotov5.Function{Return: &tfprotov5.FunctionReturn{Type: tftypes.String}},
+		wantViolations: 0,
+	},
+}
+
+func (tc *functionTestCase) check(rule FunctionDiffRule, t *testing.T) {
+	messages := rule.Messages("function", diff.FunctionDiff{Old: tc.old, New: tc.new})
+	if len(messages) != tc.wantViolations {
+		t.Errorf("Test `%s` failed: expected %d violations, got %d: %v", tc.name, tc.wantViolations, len(messages), messages)
+	}
+}
@@ -2,8 +2,9 @@ package breaking_changes
 
 // MockSchemaDiff implements the diff.SchemaDiff interface for testing
 type MockSchemaDiff struct {
-	isNewResource        bool
-	fieldsInNewStructure map[string]bool // Maps field names to whether they're in a new structure
+	isNewResource         bool
+	fieldsInNewStructure  map[string]bool // Maps field names to whether they're in a new structure
+	implementationChanged bool
 }
 
 func (sd MockSchemaDiff) IsNewResource() bool {
@@ -14,6 +15,10 @@ func (sd MockSchemaDiff) IsFieldInNewNestedStructure(field string) bool {
 	return sd.fieldsInNewStructure[field]
 }
 
+func (sd MockSchemaDiff) IsImplementationChanged() bool {
+	return sd.implementationChanged
+}
+
 // Create mock schema diffs for testing
 var (
 	// Mock for existing resource (not new, field not in new structure)
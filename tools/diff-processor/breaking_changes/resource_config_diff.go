@@ -2,6 +2,9 @@ package breaking_changes
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
 )
@@ -9,17 +12,24 @@ import (
 // ResourceConfigDiffRule provides
 // structure for rules regarding resource config changes
 type ResourceConfigDiffRule struct {
-	Identifier string
-	Messages   func(resource string, resourceConfigDiff diff.ResourceConfigDiff) []string
+	Identifier  string
+	Remediation string
+	Messages    func(resource string, resourceConfigDiff diff.ResourceConfigDiff) []string
 }
 
 // ResourceConfigDiffRules is a list of ResourceConfigDiffRule
 // guarding against provider breaking changes
-var ResourceConfigDiffRules = []ResourceConfigDiffRule{ResourceConfigRemovingAResource}
+var ResourceConfigDiffRules = []ResourceConfigDiffRule{
+	ResourceConfigRemovingAResource,
+	ResourceConfigRemovingImport,
+	ResourceConfigDecreasingTimeout,
+	ResourceConfigRemovingStateUpgrader,
+}
 
 var ResourceConfigRemovingAResource = ResourceConfigDiffRule{
-	Identifier: "resource-map-resource-removal-or-rename",
-	Messages:   ResourceConfigRemovingAResourceMessages,
+	Identifier:  "resource-map-resource-removal-or-rename",
+	Remediation: "Keep the resource. To rename it, add the new resource alongside the old one and deprecate the old one, then remove it in a major release.",
+	Messages:    ResourceConfigRemovingAResourceMessages,
 }
 
 func ResourceConfigRemovingAResourceMessages(resource string, resourceConfigDiff diff.ResourceConfigDiff) []string {
@@ -29,3 +39,98 @@ func ResourceConfigRemovingAResourceMessages(resource string, resourceConfigDiff
 	}
 	return nil
 }
+
+var ResourceConfigRemovingImport = ResourceConfigDiffRule{
+	Identifier:  "resource-config-import-removal",
+	Remediation: "Keep import support for the resource.",
+	Messages:    ResourceConfigRemovingImportMessages,
+}
+
+func ResourceConfigRemovingImportMessages(resource string, resourceConfigDiff diff.ResourceConfigDiff) []string {
+	if resourceConfigDiff.Old == nil || resourceConfigDiff.New == nil {
+		return nil
+	}
+	if resourceConfigDiff.Old.Importer != nil && resourceConfigDiff.New.Importer == nil {
+		tmpl := "Resource `%s` no longer supports import"
+		return []string{fmt.Sprintf(tmpl, resource)}
+	}
+	return nil
+}
+
+var ResourceConfigDecreasingTimeout = ResourceConfigDiffRule{
+	Identifier:  "resource-config-timeout-decrease",
+	Remediation: "Keep the previous default timeout, or increase it.",
+	Messages:    ResourceConfigDecreasingTimeoutMessages,
+}
+
+func ResourceConfigDecreasingTimeoutMessages(resource string, resourceConfigDiff diff.ResourceConfigDiff) []string {
+	if resourceConfigDiff.Old == nil || resourceConfigDiff.New == nil || resourceConfigDiff.Old.Timeouts == nil {
+		return nil
+	}
+	// The default timeouts of plugin framework resources aren't known
+	if resourceConfigDiff.ImplementationChanged {
+		return nil
+	}
+	oldTimeouts := timeoutsByOperation(resourceConfigDiff.Old.Timeouts)
+	newTimeouts := timeoutsByOperation(resourceConfigDiff.New.Timeouts)
+
+	var messages []string
+	for _, op := range timeoutOperations {
+		oldTimeout, newTimeout := oldTimeouts[op], newTimeouts[op]
+		if oldTimeout == nil {
+			continue
+		}
+		if newTimeout == nil {
+			tmpl := "Resource `%s` no longer supports the `%s` timeout"
+			messages = append(messages, fmt.Sprintf(tmpl, resource, op))
+		} else if *newTimeout < *oldTimeout {
+			tmpl := "Resource `%s` default `%s` timeout decreased from %s to %s"
+			messages = append(messages, fmt.Sprintf(tmpl, resource, op, *oldTimeout, *newTimeout))
+		}
+	}
+	return messages
+}
+
+var timeoutOperations = []string{"create", "read", "update", "delete", "default"}
+
+func timeoutsByOperation(timeouts *schema.ResourceTimeout) map[string]*time.Duration {
+	if timeouts == nil {
+		return nil
+	}
+	return map[string]*time.Duration{
+		"create":  timeouts.Create,
+		"read":    timeouts.Read,
+		"update":  timeouts.Update,
+		"delete":  timeouts.Delete,
+		"default": timeouts.Default,
+	}
+}
+
+var ResourceConfigRemovingStateUpgrader = ResourceConfigDiffRule{
+	Identifier:  "resource-config-state-upgrader-removal",
+	Remediation: "Keep the state upgrader, so that state written by older provider versions can still be upgraded.",
+	Messages:    ResourceConfigRemovingStateUpgraderMessages,
+}
+
+func ResourceConfigRemovingStateUpgraderMessages(resource string, resourceConfigDiff diff.ResourceConfigDiff) []string {
+	if resourceConfigDiff.Old == nil || resourceConfigDiff.New == nil {
+		return nil
+	}
+	// Plugin framework resources upgrade state without SDK state upgraders
+	if resourceConfigDiff.ImplementationChanged {
+		return nil
+	}
+	newVersions := make(map[int]bool)
+	for _, upgrader := range resourceConfigDiff.New.StateUpgraders {
+		newVersions[upgrader.Version] = true
+	}
+
+	var messages []string
+	for _, upgrader := range resourceConfigDiff.Old.StateUpgraders {
+		if !newVersions[upgrader.Version] {
+			tmpl := "Resource `%s` state upgrader from schema version %d was removed"
+			messages = append(messages, fmt.Sprintf(tmpl, resource, upgrader.Version))
+		}
+	}
+	return messages
+}
@@ -2,6 +2,7 @@ package breaking_changes
 
 import (
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 
@@ -9,15 +10,16 @@ import (
 )
 
 type resourceInventoryTestCase struct {
-	name           string
-	old            *schema.Resource
-	new            *schema.Resource
-	wantViolations bool
+	name                  string
+	old                   *schema.Resource
+	new                   *schema.Resource
+	implementationChanged bool
+	wantViolations        bool
 }
 
 func TestResourceInventoryRule_RemovingAResource(t *testing.T) {
 	for _, tc := range resourceConfigRemovingAResourceTestCases {
-		got := ResourceConfigRemovingAResource.Messages("resource", diff.ResourceConfigDiff{Old: tc.old, New: tc.new})
+		got := ResourceConfigRemovingAResource.Messages("resource", diff.ResourceConfigDiff{Old: tc.old, New: tc.new, ImplementationChanged: tc.implementationChanged})
 		gotViolations := len(got) > 0
 		if tc.wantViolations != gotViolations {
 			t.Errorf("ResourceConfigRemovingAResource.Messages(%v) violations not expected. Got %v, want %v", tc.name, gotViolations, tc.wantViolations)
@@ -45,3 +47,151 @@ var resourceConfigRemovingAResourceTestCases = []resourceInventoryTestCase{
 		wantViolations: true,
 	},
 }
+
+func TestResourceConfigRule_RemovingImport(t *testing.T) {
+	for _, tc := range resourceConfigRemovingImportTestCases {
+		got := ResourceConfigRemovingImport.Messages("resource", diff.ResourceConfigDiff{Old: tc.old, New: tc.new, ImplementationChanged: tc.implementationChanged})
+		gotViolations := len(got) > 0
+		if tc.wantViolations != gotViolations {
+			t.Errorf("ResourceConfigRemovingImport.Messages(%v) violations not expected. Got %v, want %v", tc.name, gotViolations, tc.wantViolations)
+		}
+	}
+}
+
+var resourceConfigRemovingImportTestCases = []resourceInventoryTestCase{
+	{
+		name:           "control",
+		old:            &schema.Resource{Importer: &schema.ResourceImporter{}},
+		new:            &schema.Resource{Importer: &schema.ResourceImporter{}},
+		wantViolations: false,
+	},
+	{
+		name:           "import added",
+		old:            &schema.Resource{},
+		new:            &schema.Resource{Importer: &schema.ResourceImporter{}},
+		wantViolations: false,
+	},
+	{
+		name:           "import removed",
+		old:            &schema.Resource{Importer: &schema.ResourceImporter{}},
+		new:            &schema.Resource{},
+		wantViolations: true,
+	},
+	{
+		name:           "resource removed",
+		old:            &schema.Resource{Importer: &schema.ResourceImporter{}},
+		new:            nil,
+		wantViolations: false,
+	},
+}
+
+func TestResourceConfigRule_DecreasingTimeout(t *testing.T) {
+	for _, tc := range resourceConfigDecreasingTimeoutTestCases {
+		got := ResourceConfigDecreasingTimeout.Messages("resource", diff.ResourceConfigDiff{Old: tc.old, New: tc.new, ImplementationChanged: tc.implementationChanged})
+		gotViolations := len(got) > 0
+		if tc.wantViolations != gotViolations {
+			t.Errorf("ResourceConfigDecreasingTimeout.Messages(%v) violations not expected. Got %v, want %v", tc.name, gotViolations, tc.wantViolations)
+		}
+	}
+}
+
+var (
+	tenMinutes    = 10 * time.Minute
+	twentyMinutes = 20 * time.Minute
+)
+
+var resourceConfigDecreasingTimeoutTestCases = []resourceInventoryTestCase{
+	{
+		name:           "control",
+		old:            &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &twentyMinutes}},
+		new:            &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &twentyMinutes}},
+		wantViolations: false,
+	},
+	{
+		name:           "timeout increased",
+		old:            &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &tenMinutes}},
+		new:            &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &twentyMinutes}},
+		wantViolations: false,
+	},
+	{
+		name:           "timeout added",
+		old:            &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &twentyMinutes}},
+		new:            &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &twentyMinutes, Delete: &twentyMinutes}},
+		wantViolations: false,
+	},
+	{
+		name:           "timeouts added",
+		old:            &schema.Resource{},
+		new:            &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &twentyMinutes}},
+		wantViolations: false,
+	},
+	{
+		name:           "timeout decreased",
+		old:            &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &twentyMinutes}},
+		new:            &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &tenMinutes}},
+		wantViolations: true,
+	},
+	{
+		name:           "timeout removed",
+		old:            &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &twentyMinutes, Delete: &twentyMinutes}},
+		new:            &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &twentyMinutes}},
+		wantViolations: true,
+	},
+	{
+		name:           "timeouts removed",
+		old:            &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &twentyMinutes}},
+		new:            &schema.Resource{},
+		wantViolations: true,
+	},
+	{
+		name:                  "resource moved to the plugin framework",
+		old:                   &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &twentyMinutes}},
+		new:                   &schema.Resource{},
+		implementationChanged: true,
+		wantViolations:        false,
+	},
+}
+
+func TestResourceConfigRule_RemovingStateUpgrader(t *testing.T) {
+	for _, tc := range resourceConfigRemovingStateUpgraderTestCases {
+		got := ResourceConfigRemovingStateUpgrader.Messages("resource", diff.ResourceConfigDiff{Old: tc.old, New: tc.new, ImplementationChanged: tc.implementationChanged})
+		gotViolations := len(got) > 0
+		if tc.wantViolations != gotViolations {
+			t.Errorf("ResourceConfigRemovingStateUpgrader.Messages(%v) violations not expected. Got %v, want %v", tc.name, gotViolations, tc.wantViolations)
+		}
+	}
+}
+
+var resourceConfigRemovingStateUpgraderTestCases = []resourceInventoryTestCase{
+	{
+		name:           "control",
+		old:            &schema.Resource{SchemaVersion: 1, StateUpgraders: []schema.StateUpgrader{{Version: 0}}},
+		new:            &schema.Resource{SchemaVersion: 1, StateUpgraders: []schema.StateUpgrader{{Version: 0}}},
+		wantViolations: false,
+	},
+	{
+		name:           "state upgrader added",
+		old:            &schema.Resource{SchemaVersion: 1, StateUpgraders: []schema.StateUpgrader{{Version: 0}}},
+		new:            &schema.Resource{SchemaVersion: 2, StateUpgraders: []schema.StateUpgrader{{Version: 0}, {Version: 1}}},
+		wantViolations: false,
+	},
+	{
+		name:           "state upgrader removed",
+		old:            &schema.Resource{SchemaVersion: 2, StateUpgraders: []schema.StateUpgrader{{Version: 0}, {Version: 1}}},
+		new:            &schema.Resource{SchemaVersion: 2, StateUpgraders: []schema.StateUpgrader{{Version: 1}}},
+		wantViolations: true,
+	},
+	{
+		name:           "resource removed",
+		old:            &schema.Resource{SchemaVersion: 1, StateUpgraders: []schema.StateUpgrader{{Version: 0}}},
+		new:            nil,
+		wantViolations: false,
+	},
+	{
+		name:                  "resource moved to the plugin framework",
+		old:                   &schema.Resource{SchemaVersion: 1, StateUpgraders: []schema.StateUpgrader{{Version: 0}}},
+		new:                   &schema.Resource{SchemaVersion: 1},
+		implementationChanged: true,
+		wantViolations:        false,
+	},
+}
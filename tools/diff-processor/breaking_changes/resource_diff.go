@@ -9,20 +9,28 @@ import (
 
 // ResourceDiffRule is a rule that operates on an entire ResourceDiff
 type ResourceDiffRule struct {
-	Identifier string
-	Messages   func(resource string, resourceDiff diff.ResourceDiff) []string
+	Identifier  string
+	Remediation string
+	Messages    func(resource string, resourceDiff diff.ResourceDiff) []FieldMessage
+}
+
+// FieldMessage is a message about a breaking change to a single field
+type FieldMessage struct {
+	Field   string
+	Message string
 }
 
 // ResourceDiffRules is a list of all ResourceDiff rules
 var ResourceDiffRules = []ResourceDiffRule{RemovingAField, AddingExactlyOneOf}
 
 var RemovingAField = ResourceDiffRule{
-	Identifier: "resource-schema-field-removal-or-rename",
-	Messages:   RemovingAFieldMessages,
+	Identifier:  "resource-schema-field-removal-or-rename",
+	Remediation: "Keep the field. To rename it, add the new field alongside the old one and deprecate the old one, then remove it in a major release.",
+	Messages:    RemovingAFieldMessages,
 }
 
 // TODO: Make field removal a FieldDiffRule b/300124253
-func RemovingAFieldMessages(resource string, resourceDiff diff.ResourceDiff) []string {
+func RemovingAFieldMessages(resource string, resourceDiff diff.ResourceDiff) []FieldMessage {
 	fieldsRemoved := []string{}
 	for field, fieldDiff := range resourceDiff.Fields {
 		if fieldDiff.Old != nil && fieldDiff.New == nil {
@@ -31,20 +39,22 @@ func RemovingAFieldMessages(resource string, resourceDiff diff.ResourceDiff) []s
 	}
 
 	tmpl := "Field `%s` within resource `%s` was either removed or renamed"
-	var messages []string
+	var messages []FieldMessage
 	for _, field := range fieldsRemoved {
-		messages = append(messages, fmt.Sprintf(tmpl, field, resource))
+		messages = append(messages, FieldMessage{Field: field, Message: fmt.Sprintf(tmpl, field, resource)})
 	}
 	return messages
 }
 
 var AddingExactlyOneOf = ResourceDiffRule{
-	Identifier: "resource-schema-field-addition-of-exactly-one-of",
-	Messages:   AddingExactlyOneOfMessages,
+	Identifier:  "resource-schema-field-addition-of-exactly-one-of",
+	Remediation: "Only add new fields to the ExactlyOneOf constraint, or leave it out and let the API reject invalid combinations.",
+	Messages:    AddingExactlyOneOfMessages,
 }
 
-func AddingExactlyOneOfMessages(resource string, resourceDiff diff.ResourceDiff) []string {
-	messages := []string{}
+func AddingExactlyOneOfMessages(resource string, resourceDiff diff.ResourceDiff) []FieldMessage {
+	messages := []FieldMessage{}
+	tmpl := "Field `%s` within resource `%s` was added to exactly one of"
 
 	for newKey, newSet := range resourceDiff.FieldSets.New.ExactlyOneOf {
 		if _, ok := resourceDiff.FieldSets.Old.ExactlyOneOf[newKey]; ok {
@@ -68,7 +78,7 @@ func AddingExactlyOneOfMessages(resource string, resourceDiff diff.ResourceDiff)
 			// Only added *existing* optional fields are breaking.
 			for field := range simpleAddedFields {
 				if !isNewField(field, resourceDiff) && !isExistingFieldRequired(field, resourceDiff) {
-					messages = append(messages, fmt.Sprintf("Field `%s` within resource `%s` was added to exactly one of", field, resource))
+					messages = append(messages, FieldMessage{Field: field, Message: fmt.Sprintf(tmpl, field, resource)})
 				}
 			}
 		} else if isComplexModification(newSet, resourceDiff) {
@@ -76,7 +86,7 @@ func AddingExactlyOneOfMessages(resource string, resourceDiff diff.ResourceDiff)
 			// Any existing, optional field in the new set is breaking. New fields are not.
 			for field := range newSet {
 				if !isNewField(field, resourceDiff) && !isExistingFieldRequired(field, resourceDiff) {
-					messages = append(messages, fmt.Sprintf("Field `%s` within resource `%s` was added to exactly one of", field, resource))
+					messages = append(messages, FieldMessage{Field: field, Message: fmt.Sprintf(tmpl, field, resource)})
 				}
 			}
 		} else {
@@ -110,7 +120,7 @@ func AddingExactlyOneOfMessages(resource string, resourceDiff diff.ResourceDiff)
 
 			// Otherwise, all fields are breaking.
 			for field := range newSet {
-				messages = append(messages, fmt.Sprintf("Field `%s` within resource `%s` was added to exactly one of", field, resource))
+				messages = append(messages, FieldMessage{Field: field, Message: fmt.Sprintf(tmpl, field, resource)})
 			}
 		}
 	}
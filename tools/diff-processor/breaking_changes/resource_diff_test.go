@@ -19,10 +19,10 @@ func TestRemovingAFieldMessages(t *testing.T) {
 		}
 		wantFields := tc.expectedFields
 		sort.Strings(wantFields)
-		sort.Strings(gotMessages)
+		sort.Slice(gotMessages, func(i, j int) bool { return gotMessages[i].Field < gotMessages[j].Field })
 		for i, field := range wantFields {
-			if !strings.Contains(gotMessages[i], field) {
-				t.Errorf("RemovingAFieldMessages(%v) got message %q; want field %q", tc.name, gotMessages[i], field)
+			if gotMessages[i].Field != field || !strings.Contains(gotMessages[i].Message, field) {
+				t.Errorf("RemovingAFieldMessages(%v) got message %+v; want field %q", tc.name, gotMessages[i], field)
 			}
 		}
 	}
@@ -37,10 +37,10 @@ func TestAddingExactlyOneOfMessages(t *testing.T) {
 		}
 		wantFields := tc.expectedFields
 		sort.Strings(wantFields)
-		sort.Strings(gotMessages)
+		sort.Slice(gotMessages, func(i, j int) bool { return gotMessages[i].Field < gotMessages[j].Field })
 		for i, field := range wantFields {
-			if !strings.Contains(gotMessages[i], field) {
-				t.Errorf("AddingExactlyOneOfMessages(%v) got message %q; want field %q", tc.name, gotMessages[i], field)
+			if gotMessages[i].Field != field || !strings.Contains(gotMessages[i].Message, field) {
+				t.Errorf("AddingExactlyOneOfMessages(%v) got message %+v; want field %q", tc.name, gotMessages[i], field)
 			}
 		}
 	}
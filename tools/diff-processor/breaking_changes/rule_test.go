@@ -49,6 +49,34 @@ func TestMarkdownIdentifiers(t *testing.T) {
 	}
 }
 
+func TestRuleRemediations(t *testing.T) {
+	type rule struct{ identifier, remediation string }
+	var rules []rule
+	for _, ruleSet := range []RuleSet{ResourceRules, DataSourceRules, EphemeralResourceRules} {
+		for _, r := range ruleSet.ResourceConfigDiffRules {
+			rules = append(rules, rule{r.Identifier, r.Remediation})
+		}
+		for _, r := range ruleSet.ResourceDiffRules {
+			rules = append(rules, rule{r.Identifier, r.Remediation})
+		}
+		for _, r := range ruleSet.FieldDiffRules {
+			rules = append(rules, rule{r.Identifier, r.Remediation})
+		}
+	}
+	for _, r := range FunctionDiffRules {
+		rules = append(rules, rule{r.Identifier, r.Remediation})
+	}
+	for _, r := range FieldBehaviorDiffRules {
+		rules = append(rules, rule{r.Identifier, r.Remediation})
+	}
+
+	for _, r := range rules {
+		if r.remediation == "" {
+			t.Errorf("Rule %s does not have a remediation", r.identifier)
+		}
+	}
+}
+
 func getArrayOfIdentifiers() []string {
 	var identifiers []string
 
@@ -64,5 +92,13 @@ func getArrayOfIdentifiers() []string {
 		identifiers = append(identifiers, r.Identifier)
 	}
 
+	for _, r := range FunctionDiffRules {
+		identifiers = append(identifiers, r.Identifier)
+	}
+
+	for _, r := range FieldBehaviorDiffRules {
+		identifiers = append(identifiers, r.Identifier)
+	}
+
 	return identifiers
 }
@@ -23,3 +23,7 @@ func getValueType(valueType schema.ValueType) string {
 	}
 	return "TypeUndefined"
 }
+
+func isNumber(valueType schema.ValueType) bool {
+	return valueType == schema.TypeInt || valueType == schema.TypeFloat
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 	"os"
 	"sort"
+	"time"
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
@@ -15,37 +16,92 @@ import (
 
 const breakingChangesDesc = `Check for breaking changes between the new / old Terraform provider versions.`
 
+const breakingChangesLongDesc = breakingChangesDesc + `
+
+Breaking changes acknowledged by an unexpired entry of the --allowlist file
+aren't reported in JSON output, and are reported as suppressed in SARIF output.`
+
 type breakingChangesOptions struct {
-	rootOptions       *rootOptions
-	computeSchemaDiff func() diff.SchemaDiff
-	stdout            io.Writer
+	rootOptions         *rootOptions
+	computeProviderDiff func() (diff.ProviderDiff, error)
+	now                 func() time.Time
+	format              string
+	allowlistPath       string
+	stdout              io.Writer
+	stderr              io.Writer
 }
 
 func newBreakingChangesCmd(rootOptions *rootOptions) *cobra.Command {
 	o := &breakingChangesOptions{
-		rootOptions: rootOptions,
-		computeSchemaDiff: func() diff.SchemaDiff {
-			return schemaDiff
-		},
-		stdout: os.Stdout,
+		rootOptions:         rootOptions,
+		computeProviderDiff: loadProviderDiff,
+		now:                 time.Now,
+		stdout:              os.Stdout,
+		stderr:              os.Stderr,
 	}
 	cmd := &cobra.Command{
 		Use:   "breaking-changes",
 		Short: breakingChangesDesc,
-		Long:  breakingChangesDesc,
+		Long:  breakingChangesLongDesc,
 		RunE: func(c *cobra.Command, args []string) error {
 			return o.run()
 		},
 	}
+	cmd.Flags().StringVar(&o.format, "format", "json", "output format, either json or sarif")
+	cmd.Flags().StringVar(&o.allowlistPath, "allowlist", "", "path to a YAML file of acknowledged breaking changes")
 	return cmd
 }
+
+// suppressedBreakingChange is a breaking change acknowledged by an allowlist
+// entry.
+type suppressedBreakingChange struct {
+	breaking_changes.BreakingChange
+	entry breaking_changes.AllowlistEntry
+}
+
 func (o *breakingChangesOptions) run() error {
-	schemaDiff := o.computeSchemaDiff()
-	breakingChanges := breaking_changes.ComputeBreakingChanges(schemaDiff)
+	if o.format != "json" && o.format != "sarif" {
+		return fmt.Errorf("unknown output format %q, must be json or sarif", o.format)
+	}
+	var allowlist breaking_changes.Allowlist
+	if o.allowlistPath != "" {
+		var err error
+		allowlist, err = breaking_changes.ReadAllowlist(o.allowlistPath)
+		if err != nil {
+			return err
+		}
+	}
+
+	providerDiff, err := o.computeProviderDiff()
+	if err != nil {
+		return err
+	}
+	breakingChanges := breaking_changes.ComputeProviderBreakingChanges(providerDiff)
 	sort.Slice(breakingChanges, func(i, j int) bool {
 		return breakingChanges[i].Message < breakingChanges[j].Message
 	})
-	if err := json.NewEncoder(o.stdout).Encode(breakingChanges); err != nil {
+
+	var reported []breaking_changes.BreakingChange
+	var suppressed []suppressedBreakingChange
+	for _, breakingChange := range breakingChanges {
+		entry, ok := allowlist.Match(breakingChange)
+		if !ok {
+			reported = append(reported, breakingChange)
+			continue
+		}
+		if entry.Expired(o.now()) {
+			fmt.Fprintf(o.stderr, "allowlist entry for %s on %s expired on %s: %s\n", entry.Rule, breakingChange.Resource, entry.Expires, breakingChange.Message)
+			reported = append(reported, breakingChange)
+			continue
+		}
+		suppressed = append(suppressed, suppressedBreakingChange{BreakingChange: breakingChange, entry: entry})
+	}
+
+	var output any = reported
+	if o.format == "sarif" {
+		output = newSarifLog(reported, suppressed)
+	}
+	if err := json.NewEncoder(o.stdout).Encode(output); err != nil {
 		return fmt.Errorf("error encoding json: %w", err)
 	}
 	return nil
@@ -3,11 +3,17 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -15,6 +21,12 @@ func TestBreakingChangesCmd(t *testing.T) {
 	cases := map[string]struct {
 		oldResourceMap     map[string]*schema.Resource
 		newResourceMap     map[string]*schema.Resource
+		oldDataSourceMap   map[string]*schema.Resource
+		newDataSourceMap   map[string]*schema.Resource
+		oldFunctions       map[string]*tfprotov5.Function
+		newFunctions       map[string]*tfprotov5.Function
+		oldMetadata        map[string]diff.ResourceMetadata
+		newMetadata        map[string]diff.ResourceMetadata
 		expectedViolations int
 	}{
 		"no breaking changes": {
@@ -71,6 +83,55 @@ func TestBreakingChangesCmd(t *testing.T) {
 			},
 			expectedViolations: 3,
 		},
+		"data source missing and optional to required": {
+			oldDataSourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {Description: "beep", Optional: true},
+					},
+				},
+				"google-y": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {Description: "beep", Optional: true},
+					},
+				},
+			},
+			newDataSourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {Description: "beep", Required: true},
+					},
+				},
+			},
+			expectedViolations: 2,
+		},
+		"function removed and parameter type changed": {
+			oldFunctions: map[string]*tfprotov5.Function{
+				"function_x": {
+					Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.String}},
+					Return:     &tfprotov5.FunctionReturn{Type: tftypes.String},
+				},
+				"function_y": {
+					Return: &tfprotov5.FunctionReturn{Type: tftypes.String},
+				},
+			},
+			newFunctions: map[string]*tfprotov5.Function{
+				"function_x": {
+					Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.Number}},
+					Return:     &tfprotov5.FunctionReturn{Type: tftypes.String},
+				},
+			},
+			expectedViolations: 2,
+		},
+		"enum value removed": {
+			oldMetadata: map[string]diff.ResourceMetadata{
+				"google-x": {Fields: []diff.FieldMetadata{{ApiField: "mode", EnumValues: []string{"A", "B"}}}},
+			},
+			newMetadata: map[string]diff.ResourceMetadata{
+				"google-x": {Fields: []diff.FieldMetadata{{ApiField: "mode", EnumValues: []string{"A"}}}},
+			},
+			expectedViolations: 1,
+		},
 	}
 
 	for tn, tc := range cases {
@@ -80,9 +141,15 @@ func TestBreakingChangesCmd(t *testing.T) {
 
 			var buf bytes.Buffer
 			o := breakingChangesOptions{
-				computeSchemaDiff: func() diff.SchemaDiff {
-					return diff.ComputeSchemaDiff(tc.oldResourceMap, tc.newResourceMap)
+				computeProviderDiff: func() (diff.ProviderDiff, error) {
+					return diff.ProviderDiff{
+						Resources:        diff.ComputeSchemaDiff(tc.oldResourceMap, tc.newResourceMap),
+						DataSources:      diff.ComputeSchemaDiff(tc.oldDataSourceMap, tc.newDataSourceMap),
+						Functions:        diff.ComputeFunctionDiff(tc.oldFunctions, tc.newFunctions),
+						ResourceMetadata: diff.ComputeMetadataDiff(tc.oldMetadata, tc.newMetadata),
+					}, nil
 				},
+				format: "json",
 				stdout: &buf,
 			}
 
@@ -105,3 +172,107 @@ func TestBreakingChangesCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestBreakingChangesCmdAllowlist(t *testing.T) {
+	allowlist := `
+- rule: resource-map-resource-removal-or-rename
+  resource: google-y
+  expires: 2025-09-30
+  justification: Removed in the major release.
+- rule: resource-schema-field-removal-or-rename
+  resource: google-x
+  field: field-b
+  expires: 2025-06-30
+  justification: Removed in the major release.
+`
+	allowlistPath := filepath.Join(t.TempDir(), "allowlist.yaml")
+	if err := os.WriteFile(allowlistPath, []byte(allowlist), 0644); err != nil {
+		t.Fatal(err)
+	}
+	oldResourceMap := map[string]*schema.Resource{
+		"google-x": {
+			Schema: map[string]*schema.Schema{
+				"field-a": {Description: "beep", Optional: true},
+				"field-b": {Description: "beep", Optional: true},
+			},
+		},
+		"google-y": {
+			Schema: map[string]*schema.Schema{
+				"field-a": {Description: "beep", Optional: true},
+			},
+		},
+	}
+	newResourceMap := map[string]*schema.Resource{
+		"google-x": {
+			Schema: map[string]*schema.Schema{
+				"field-a": {Description: "beep", Required: true},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		format         string
+		wantOutput     []string
+		wantStderr     string
+		wantViolations int
+	}{
+		"json": {
+			format:         "json",
+			wantOutput:     []string{`"Resource":"google-x"`, `"Field":"field-b"`, `"RuleName":"resource-schema-field-removal-or-rename"`},
+			wantStderr:     "allowlist entry for resource-schema-field-removal-or-rename on google-x expired on 2025-06-30",
+			wantViolations: 2,
+		},
+		"sarif": {
+			format: "sarif",
+			wantOutput: []string{
+				`"version":"2.1.0"`,
+				`"level":"error"`,
+				`"fullyQualifiedName":"google-x.field-b"`,
+				`"suppressions":[{"kind":"external","justification":"Removed in the major release."}]`,
+				`"remediation":"Keep the resource.`,
+			},
+			wantStderr: "expired on 2025-06-30",
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+
+			var stdout, stderr bytes.Buffer
+			o := breakingChangesOptions{
+				computeProviderDiff: func() (diff.ProviderDiff, error) {
+					return diff.ProviderDiff{Resources: diff.ComputeSchemaDiff(oldResourceMap, newResourceMap)}, nil
+				},
+				now:           func() time.Time { return time.Date(2025, 7, 1, 0, 0, 0, 0, time.UTC) },
+				format:        tc.format,
+				allowlistPath: allowlistPath,
+				stdout:        &stdout,
+				stderr:        &stderr,
+			}
+
+			if err := o.run(); err != nil {
+				t.Fatalf("Error running command: %s", err)
+			}
+			for _, want := range tc.wantOutput {
+				if !strings.Contains(stdout.String(), want) {
+					t.Errorf("Output doesn't contain %s. Output: %s", want, stdout.String())
+				}
+			}
+			if !strings.Contains(stderr.String(), tc.wantStderr) {
+				t.Errorf("Stderr doesn't contain %q. Stderr: %s", tc.wantStderr, stderr.String())
+			}
+			if tc.format != "json" {
+				return
+			}
+			var got []breaking_changes.BreakingChange
+			if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+				t.Fatalf("Failed to unmarshall output: %s", err)
+			}
+			if len(got) != tc.wantViolations {
+				t.Errorf("Unexpected number of violations. Want %d, got %d. Output: %s", tc.wantViolations, len(got), stdout.String())
+			}
+		})
+	}
+}
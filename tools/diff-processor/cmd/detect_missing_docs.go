@@ -12,9 +12,6 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/maps"
-
-	newProvider "google/provider/new/google/provider"
-	oldProvider "google/provider/old/google/provider"
 )
 
 const detectMissingDocDesc = `Compute list of fields missing documents`
@@ -26,27 +23,23 @@ type MissingDocsInfo struct {
 }
 
 type detectMissingDocsOptions struct {
-	rootOptions                 *rootOptions
-	computeSchemaDiff           func() diff.SchemaDiff
-	computeDatasourceSchemaDiff func() diff.SchemaDiff
-	stdout                      io.Writer
+	rootOptions         *rootOptions
+	computeProviderDiff func() (diff.ProviderDiff, error)
+	stdout              io.Writer
 }
 
 type MissingDocsSummary struct {
-	Resource   []detector.MissingDocDetails
-	DataSource []detector.MissingDocDetails
+	Resource          []detector.MissingDocDetails
+	DataSource        []detector.MissingDocDetails
+	EphemeralResource []detector.MissingDocDetails
+	Function          []detector.MissingDocDetails
 }
 
 func newDetectMissingDocsCmd(rootOptions *rootOptions) *cobra.Command {
 	o := &detectMissingDocsOptions{
-		rootOptions: rootOptions,
-		computeSchemaDiff: func() diff.SchemaDiff {
-			return schemaDiff
-		},
-		computeDatasourceSchemaDiff: func() diff.SchemaDiff {
-			return diff.ComputeSchemaDiff(oldProvider.DatasourceMap(), newProvider.DatasourceMap())
-		},
-		stdout: os.Stdout,
+		rootOptions:         rootOptions,
+		computeProviderDiff: loadProviderDiff,
+		stdout:              os.Stdout,
 	}
 	cmd := &cobra.Command{
 		Use:   "detect-missing-docs",
@@ -60,21 +53,36 @@ func newDetectMissingDocsCmd(rootOptions *rootOptions) *cobra.Command {
 	return cmd
 }
 func (o *detectMissingDocsOptions) run(args []string) error {
-	schemaDiff := o.computeSchemaDiff()
-	detectedResources, err := detector.DetectMissingDocs(schemaDiff, args[0])
+	providerDiff, err := o.computeProviderDiff()
+	if err != nil {
+		return err
+	}
+
+	detectedResources, err := detector.DetectMissingDocs(providerDiff.Resources, args[0])
+	if err != nil {
+		return err
+	}
+
+	detectedDataSources, err := detector.DetectMissingDocsForDatasource(providerDiff.DataSources, args[0])
+	if err != nil {
+		return err
+	}
+
+	detectedEphemeralResources, err := detector.DetectMissingDocsForEphemeralResource(providerDiff.EphemeralResources, args[0])
 	if err != nil {
 		return err
 	}
 
-	datasourceSchemaDiff := o.computeDatasourceSchemaDiff()
-	detectedDataSources, err := detector.DetectMissingDocsForDatasource(datasourceSchemaDiff, args[0])
+	detectedFunctions, err := detector.DetectMissingDocsForFunction(providerDiff.Functions, args[0])
 	if err != nil {
 		return err
 	}
 
 	sum := MissingDocsSummary{
-		Resource:   sortMissingDocDetails(detectedResources),
-		DataSource: sortMissingDocDetails(detectedDataSources),
+		Resource:          sortMissingDocDetails(detectedResources),
+		DataSource:        sortMissingDocDetails(detectedDataSources),
+		EphemeralResource: sortMissingDocDetails(detectedEphemeralResources),
+		Function:          sortMissingDocDetails(detectedFunctions),
 	}
 
 	if err := json.NewEncoder(o.stdout).Encode(sum); err != nil {
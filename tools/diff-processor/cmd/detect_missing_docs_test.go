@@ -9,6 +9,8 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
 	"github.com/google/go-cmp/cmp"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -19,6 +21,8 @@ func TestDetectMissingDocs(t *testing.T) {
 		newResourceMap   map[string]*schema.Resource
 		oldDataSourceMap map[string]*schema.Resource
 		newDataSourceMap map[string]*schema.Resource
+		oldFunctions     map[string]*tfprotov5.Function
+		newFunctions     map[string]*tfprotov5.Function
 		want             MissingDocsSummary
 	}{
 		{
@@ -56,8 +60,10 @@ func TestDetectMissingDocs(t *testing.T) {
 				},
 			},
 			want: MissingDocsSummary{
-				Resource:   []detector.MissingDocDetails{},
-				DataSource: []detector.MissingDocDetails{},
+				Resource:          []detector.MissingDocDetails{},
+				DataSource:        []detector.MissingDocDetails{},
+				EphemeralResource: []detector.MissingDocDetails{},
+				Function:          []detector.MissingDocDetails{},
 			},
 		},
 		{
@@ -79,6 +85,10 @@ func TestDetectMissingDocs(t *testing.T) {
 					},
 				},
 			},
+			oldFunctions: map[string]*tfprotov5.Function{},
+			newFunctions: map[string]*tfprotov5.Function{
+				"function_z": {Return: &tfprotov5.FunctionReturn{Type: tftypes.String}},
+			},
 			want: MissingDocsSummary{
 				Resource: []detector.MissingDocDetails{
 					{
@@ -99,6 +109,13 @@ func TestDetectMissingDocs(t *testing.T) {
 						},
 					},
 				},
+				EphemeralResource: []detector.MissingDocDetails{},
+				Function: []detector.MissingDocDetails{
+					{
+						Name:     "function_z",
+						FilePath: "/website/docs/functions/function_z.html.markdown",
+					},
+				},
 			},
 		},
 	}
@@ -107,13 +124,14 @@ func TestDetectMissingDocs(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			var buf bytes.Buffer
 			o := detectMissingDocsOptions{
-				computeSchemaDiff: func() diff.SchemaDiff {
-					return diff.ComputeSchemaDiff(tc.oldResourceMap, tc.newResourceMap)
+				computeProviderDiff: func() (diff.ProviderDiff, error) {
+					return diff.ProviderDiff{
+						Resources:   diff.ComputeSchemaDiff(tc.oldResourceMap, tc.newResourceMap),
+						DataSources: diff.ComputeSchemaDiff(tc.oldDataSourceMap, tc.newDataSourceMap),
+						Functions:   diff.ComputeFunctionDiff(tc.oldFunctions, tc.newFunctions),
+					}, nil
 				},
 				stdout: &buf,
-				computeDatasourceSchemaDiff: func() diff.SchemaDiff {
-					return diff.ComputeSchemaDiff(tc.oldDataSourceMap, tc.newDataSourceMap)
-				},
 			}
 
 			err := o.run([]string{t.TempDir()})
@@ -42,7 +42,11 @@ func (o *detectMissingTestsOptions) run(args []string) error {
 		glog.Infof("error reading path: %s, err: %v", path, err)
 	}
 
-	missingTests, err := detector.DetectMissingTests(schemaDiff, allTests)
+	providerDiff, err := loadProviderDiff()
+	if err != nil {
+		return err
+	}
+	missingTests, err := detector.DetectMissingTests(providerDiff.Resources, allTests)
 	if err != nil {
 		return fmt.Errorf("error detecting missing tests: %v", err)
 	}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
+)
+
+// The subset of SARIF 2.1.0 used to report breaking changes. See
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html.
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID      string       `json:"id"`
+	HelpURI string       `json:"helpUri"`
+	Help    sarifMessage `json:"help"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID       string             `json:"ruleId"`
+	RuleIndex    int                `json:"ruleIndex"`
+	Level        string             `json:"level"`
+	Message      sarifMessage       `json:"message"`
+	Locations    []sarifLocation    `json:"locations"`
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+	Properties   sarifProperties    `json:"properties"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification"`
+}
+
+type sarifProperties struct {
+	Resource    string `json:"resource"`
+	Field       string `json:"field,omitempty"`
+	Remediation string `json:"remediation"`
+	Expires     string `json:"expires,omitempty"`
+}
+
+// newSarifLog returns a SARIF log of reported breaking changes and of
+// breaking changes suppressed by allowlist entries.
+func newSarifLog(reported []breaking_changes.BreakingChange, suppressed []suppressedBreakingChange) sarifLog {
+	driver := sarifDriver{
+		Name:           "diff-processor",
+		InformationURI: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes",
+		Rules:          []sarifRule{},
+	}
+	ruleIndexes := make(map[string]int)
+	newResult := func(breakingChange breaking_changes.BreakingChange) sarifResult {
+		index, ok := ruleIndexes[breakingChange.RuleName]
+		if !ok {
+			index = len(driver.Rules)
+			ruleIndexes[breakingChange.RuleName] = index
+			driver.Rules = append(driver.Rules, sarifRule{
+				ID:      breakingChange.RuleName,
+				HelpURI: breakingChange.DocumentationReference,
+				Help:    sarifMessage{Text: breakingChange.Remediation},
+			})
+		}
+		location := sarifLogicalLocation{
+			Name:               breakingChange.Resource,
+			FullyQualifiedName: breakingChange.Resource,
+			Kind:               "resource",
+		}
+		if breakingChange.Field != "" {
+			location.Name = breakingChange.Field
+			location.FullyQualifiedName += "." + breakingChange.Field
+			location.Kind = "member"
+		}
+		return sarifResult{
+			RuleID:    breakingChange.RuleName,
+			RuleIndex: index,
+			Level:     breakingChange.Severity,
+			Message:   sarifMessage{Text: breakingChange.Message},
+			Locations: []sarifLocation{{LogicalLocations: []sarifLogicalLocation{location}}},
+			Properties: sarifProperties{
+				Resource:    breakingChange.Resource,
+				Field:       breakingChange.Field,
+				Remediation: breakingChange.Remediation,
+			},
+		}
+	}
+
+	results := []sarifResult{}
+	for _, breakingChange := range reported {
+		results = append(results, newResult(breakingChange))
+	}
+	for _, breakingChange := range suppressed {
+		result := newResult(breakingChange.BreakingChange)
+		result.Suppressions = []sarifSuppression{{Kind: "external", Justification: breakingChange.entry.Justification}}
+		result.Properties.Expires = breakingChange.entry.Expires
+		results = append(results, result)
+	}
+	return sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+}
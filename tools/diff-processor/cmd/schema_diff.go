@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	newFwprovider "google/provider/new/google/fwprovider"
 	newProvider "google/provider/new/google/provider"
+	oldFwprovider "google/provider/old/google/fwprovider"
 	oldProvider "google/provider/old/google/provider"
 
 	"encoding/json"
@@ -9,6 +11,7 @@ import (
 	"io"
 	"os"
 	"sort"
+	"sync"
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
 	"github.com/spf13/cobra"
@@ -16,25 +19,49 @@ import (
 
 const schemaDiffDesc = `Return a simple summary of the schema diff for this build.`
 
-var schemaDiff = diff.ComputeSchemaDiff(oldProvider.ResourceMap(), newProvider.ResourceMap())
+// loadProviderDiff returns the diff between the schemas of the old and new
+// provider builds, computed on first use. Resource metadata is read from the
+// old and new provider sources, which are built from the working directory.
+var loadProviderDiff = sync.OnceValues(func() (diff.ProviderDiff, error) {
+	oldPrimary := oldProvider.Provider()
+	oldSchemas, err := diff.LoadProviderSchemas(oldPrimary, oldFwprovider.New(oldPrimary))
+	if err != nil {
+		return diff.ProviderDiff{}, fmt.Errorf("error loading old provider schemas: %w", err)
+	}
+	oldSchemas.ResourceMetadata, err = diff.LoadResourceMetadata("old/google/services")
+	if err != nil {
+		return diff.ProviderDiff{}, fmt.Errorf("error loading old resource metadata: %w", err)
+	}
+	newPrimary := newProvider.Provider()
+	newSchemas, err := diff.LoadProviderSchemas(newPrimary, newFwprovider.New(newPrimary))
+	if err != nil {
+		return diff.ProviderDiff{}, fmt.Errorf("error loading new provider schemas: %w", err)
+	}
+	newSchemas.ResourceMetadata, err = diff.LoadResourceMetadata("new/google/services")
+	if err != nil {
+		return diff.ProviderDiff{}, fmt.Errorf("error loading new resource metadata: %w", err)
+	}
+	return diff.ComputeProviderDiff(oldSchemas, newSchemas), nil
+})
 
 type simpleSchemaDiff struct {
-	AddedResources, ModifiedResources, RemovedResources []string
+	AddedResources, ModifiedResources, RemovedResources                            []string
+	AddedDataSources, ModifiedDataSources, RemovedDataSources                      []string
+	AddedEphemeralResources, ModifiedEphemeralResources, RemovedEphemeralResources []string
+	AddedFunctions, ModifiedFunctions, RemovedFunctions                            []string
 }
 
 type schemaDiffOptions struct {
-	rootOptions       *rootOptions
-	computeSchemaDiff func() diff.SchemaDiff
-	stdout            io.Writer
+	rootOptions         *rootOptions
+	computeProviderDiff func() (diff.ProviderDiff, error)
+	stdout              io.Writer
 }
 
 func newSchemaDiffCmd(rootOptions *rootOptions) *cobra.Command {
 	o := &schemaDiffOptions{
-		rootOptions: rootOptions,
-		computeSchemaDiff: func() diff.SchemaDiff {
-			return schemaDiff
-		},
-		stdout: os.Stdout,
+		rootOptions:         rootOptions,
+		computeProviderDiff: loadProviderDiff,
+		stdout:              os.Stdout,
 	}
 	cmd := &cobra.Command{
 		Use:   "schema-diff",
@@ -48,23 +75,28 @@ func newSchemaDiffCmd(rootOptions *rootOptions) *cobra.Command {
 	return cmd
 }
 func (o *schemaDiffOptions) run() error {
-	schemaDiff := o.computeSchemaDiff()
+	providerDiff, err := o.computeProviderDiff()
+	if err != nil {
+		return err
+	}
 
 	simple := simpleSchemaDiff{}
+	simple.AddedResources, simple.ModifiedResources, simple.RemovedResources = summarizeSchemaDiff(providerDiff.Resources)
+	simple.AddedDataSources, simple.ModifiedDataSources, simple.RemovedDataSources = summarizeSchemaDiff(providerDiff.DataSources)
+	simple.AddedEphemeralResources, simple.ModifiedEphemeralResources, simple.RemovedEphemeralResources = summarizeSchemaDiff(providerDiff.EphemeralResources)
 
-	for k, d := range schemaDiff {
-		if d.ResourceConfig.Old == nil {
-			simple.AddedResources = append(simple.AddedResources, k)
-		} else if d.ResourceConfig.New == nil {
-			simple.RemovedResources = append(simple.RemovedResources, k)
+	for k, d := range providerDiff.Functions {
+		if d.Old == nil {
+			simple.AddedFunctions = append(simple.AddedFunctions, k)
+		} else if d.New == nil {
+			simple.RemovedFunctions = append(simple.RemovedFunctions, k)
 		} else {
-			simple.ModifiedResources = append(simple.ModifiedResources, k)
+			simple.ModifiedFunctions = append(simple.ModifiedFunctions, k)
 		}
 	}
-
-	sort.Strings(simple.AddedResources)
-	sort.Strings(simple.ModifiedResources)
-	sort.Strings(simple.RemovedResources)
+	sort.Strings(simple.AddedFunctions)
+	sort.Strings(simple.ModifiedFunctions)
+	sort.Strings(simple.RemovedFunctions)
 
 	if err := json.NewEncoder(o.stdout).Encode(simple); err != nil {
 		return fmt.Errorf("Error encoding json: %w", err)
@@ -72,3 +104,22 @@ func (o *schemaDiffOptions) run() error {
 
 	return nil
 }
+
+// summarizeSchemaDiff returns the sorted names of the added, modified and
+// removed resources in a schema diff.
+func summarizeSchemaDiff(schemaDiff diff.SchemaDiff) (added, modified, removed []string) {
+	for k, d := range schemaDiff {
+		if d.ResourceConfig.Old == nil {
+			added = append(added, k)
+		} else if d.ResourceConfig.New == nil {
+			removed = append(removed, k)
+		} else {
+			modified = append(modified, k)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(modified)
+	sort.Strings(removed)
+	return added, modified, removed
+}
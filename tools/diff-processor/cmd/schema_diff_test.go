@@ -8,16 +8,22 @@ import (
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func TestSchemaDiffCmdRun(t *testing.T) {
 	cases := []struct {
-		name           string
-		args           []string
-		oldResourceMap map[string]*schema.Resource
-		newResourceMap map[string]*schema.Resource
-		want           simpleSchemaDiff
+		name             string
+		args             []string
+		oldResourceMap   map[string]*schema.Resource
+		newResourceMap   map[string]*schema.Resource
+		oldDataSourceMap map[string]*schema.Resource
+		newDataSourceMap map[string]*schema.Resource
+		oldFunctions     map[string]*tfprotov5.Function
+		newFunctions     map[string]*tfprotov5.Function
+		want             simpleSchemaDiff
 	}{
 		{
 			name:           "empty resource map",
@@ -247,6 +253,42 @@ func TestSchemaDiffCmdRun(t *testing.T) {
 				RemovedResources:  []string{"google_z_resource"},
 			},
 		},
+		{
+			name: "data sources and functions are changed",
+			args: []string{"12345"},
+			oldDataSourceMap: map[string]*schema.Resource{
+				"google_x_data_source": {
+					Schema: map[string]*schema.Schema{
+						"field_a": {Description: "beep", Optional: true},
+					},
+				},
+			},
+			newDataSourceMap: map[string]*schema.Resource{
+				"google_x_data_source": {
+					Schema: map[string]*schema.Schema{
+						"field_a": {Description: "beep", Optional: true},
+						"field_b": {Description: "beep", Optional: true},
+					},
+				},
+				"google_y_data_source": {
+					Schema: map[string]*schema.Schema{
+						"field_a": {Description: "beep", Optional: true},
+					},
+				},
+			},
+			oldFunctions: map[string]*tfprotov5.Function{
+				"function_x": {Return: &tfprotov5.FunctionReturn{Type: tftypes.String}},
+			},
+			newFunctions: map[string]*tfprotov5.Function{
+				"function_y": {Return: &tfprotov5.FunctionReturn{Type: tftypes.String}},
+			},
+			want: simpleSchemaDiff{
+				AddedDataSources:    []string{"google_y_data_source"},
+				ModifiedDataSources: []string{"google_x_data_source"},
+				AddedFunctions:      []string{"function_y"},
+				RemovedFunctions:    []string{"function_x"},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -256,8 +298,12 @@ func TestSchemaDiffCmdRun(t *testing.T) {
 
 			var buf bytes.Buffer
 			o := schemaDiffOptions{
-				computeSchemaDiff: func() diff.SchemaDiff {
-					return diff.ComputeSchemaDiff(tc.oldResourceMap, tc.newResourceMap)
+				computeProviderDiff: func() (diff.ProviderDiff, error) {
+					return diff.ProviderDiff{
+						Resources:   diff.ComputeSchemaDiff(tc.oldResourceMap, tc.newResourceMap),
+						DataSources: diff.ComputeSchemaDiff(tc.oldDataSourceMap, tc.newDataSourceMap),
+						Functions:   diff.ComputeFunctionDiff(tc.oldFunctions, tc.newFunctions),
+					}, nil
 				},
 				stdout: &buf,
 			}
@@ -224,9 +224,20 @@ func DetectMissingDocs(schemaDiff diff.SchemaDiff, repoPath string) (map[string]
 // It only checks whether the data source doc file exists.
 // Should avoid printing to stdout since the output will be consumed in generate_comment.go.
 func DetectMissingDocsForDatasource(schemaDiff diff.SchemaDiff, repoPath string) (map[string]MissingDocDetails, error) {
+	return detectMissingDocFiles(schemaDiff, repoPath, dataSourceToDocFile)
+}
+
+// DetectMissingDocsForEphemeralResource detect new fields that are missing docs given the schema diffs.
+// Return a map of ephemeral resource names to missing doc info.
+// It only checks whether the ephemeral resource doc file exists.
+func DetectMissingDocsForEphemeralResource(schemaDiff diff.SchemaDiff, repoPath string) (map[string]MissingDocDetails, error) {
+	return detectMissingDocFiles(schemaDiff, repoPath, ephemeralResourceToDocFile)
+}
+
+func detectMissingDocFiles(schemaDiff diff.SchemaDiff, repoPath string, toDocFile func(string, string) (string, error)) (map[string]MissingDocDetails, error) {
 	ret := make(map[string]MissingDocDetails)
 	for resource, resourceDiff := range schemaDiff {
-		docFilePath, err := dataSourceToDocFile(resource, repoPath)
+		docFilePath, err := toDocFile(resource, repoPath)
 		if err != nil {
 			var newFields []string
 			for field, fieldDiff := range resourceDiff.Fields {
@@ -248,6 +259,25 @@ func DetectMissingDocsForDatasource(schemaDiff diff.SchemaDiff, repoPath string)
 	return ret, nil
 }
 
+// DetectMissingDocsForFunction detect new provider-defined functions that are missing docs given the function diffs.
+// Return a map of function names to missing doc info.
+func DetectMissingDocsForFunction(functionDiff diff.FunctionSchemaDiff, repoPath string) (map[string]MissingDocDetails, error) {
+	ret := make(map[string]MissingDocDetails)
+	for function, fd := range functionDiff {
+		if fd.Old != nil || fd.New == nil {
+			continue
+		}
+		docFilePath := filepath.Join(repoPath, "website", "docs", "functions", function+".html.markdown")
+		if _, err := os.Stat(docFilePath); os.IsNotExist(err) {
+			ret[function] = MissingDocDetails{
+				Name:     function,
+				FilePath: strings.ReplaceAll(docFilePath, repoPath, ""),
+			}
+		}
+	}
+	return ret, nil
+}
+
 func isNewField(fieldDiff diff.FieldDiff) bool {
 	return fieldDiff.Old == nil && fieldDiff.New != nil
 }
@@ -302,6 +332,15 @@ func dataSourceToDocFile(resource string, repoPath string) (string, error) {
 	return filepath.Join(repoPath, "website", "docs", "d", baseNameOptions[0]+".html.markdown"), fmt.Errorf("no document files found in %s for resource %q", baseNameOptions, resource)
 }
 
+func ephemeralResourceToDocFile(resource string, repoPath string) (string, error) {
+	baseName := strings.TrimPrefix(resource, "google_") + ".html.markdown"
+	fullPath := filepath.Join(repoPath, "website", "docs", "ephemeral-resources", baseName)
+	if _, err := os.ReadFile(fullPath); os.IsNotExist(err) {
+		return fullPath, fmt.Errorf("no document file found at %s for ephemeral resource %q", fullPath, resource)
+	}
+	return fullPath, nil
+}
+
 func listToMap(items []string) map[string]bool {
 	m := make(map[string]bool)
 	for _, item := range items {
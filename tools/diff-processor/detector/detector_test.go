@@ -8,6 +8,7 @@ import (
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
 	"github.com/GoogleCloudPlatform/magic-modules/tools/test-reader/reader"
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -446,3 +447,94 @@ func TestDetectMissingDocsForDatasource(t *testing.T) {
 		})
 	}
 }
+
+func TestDetectMissingDocsForEphemeralResource(t *testing.T) {
+	schemaDiff := diff.SchemaDiff{
+		"a_resource": diff.ResourceDiff{
+			Fields: map[string]diff.FieldDiff{
+				"field_one": {
+					New: &schema.Schema{},
+				},
+				"field_two.field_three": {
+					New: &schema.Schema{},
+					Old: &schema.Schema{},
+				},
+			},
+		},
+	}
+	for _, test := range []struct {
+		name string
+		repo string
+		want map[string]MissingDocDetails
+	}{
+		{
+			name: "doc file not exist",
+			repo: t.TempDir(),
+			want: map[string]MissingDocDetails{
+				"a_resource": {
+					Name:     "a_resource",
+					FilePath: "/website/docs/ephemeral-resources/a_resource.html.markdown",
+					Fields:   []string{"field_one"},
+				},
+			},
+		},
+		{
+			name: "doc file exist",
+			repo: "../testdata",
+			want: map[string]MissingDocDetails{},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := DetectMissingDocsForEphemeralResource(schemaDiff, test.repo)
+			if err != nil {
+				t.Fatalf("DetectMissingDocsForEphemeralResource = %v, want = nil", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("got unexpected added fields: %v, expected %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDetectMissingDocsForFunction(t *testing.T) {
+	functionDiff := diff.FunctionSchemaDiff{
+		"a_function": diff.FunctionDiff{
+			New: &tfprotov5.Function{},
+		},
+		"b_function": diff.FunctionDiff{
+			Old: &tfprotov5.Function{},
+			New: &tfprotov5.Function{Summary: "changed"},
+		},
+	}
+	for _, test := range []struct {
+		name string
+		repo string
+		want map[string]MissingDocDetails
+	}{
+		{
+			name: "doc file not exist",
+			repo: t.TempDir(),
+			want: map[string]MissingDocDetails{
+				"a_function": {
+					Name:     "a_function",
+					FilePath: "/website/docs/functions/a_function.html.markdown",
+				},
+			},
+		},
+		{
+			name: "doc file exist",
+			repo: "../testdata",
+			want: map[string]MissingDocDetails{},
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := DetectMissingDocsForFunction(functionDiff, test.repo)
+			if err != nil {
+				t.Fatalf("DetectMissingDocsForFunction = %v, want = nil", err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("got unexpected missing docs: %v, expected %v", got, test.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package diff
 
 import (
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/google/go-cmp/cmp"
@@ -14,6 +15,7 @@ type SchemaDiff map[string]ResourceDiff
 type ResourceDiffInterface interface {
 	IsNewResource() bool
 	IsFieldInNewNestedStructure(fieldPath string) bool
+	IsImplementationChanged() bool
 }
 
 type ResourceDiff struct {
@@ -35,9 +37,13 @@ type ResourceFieldSets struct {
 	RequiredWith  map[string]FieldSet
 }
 
+// ResourceConfigDiff is the diff of the resource-level configuration of a
+// resource. ImplementationChanged is true if the resource moved between the
+// SDK and the plugin framework, whose schemas don't carry the same details.
 type ResourceConfigDiff struct {
-	Old *schema.Resource
-	New *schema.Resource
+	Old                   *schema.Resource
+	New                   *schema.Resource
+	ImplementationChanged bool
 }
 
 type FieldDiff struct {
@@ -54,21 +60,19 @@ func ComputeSchemaDiff(oldResourceMap, newResourceMap map[string]*schema.Resourc
 	schemaDiff := make(SchemaDiff)
 	for resource := range union(oldResourceMap, newResourceMap) {
 		// Compute diff between old and new resources and fields.
-		// TODO: add support for computing diff between resource configs, not just whether the
-		// resource was added/removed. b/300114839
 		resourceDiff := ResourceDiff{}
 		var flattenedOldSchema map[string]*schema.Schema
 		if oldResource, ok := oldResourceMap[resource]; ok {
 			flattenedOldSchema = flattenSchema("", oldResource.Schema)
 			resourceDiff.FlattenedSchema.Old = flattenedOldSchema
-			resourceDiff.ResourceConfig.Old = &schema.Resource{}
+			resourceDiff.ResourceConfig.Old = resourceConfig(oldResource)
 		}
 
 		var flattenedNewSchema map[string]*schema.Schema
 		if newResource, ok := newResourceMap[resource]; ok {
 			flattenedNewSchema = flattenSchema("", newResource.Schema)
 			resourceDiff.FlattenedSchema.New = flattenedNewSchema
-			resourceDiff.ResourceConfig.New = &schema.Resource{}
+			resourceDiff.ResourceConfig.New = resourceConfig(newResource)
 		}
 
 		resourceDiff.Fields = make(map[string]FieldDiff)
@@ -80,13 +84,94 @@ func ComputeSchemaDiff(oldResourceMap, newResourceMap map[string]*schema.Resourc
 				resourceDiff.FieldSets = mergeFieldSetsDiff(resourceDiff.FieldSets, fieldSetsDiff)
 			}
 		}
-		if len(resourceDiff.Fields) > 0 || !cmp.Equal(resourceDiff.ResourceConfig.Old, resourceDiff.ResourceConfig.New) {
+		if len(resourceDiff.Fields) > 0 || resourceConfigChanged(resourceDiff.ResourceConfig.Old, resourceDiff.ResourceConfig.New) {
 			schemaDiff[resource] = resourceDiff
 		}
 	}
 	return schemaDiff
 }
 
+// resourceConfig returns the resource-level configuration of a resource. Its
+// fields are diffed separately, so they're left out.
+func resourceConfig(resource *schema.Resource) *schema.Resource {
+	return &schema.Resource{
+		Importer:           resource.Importer,
+		Timeouts:           resource.Timeouts,
+		SchemaVersion:      resource.SchemaVersion,
+		StateUpgraders:     resource.StateUpgraders,
+		CustomizeDiff:      resource.CustomizeDiff,
+		DeprecationMessage: resource.DeprecationMessage,
+		Identity:           resource.Identity,
+	}
+}
+
+func resourceConfigChanged(oldConfig, newConfig *schema.Resource) bool {
+	// If either config is nil, the resource was added or removed
+	if oldConfig == nil || newConfig == nil {
+		return oldConfig != newConfig
+	}
+	if (oldConfig.Importer == nil) != (newConfig.Importer == nil) {
+		return true
+	}
+	if !reflect.DeepEqual(oldConfig.Timeouts, newConfig.Timeouts) {
+		return true
+	}
+	if oldConfig.SchemaVersion != newConfig.SchemaVersion {
+		return true
+	}
+	if !slices.Equal(stateUpgraderVersions(oldConfig), stateUpgraderVersions(newConfig)) {
+		return true
+	}
+	if funcChanged(oldConfig.CustomizeDiff, newConfig.CustomizeDiff) {
+		return true
+	}
+	if oldConfig.DeprecationMessage != newConfig.DeprecationMessage {
+		return true
+	}
+	return identityChanged(oldConfig.Identity, newConfig.Identity)
+}
+
+// stateUpgraderVersions returns the schema versions a resource's state
+// upgraders upgrade state from.
+func stateUpgraderVersions(resource *schema.Resource) []int {
+	var versions []int
+	for _, upgrader := range resource.StateUpgraders {
+		versions = append(versions, upgrader.Version)
+	}
+	return versions
+}
+
+func identityChanged(oldIdentity, newIdentity *schema.ResourceIdentity) bool {
+	if oldIdentity == nil || newIdentity == nil {
+		return oldIdentity != newIdentity
+	}
+	if oldIdentity.Version != newIdentity.Version {
+		return true
+	}
+	oldSchema, newSchema := identitySchema(oldIdentity), identitySchema(newIdentity)
+	for key := range union(oldSchema, newSchema) {
+		oldField, newField := oldSchema[key], newSchema[key]
+		if oldField == nil || newField == nil {
+			return true
+		}
+		if basicSchemaChanged(oldField, newField) || elemChanged(oldField, newField) {
+			return true
+		}
+		if oldField.RequiredForImport != newField.RequiredForImport || oldField.OptionalForImport != newField.OptionalForImport {
+			return true
+		}
+	}
+	return false
+}
+
+// identitySchema returns the schema of a resource identity.
+func identitySchema(identity *schema.ResourceIdentity) map[string]*schema.Schema {
+	if identity == nil || identity.SchemaFunc == nil {
+		return nil
+	}
+	return identity.SchemaFunc()
+}
+
 func flattenSchema(parentKey string, schemaObj map[string]*schema.Schema) map[string]*schema.Schema {
 	flattened := make(map[string]*schema.Schema)
 
@@ -333,6 +418,12 @@ func (rd ResourceDiff) IsNewResource() bool {
 	return false
 }
 
+// IsImplementationChanged returns whether the resource moved between the SDK
+// and the plugin framework.
+func (rd ResourceDiff) IsImplementationChanged() bool {
+	return rd.ResourceConfig.ImplementationChanged
+}
+
 // IsFieldInNewNestedStructure determines if a field is part of a completely new nested structure
 func (rd ResourceDiff) IsFieldInNewNestedStructure(fieldPath string) bool {
 	if rd.IsNewResource() {
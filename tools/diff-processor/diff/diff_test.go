@@ -1,8 +1,10 @@
 package diff
 
 import (
+	"context"
 	"strings"
 	"testing"
+	"time"
 
 	newProvider "google/provider/new/google/provider"
 	newTpgresource "google/provider/new/google/tpgresource"
@@ -13,6 +15,7 @@ import (
 
 	"github.com/davecgh/go-spew/spew"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -32,7 +35,9 @@ func TestNewProviderOldProviderChanges(t *testing.T) {
 			continue
 		}
 		t.Logf("%s is modified", resource)
-		if diff := cmp.Diff(resourceDiff.ResourceConfig.Old, resourceDiff.ResourceConfig.New); diff != "" {
+		// cty.Type has unexported fields, so state upgrader types can't be compared.
+		ignoreStateUpgraderTypes := cmpopts.IgnoreFields(schema.StateUpgrader{}, "Type")
+		if diff := cmp.Diff(resourceDiff.ResourceConfig.Old, resourceDiff.ResourceConfig.New, ignoreStateUpgraderTypes); diff != "" {
 			t.Logf("%s config changes (-old, +new):\n%s", resource, diff)
 		}
 		for field, fieldDiff := range resourceDiff.Fields {
@@ -1006,6 +1011,132 @@ func TestFieldChanged(t *testing.T) {
 	}
 }
 
+func TestResourceConfigChanged(t *testing.T) {
+	twentyMinutes := 20 * time.Minute
+	tenMinutes := 10 * time.Minute
+	identitySchema := func(field *schema.Schema) func() map[string]*schema.Schema {
+		return func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{"name": field}
+		}
+	}
+	cases := map[string]struct {
+		oldConfig     *schema.Resource
+		newConfig     *schema.Resource
+		expectChanged bool
+	}{
+		"unchanged": {
+			oldConfig: &schema.Resource{
+				Importer:      &schema.ResourceImporter{},
+				Timeouts:      &schema.ResourceTimeout{Create: &twentyMinutes},
+				SchemaVersion: 1,
+				StateUpgraders: []schema.StateUpgrader{
+					{Version: 0},
+				},
+			},
+			newConfig: &schema.Resource{
+				Importer:      &schema.ResourceImporter{},
+				Timeouts:      &schema.ResourceTimeout{Create: &twentyMinutes},
+				SchemaVersion: 1,
+				StateUpgraders: []schema.StateUpgrader{
+					{Version: 0},
+				},
+			},
+			expectChanged: false,
+		},
+		"resource added": {
+			oldConfig:     nil,
+			newConfig:     &schema.Resource{},
+			expectChanged: true,
+		},
+		"importer removed": {
+			oldConfig:     &schema.Resource{Importer: &schema.ResourceImporter{}},
+			newConfig:     &schema.Resource{},
+			expectChanged: true,
+		},
+		"timeout changed": {
+			oldConfig:     &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &twentyMinutes}},
+			newConfig:     &schema.Resource{Timeouts: &schema.ResourceTimeout{Create: &tenMinutes}},
+			expectChanged: true,
+		},
+		"schema version changed": {
+			oldConfig:     &schema.Resource{SchemaVersion: 0},
+			newConfig:     &schema.Resource{SchemaVersion: 1},
+			expectChanged: true,
+		},
+		"state upgrader added": {
+			oldConfig: &schema.Resource{},
+			newConfig: &schema.Resource{
+				StateUpgraders: []schema.StateUpgrader{
+					{Version: 0},
+				},
+			},
+			expectChanged: true,
+		},
+		"customize diff added": {
+			oldConfig: &schema.Resource{},
+			newConfig: &schema.Resource{
+				CustomizeDiff: func(context.Context, *schema.ResourceDiff, interface{}) error { return nil },
+			},
+			expectChanged: true,
+		},
+		"deprecation message changed": {
+			oldConfig:     &schema.Resource{},
+			newConfig:     &schema.Resource{DeprecationMessage: "deprecated"},
+			expectChanged: true,
+		},
+		"identity added": {
+			oldConfig: &schema.Resource{},
+			newConfig: &schema.Resource{
+				Identity: &schema.ResourceIdentity{
+					SchemaFunc: identitySchema(&schema.Schema{Type: schema.TypeString, RequiredForImport: true}),
+				},
+			},
+			expectChanged: true,
+		},
+		"identity unchanged": {
+			oldConfig: &schema.Resource{
+				Identity: &schema.ResourceIdentity{
+					SchemaFunc: identitySchema(&schema.Schema{Type: schema.TypeString, RequiredForImport: true}),
+				},
+			},
+			newConfig: &schema.Resource{
+				Identity: &schema.ResourceIdentity{
+					SchemaFunc: identitySchema(&schema.Schema{Type: schema.TypeString, RequiredForImport: true}),
+				},
+			},
+			expectChanged: false,
+		},
+		"identity field changed": {
+			oldConfig: &schema.Resource{
+				Identity: &schema.ResourceIdentity{
+					SchemaFunc: identitySchema(&schema.Schema{Type: schema.TypeString, RequiredForImport: true}),
+				},
+			},
+			newConfig: &schema.Resource{
+				Identity: &schema.ResourceIdentity{
+					SchemaFunc: identitySchema(&schema.Schema{Type: schema.TypeString, OptionalForImport: true}),
+				},
+			},
+			expectChanged: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			if changed := resourceConfigChanged(tc.oldConfig, tc.newConfig); changed != tc.expectChanged {
+				t.Errorf("want %t; got %t.\nOld config: %s\nNew config: %s\n",
+					tc.expectChanged,
+					changed,
+					spew.Sdump(tc.oldConfig),
+					spew.Sdump(tc.newConfig),
+				)
+			}
+		})
+	}
+}
+
 func TestComputeSchemaDiff(t *testing.T) {
 	cases := map[string]struct {
 		oldResourceMap     map[string]*schema.Resource
@@ -1028,6 +1159,41 @@ func TestComputeSchemaDiff(t *testing.T) {
 			},
 			expectedSchemaDiff: SchemaDiff{},
 		},
+		"resource-config-changed": {
+			oldResourceMap: map[string]*schema.Resource{
+				"google_service_one_resource_one": {
+					Importer: &schema.ResourceImporter{},
+					Schema: map[string]*schema.Schema{
+						"field_one": {Type: schema.TypeString},
+					},
+				},
+			},
+			newResourceMap: map[string]*schema.Resource{
+				"google_service_one_resource_one": {
+					SchemaVersion: 1,
+					Schema: map[string]*schema.Schema{
+						"field_one": {Type: schema.TypeString},
+					},
+				},
+			},
+			expectedSchemaDiff: SchemaDiff{
+				"google_service_one_resource_one": ResourceDiff{
+					ResourceConfig: ResourceConfigDiff{
+						Old: &schema.Resource{Importer: &schema.ResourceImporter{}},
+						New: &schema.Resource{SchemaVersion: 1},
+					},
+					FlattenedSchema: FlattenedSchemaRaw{
+						Old: map[string]*schema.Schema{
+							"field_one": {Type: schema.TypeString},
+						},
+						New: map[string]*schema.Schema{
+							"field_one": {Type: schema.TypeString},
+						},
+					},
+					Fields: map[string]FieldDiff{},
+				},
+			},
+		},
 		"unchanged-nested-field": {
 			oldResourceMap: map[string]*schema.Resource{
 				"google_service_one_resource_one": {
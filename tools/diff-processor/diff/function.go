@@ -0,0 +1,95 @@
+package diff
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// FunctionSchemaDiff is a map of provider-defined function names to the
+// diffs of their definitions.
+type FunctionSchemaDiff map[string]FunctionDiff
+
+type FunctionDiff struct {
+	Old *tfprotov5.Function
+	New *tfprotov5.Function
+}
+
+func ComputeFunctionDiff(oldFunctions, newFunctions map[string]*tfprotov5.Function) FunctionSchemaDiff {
+	functionDiff := make(FunctionSchemaDiff)
+	for function := range union(oldFunctions, newFunctions) {
+		oldFunction, newFunction := oldFunctions[function], newFunctions[function]
+		if functionChanged(oldFunction, newFunction) {
+			functionDiff[function] = FunctionDiff{Old: oldFunction, New: newFunction}
+		}
+	}
+	return functionDiff
+}
+
+func functionChanged(oldFunction, newFunction *tfprotov5.Function) bool {
+	// If either function is nil, the function was added or removed
+	if oldFunction == nil || newFunction == nil {
+		return oldFunction != newFunction
+	}
+	if len(oldFunction.Parameters) != len(newFunction.Parameters) {
+		return true
+	}
+	for i := range oldFunction.Parameters {
+		if functionParameterChanged(oldFunction.Parameters[i], newFunction.Parameters[i]) {
+			return true
+		}
+	}
+	if functionParameterChanged(oldFunction.VariadicParameter, newFunction.VariadicParameter) {
+		return true
+	}
+	if protocolTypeChanged(FunctionReturnType(oldFunction), FunctionReturnType(newFunction)) {
+		return true
+	}
+	if oldFunction.Summary != newFunction.Summary {
+		return true
+	}
+	if oldFunction.Description != newFunction.Description {
+		return true
+	}
+	if oldFunction.DeprecationMessage != newFunction.DeprecationMessage {
+		return true
+	}
+	return false
+}
+
+func functionParameterChanged(oldParameter, newParameter *tfprotov5.FunctionParameter) bool {
+	if oldParameter == nil || newParameter == nil {
+		return oldParameter != newParameter
+	}
+	if oldParameter.Name != newParameter.Name {
+		return true
+	}
+	if protocolTypeChanged(oldParameter.Type, newParameter.Type) {
+		return true
+	}
+	if oldParameter.AllowNullValue != newParameter.AllowNullValue {
+		return true
+	}
+	if oldParameter.AllowUnknownValues != newParameter.AllowUnknownValues {
+		return true
+	}
+	if oldParameter.Description != newParameter.Description {
+		return true
+	}
+	return false
+}
+
+// FunctionReturnType returns the type of a function's return value, or nil
+// if it isn't set.
+func FunctionReturnType(function *tfprotov5.Function) tftypes.Type {
+	if function == nil || function.Return == nil {
+		return nil
+	}
+	return function.Return.Type
+}
+
+func protocolTypeChanged(oldType, newType tftypes.Type) bool {
+	if oldType == nil || newType == nil {
+		return oldType != newType
+	}
+	return !oldType.Equal(newType)
+}
@@ -0,0 +1,94 @@
+package diff
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestComputeFunctionDiff(t *testing.T) {
+	idFunction := func() *tfprotov5.Function {
+		return &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{{Name: "id", Type: tftypes.String}},
+			Return:     &tfprotov5.FunctionReturn{Type: tftypes.String},
+			Summary:    "Returns the name within a resource id",
+		}
+	}
+	cases := map[string]struct {
+		oldFunctions         map[string]*tfprotov5.Function
+		newFunctions         map[string]*tfprotov5.Function
+		expectedChangedNames []string
+	}{
+		"unchanged": {
+			oldFunctions: map[string]*tfprotov5.Function{"name_from_id": idFunction()},
+			newFunctions: map[string]*tfprotov5.Function{"name_from_id": idFunction()},
+		},
+		"added and removed": {
+			oldFunctions:         map[string]*tfprotov5.Function{"name_from_id": idFunction()},
+			newFunctions:         map[string]*tfprotov5.Function{"project_from_id": idFunction()},
+			expectedChangedNames: []string{"name_from_id", "project_from_id"},
+		},
+		"parameter type changed": {
+			oldFunctions: map[string]*tfprotov5.Function{"name_from_id": idFunction()},
+			newFunctions: map[string]*tfprotov5.Function{
+				"name_from_id": func() *tfprotov5.Function {
+					f := idFunction()
+					f.Parameters[0].Type = tftypes.List{ElementType: tftypes.String}
+					return f
+				}(),
+			},
+			expectedChangedNames: []string{"name_from_id"},
+		},
+		"variadic parameter added": {
+			oldFunctions: map[string]*tfprotov5.Function{"name_from_id": idFunction()},
+			newFunctions: map[string]*tfprotov5.Function{
+				"name_from_id": func() *tfprotov5.Function {
+					f := idFunction()
+					f.VariadicParameter = &tfprotov5.FunctionParameter{Name: "ids", Type: tftypes.String}
+					return f
+				}(),
+			},
+			expectedChangedNames: []string{"name_from_id"},
+		},
+		"return type changed": {
+			oldFunctions: map[string]*tfprotov5.Function{"name_from_id": idFunction()},
+			newFunctions: map[string]*tfprotov5.Function{
+				"name_from_id": func() *tfprotov5.Function {
+					f := idFunction()
+					f.Return.Type = tftypes.Bool
+					return f
+				}(),
+			},
+			expectedChangedNames: []string{"name_from_id"},
+		},
+		"summary changed": {
+			oldFunctions: map[string]*tfprotov5.Function{"name_from_id": idFunction()},
+			newFunctions: map[string]*tfprotov5.Function{
+				"name_from_id": func() *tfprotov5.Function {
+					f := idFunction()
+					f.Summary = "Returns the short name within a resource id"
+					return f
+				}(),
+			},
+			expectedChangedNames: []string{"name_from_id"},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			var changedNames []string
+			for name := range ComputeFunctionDiff(tc.oldFunctions, tc.newFunctions) {
+				changedNames = append(changedNames, name)
+			}
+			slices.Sort(changedNames)
+			if diff := cmp.Diff(tc.expectedChangedNames, changedNames); diff != "" {
+				t.Errorf("changed functions not equal (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package diff
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ResourceMetadata is the part of a resource's `*_meta.yaml` metadata file
+// that describes how its fields behave. MMv1 generates the behavior of its
+// fields from their validation, enum values, diff suppress and state funcs,
+// and whether empty values are sent to the API; the schema only has pointers
+// to the funcs implementing these.
+type ResourceMetadata struct {
+	Resource string          `yaml:"resource"`
+	Fields   []FieldMetadata `yaml:"fields"`
+}
+
+type FieldMetadata struct {
+	ApiField         string   `yaml:"api_field"`
+	Field            string   `yaml:"field"`
+	ValidationRegex  string   `yaml:"validation_regex"`
+	EnumValues       []string `yaml:"enum_values"`
+	DiffSuppressFunc string   `yaml:"diff_suppress_func"`
+	StateFunc        string   `yaml:"state_func"`
+	SendEmptyValue   bool     `yaml:"send_empty_value"`
+}
+
+// Name returns the dotted Terraform path of the field, such as `foo.bar`.
+// Metadata only records it if it differs from the snake case API path.
+func (f FieldMetadata) Name() string {
+	if f.Field != "" {
+		return f.Field
+	}
+	parts := strings.Split(f.ApiField, ".")
+	for i, part := range parts {
+		parts[i] = underscore(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+var (
+	underscoreAcronymRegexp = regexp.MustCompile(`([A-Z]+)([A-Z][a-z])`)
+	underscoreWordRegexp    = regexp.MustCompile(`([a-z\d])([A-Z])`)
+)
+
+// underscore converts an API field name to snake case the way MMv1 does.
+func underscore(s string) string {
+	s = underscoreAcronymRegexp.ReplaceAllString(s, "${1}_${2}")
+	s = underscoreWordRegexp.ReplaceAllString(s, "${1}_${2}")
+	s = strings.Replace(s, "-", "_", 1)
+	return strings.ToLower(s)
+}
+
+// LoadResourceMetadata reads the metadata files of the resources in a
+// provider's services directory, keyed by resource name. A missing directory
+// has no metadata.
+func LoadResourceMetadata(servicesDir string) (map[string]ResourceMetadata, error) {
+	metadata := make(map[string]ResourceMetadata)
+	err := filepath.WalkDir(servicesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), "_meta.yaml") {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		var resourceMetadata ResourceMetadata
+		if err := yaml.Unmarshal(content, &resourceMetadata); err != nil {
+			return fmt.Errorf("error parsing %s: %w", path, err)
+		}
+		if resourceMetadata.Resource != "" {
+			metadata[resourceMetadata.Resource] = resourceMetadata
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return metadata, nil
+	}
+	return metadata, err
+}
+
+// MetadataDiff is a nested map of resource names and field names to the diffs
+// of the behavior of fields in both versions of a resource.
+type MetadataDiff map[string]map[string]FieldMetadataDiff
+
+type FieldMetadataDiff struct {
+	Old *FieldMetadata
+	New *FieldMetadata
+}
+
+// ComputeMetadataDiff computes the diff between the field metadata of two
+// provider builds. Fields that were added or removed are left to the schema
+// diff.
+func ComputeMetadataDiff(oldMetadata, newMetadata map[string]ResourceMetadata) MetadataDiff {
+	metadataDiff := make(MetadataDiff)
+	for resource, oldResourceMetadata := range oldMetadata {
+		newResourceMetadata, ok := newMetadata[resource]
+		if !ok {
+			continue
+		}
+		oldFields, newFields := fieldMetadataByName(oldResourceMetadata), fieldMetadataByName(newResourceMetadata)
+		for field, oldField := range oldFields {
+			newField, ok := newFields[field]
+			if !ok || !fieldMetadataChanged(oldField, newField) {
+				continue
+			}
+			if _, ok := metadataDiff[resource]; !ok {
+				metadataDiff[resource] = make(map[string]FieldMetadataDiff)
+			}
+			metadataDiff[resource][field] = FieldMetadataDiff{Old: oldField, New: newField}
+		}
+	}
+	return metadataDiff
+}
+
+func fieldMetadataByName(resourceMetadata ResourceMetadata) map[string]*FieldMetadata {
+	fields := make(map[string]*FieldMetadata)
+	for i := range resourceMetadata.Fields {
+		field := &resourceMetadata.Fields[i]
+		fields[field.Name()] = field
+	}
+	return fields
+}
+
+func fieldMetadataChanged(oldField, newField *FieldMetadata) bool {
+	if oldField.ValidationRegex != newField.ValidationRegex {
+		return true
+	}
+	if !slices.Equal(oldField.EnumValues, newField.EnumValues) {
+		return true
+	}
+	if oldField.DiffSuppressFunc != newField.DiffSuppressFunc {
+		return true
+	}
+	if oldField.StateFunc != newField.StateFunc {
+		return true
+	}
+	if oldField.SendEmptyValue != newField.SendEmptyValue {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestLoadResourceMetadata(t *testing.T) {
+	metadata, err := LoadResourceMetadata("../testdata/services")
+	if err != nil {
+		t.Fatalf("Error loading metadata: %v", err)
+	}
+	want := map[string]ResourceMetadata{
+		"google_a_resource": {
+			Resource: "google_a_resource",
+			Fields: []FieldMetadata{
+				{Field: "location"},
+				{ApiField: "networkConfig.connectMode", EnumValues: []string{"DIRECT_PEERING", "PRIVATE_SERVICE_ACCESS"}},
+				{ApiField: "network", Field: "network_name", DiffSuppressFunc: "tpgresource.CompareSelfLinkOrResourceName"},
+				{ApiField: "displayName", ValidationRegex: "^[a-z]([-a-z0-9]*[a-z0-9])?$", SendEmptyValue: true},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, metadata); diff != "" {
+		t.Errorf("LoadResourceMetadata() diff(-want, +got) = %s", diff)
+	}
+
+	metadata, err = LoadResourceMetadata("../testdata/missing")
+	if err != nil || len(metadata) != 0 {
+		t.Errorf("LoadResourceMetadata() of a missing directory = %v, %v; want no metadata", metadata, err)
+	}
+}
+
+func TestFieldMetadataName(t *testing.T) {
+	cases := map[string]struct {
+		field FieldMetadata
+		want  string
+	}{
+		"api field": {
+			field: FieldMetadata{ApiField: "networkConfig.connectMode"},
+			want:  "network_config.connect_mode",
+		},
+		"acronym": {
+			field: FieldMetadata{ApiField: "kmsKeyName.sourceIPRanges"},
+			want:  "kms_key_name.source_ip_ranges",
+		},
+		"field": {
+			field: FieldMetadata{ApiField: "network", Field: "network_name"},
+			want:  "network_name",
+		},
+	}
+	for tn, tc := range cases {
+		if got := tc.field.Name(); got != tc.want {
+			t.Errorf("%s: Name() = %q, want %q", tn, got, tc.want)
+		}
+	}
+}
+
+func TestComputeMetadataDiff(t *testing.T) {
+	resourceMetadata := func(fields ...FieldMetadata) map[string]ResourceMetadata {
+		return map[string]ResourceMetadata{"google_a_resource": {Resource: "google_a_resource", Fields: fields}}
+	}
+	cases := map[string]struct {
+		oldMetadata map[string]ResourceMetadata
+		newMetadata map[string]ResourceMetadata
+		wantFields  []string
+	}{
+		"unchanged": {
+			oldMetadata: resourceMetadata(FieldMetadata{ApiField: "mode", EnumValues: []string{"A", "B"}}),
+			newMetadata: resourceMetadata(FieldMetadata{ApiField: "mode", EnumValues: []string{"A", "B"}}),
+		},
+		"resource added": {
+			newMetadata: resourceMetadata(FieldMetadata{ApiField: "mode", EnumValues: []string{"A", "B"}}),
+		},
+		"field added": {
+			oldMetadata: resourceMetadata(),
+			newMetadata: resourceMetadata(FieldMetadata{ApiField: "mode", EnumValues: []string{"A", "B"}}),
+		},
+		"enum value removed": {
+			oldMetadata: resourceMetadata(FieldMetadata{ApiField: "mode", EnumValues: []string{"A", "B"}}),
+			newMetadata: resourceMetadata(FieldMetadata{ApiField: "mode", EnumValues: []string{"A"}}),
+			wantFields:  []string{"mode"},
+		},
+		"state func and send empty value changed": {
+			oldMetadata: resourceMetadata(
+				FieldMetadata{ApiField: "config", StateFunc: "normalize"},
+				FieldMetadata{ApiField: "displayName"},
+			),
+			newMetadata: resourceMetadata(
+				FieldMetadata{ApiField: "config"},
+				FieldMetadata{ApiField: "displayName", SendEmptyValue: true},
+			),
+			wantFields: []string{"config", "display_name"},
+		},
+	}
+	for tn, tc := range cases {
+		metadataDiff := ComputeMetadataDiff(tc.oldMetadata, tc.newMetadata)
+		var gotFields []string
+		for field := range metadataDiff["google_a_resource"] {
+			gotFields = append(gotFields, field)
+		}
+		if diff := cmp.Diff(tc.wantFields, gotFields, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+			t.Errorf("%s: ComputeMetadataDiff() fields diff(-want, +got) = %s", tn, diff)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package diff
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ProviderSchemas holds the schemas of everything a provider build serves.
+// Resources and data sources include those implemented with the plugin
+// framework, and FrameworkResources holds the names of those resources.
+// ResourceMetadata holds the behavior of the fields of generated resources,
+// which isn't part of their schemas.
+type ProviderSchemas struct {
+	Resources          map[string]*schema.Resource
+	DataSources        map[string]*schema.Resource
+	EphemeralResources map[string]*schema.Resource
+	Functions          map[string]*tfprotov5.Function
+	ResourceMetadata   map[string]ResourceMetadata
+	FrameworkResources map[string]bool
+}
+
+// ProviderDiff is the diff between the schemas of two provider builds.
+type ProviderDiff struct {
+	Resources          SchemaDiff
+	DataSources        SchemaDiff
+	EphemeralResources SchemaDiff
+	Functions          FunctionSchemaDiff
+	ResourceMetadata   MetadataDiff
+}
+
+// LoadProviderSchemas returns the schemas served by a provider build's SDK
+// provider and plugin framework provider. Framework schemas are read through
+// the plugin protocol and converted to SDK schemas, so that they're diffed the
+// same way. Import support of framework resources is read from their
+// implementation, but the protocol doesn't carry their default timeouts or
+// validation, so those aren't diffed.
+func LoadProviderSchemas(sdkProvider *schema.Provider, frameworkProvider provider.Provider) (ProviderSchemas, error) {
+	ctx := context.Background()
+	server := providerserver.NewProtocol5(frameworkProvider)()
+	resp, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return ProviderSchemas{}, err
+	}
+	var errs []string
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			errs = append(errs, fmt.Sprintf("%s: %s", d.Summary, d.Detail))
+		}
+	}
+	if len(errs) > 0 {
+		return ProviderSchemas{}, fmt.Errorf("error reading framework provider schemas: %s", strings.Join(errs, "; "))
+	}
+
+	resources := resourceMapFromProtocolSchemas(resp.ResourceSchemas)
+	frameworkResources := make(map[string]bool)
+	for name, importable := range frameworkResourceImportSupport(ctx, frameworkProvider) {
+		if r, ok := resources[name]; ok {
+			frameworkResources[name] = true
+			if importable {
+				r.Importer = &schema.ResourceImporter{}
+			}
+		}
+	}
+	maps.Copy(resources, sdkProvider.ResourcesMap)
+	dataSources := resourceMapFromProtocolSchemas(resp.DataSourceSchemas)
+	maps.Copy(dataSources, sdkProvider.DataSourcesMap)
+	return ProviderSchemas{
+		Resources:          resources,
+		DataSources:        dataSources,
+		EphemeralResources: resourceMapFromProtocolSchemas(resp.EphemeralResourceSchemas),
+		Functions:          resp.Functions,
+		FrameworkResources: frameworkResources,
+	}, nil
+}
+
+// frameworkResourceImportSupport returns whether each resource of a plugin
+// framework provider supports import, by name.
+func frameworkResourceImportSupport(ctx context.Context, frameworkProvider provider.Provider) map[string]bool {
+	var providerMetadata provider.MetadataResponse
+	frameworkProvider.Metadata(ctx, provider.MetadataRequest{}, &providerMetadata)
+
+	importSupport := make(map[string]bool)
+	for _, newResource := range frameworkProvider.Resources(ctx) {
+		r := newResource()
+		var metadata resource.MetadataResponse
+		r.Metadata(ctx, resource.MetadataRequest{ProviderTypeName: providerMetadata.TypeName}, &metadata)
+		_, importable := r.(resource.ResourceWithImportState)
+		importSupport[metadata.TypeName] = importable
+	}
+	return importSupport
+}
+
+// ComputeProviderDiff computes the diff between the schemas of two provider
+// builds.
+func ComputeProviderDiff(oldSchemas, newSchemas ProviderSchemas) ProviderDiff {
+	resources := ComputeSchemaDiff(oldSchemas.Resources, newSchemas.Resources)
+	for name, resourceDiff := range resources {
+		if oldSchemas.FrameworkResources[name] != newSchemas.FrameworkResources[name] {
+			resourceDiff.ResourceConfig.ImplementationChanged = resourceDiff.ResourceConfig.Old != nil && resourceDiff.ResourceConfig.New != nil
+			resources[name] = resourceDiff
+		}
+	}
+	return ProviderDiff{
+		Resources:          resources,
+		DataSources:        ComputeSchemaDiff(oldSchemas.DataSources, newSchemas.DataSources),
+		EphemeralResources: ComputeSchemaDiff(oldSchemas.EphemeralResources, newSchemas.EphemeralResources),
+		Functions:          ComputeFunctionDiff(oldSchemas.Functions, newSchemas.Functions),
+		ResourceMetadata:   ComputeMetadataDiff(oldSchemas.ResourceMetadata, newSchemas.ResourceMetadata),
+	}
+}
+
+func resourceMapFromProtocolSchemas(schemas map[string]*tfprotov5.Schema) map[string]*schema.Resource {
+	resourceMap := make(map[string]*schema.Resource)
+	for name, s := range schemas {
+		resource := &schema.Resource{
+			Schema:        schemaFromProtocolBlock(s.Block),
+			SchemaVersion: int(s.Version),
+		}
+		if s.Block != nil && s.Block.Deprecated {
+			resource.DeprecationMessage = protocolDeprecationMessage
+		}
+		resourceMap[name] = resource
+	}
+	return resourceMap
+}
+
+// The plugin protocol only says whether a schema element is deprecated.
+const protocolDeprecationMessage = "deprecated"
+
+// schemaFromProtocolBlock converts the attributes and nested blocks of a
+// protocol schema block to SDK fields. Nested blocks become lists, sets or
+// maps of nested resources, as they are in SDK schemas, and single nested
+// blocks become lists of at most one element.
+func schemaFromProtocolBlock(block *tfprotov5.SchemaBlock) map[string]*schema.Schema {
+	fields := make(map[string]*schema.Schema)
+	if block == nil {
+		return fields
+	}
+	for _, attribute := range block.Attributes {
+		field := schemaFromProtocolType(attribute.Type)
+		field.Required = attribute.Required
+		field.Optional = attribute.Optional
+		field.Computed = attribute.Computed
+		field.Sensitive = attribute.Sensitive
+		field.WriteOnly = attribute.WriteOnly
+		field.Description = attribute.Description
+		if attribute.Deprecated {
+			field.Deprecated = protocolDeprecationMessage
+		}
+		fields[attribute.Name] = field
+	}
+	for _, nestedBlock := range block.BlockTypes {
+		field := &schema.Schema{
+			Elem:     &schema.Resource{Schema: schemaFromProtocolBlock(nestedBlock.Block)},
+			Required: nestedBlock.MinItems > 0,
+			Optional: nestedBlock.MinItems == 0,
+			MinItems: int(nestedBlock.MinItems),
+			MaxItems: int(nestedBlock.MaxItems),
+		}
+		switch nestedBlock.Nesting {
+		case tfprotov5.SchemaNestedBlockNestingModeSet:
+			field.Type = schema.TypeSet
+		case tfprotov5.SchemaNestedBlockNestingModeMap:
+			field.Type = schema.TypeMap
+		case tfprotov5.SchemaNestedBlockNestingModeSingle, tfprotov5.SchemaNestedBlockNestingModeGroup:
+			field.Type = schema.TypeList
+			field.MaxItems = 1
+		default:
+			field.Type = schema.TypeList
+		}
+		if nestedBlock.Block != nil {
+			field.Description = nestedBlock.Block.Description
+			if nestedBlock.Block.Deprecated {
+				field.Deprecated = protocolDeprecationMessage
+			}
+		}
+		fields[nestedBlock.TypeName] = field
+	}
+	return fields
+}
+
+// schemaFromProtocolType converts the type of a protocol schema attribute to
+// an SDK field. Numbers become floats, as the protocol doesn't distinguish
+// them from integers, and objects become lists of at most one nested
+// resource.
+func schemaFromProtocolType(t tftypes.Type) *schema.Schema {
+	switch t := t.(type) {
+	case tftypes.List:
+		return &schema.Schema{Type: schema.TypeList, Elem: elemFromProtocolType(t.ElementType)}
+	case tftypes.Set:
+		return &schema.Schema{Type: schema.TypeSet, Elem: elemFromProtocolType(t.ElementType)}
+	case tftypes.Map:
+		return &schema.Schema{Type: schema.TypeMap, Elem: elemFromProtocolType(t.ElementType)}
+	case tftypes.Object:
+		return &schema.Schema{Type: schema.TypeList, MaxItems: 1, Elem: elemFromProtocolType(t)}
+	}
+
+	switch {
+	case t.Is(tftypes.String):
+		return &schema.Schema{Type: schema.TypeString}
+	case t.Is(tftypes.Bool):
+		return &schema.Schema{Type: schema.TypeBool}
+	case t.Is(tftypes.Number):
+		return &schema.Schema{Type: schema.TypeFloat}
+	}
+	return &schema.Schema{Type: schema.TypeInvalid}
+}
+
+func elemFromProtocolType(t tftypes.Type) interface{} {
+	object, ok := t.(tftypes.Object)
+	if !ok {
+		return schemaFromProtocolType(t)
+	}
+	fields := make(map[string]*schema.Schema)
+	for name, attributeType := range object.AttributeTypes {
+		fields[name] = schemaFromProtocolType(attributeType)
+	}
+	return &schema.Resource{Schema: fields}
+}
@@ -0,0 +1,251 @@
+package diff
+
+import (
+	"context"
+	"testing"
+
+	newFwprovider "google/provider/new/google/fwprovider"
+	newProvider "google/provider/new/google/provider"
+	oldFwprovider "google/provider/old/google/fwprovider"
+	oldProvider "google/provider/old/google/provider"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestNewProviderOldProviderSchemaChanges(t *testing.T) {
+	oldPrimary := oldProvider.Provider()
+	oldSchemas, err := LoadProviderSchemas(oldPrimary, oldFwprovider.New(oldPrimary))
+	if err != nil {
+		t.Fatalf("error loading old provider schemas: %s", err)
+	}
+	newPrimary := newProvider.Provider()
+	newSchemas, err := LoadProviderSchemas(newPrimary, newFwprovider.New(newPrimary))
+	if err != nil {
+		t.Fatalf("error loading new provider schemas: %s", err)
+	}
+	if len(newSchemas.Functions) == 0 {
+		t.Errorf("no functions loaded from the new provider")
+	}
+
+	changes := ComputeProviderDiff(oldSchemas, newSchemas)
+	for dataSource := range changes.DataSources {
+		t.Logf("data source %s is changed", dataSource)
+	}
+	for ephemeralResource := range changes.EphemeralResources {
+		t.Logf("ephemeral resource %s is changed", ephemeralResource)
+	}
+	for function := range changes.Functions {
+		t.Logf("function %s is changed", function)
+	}
+}
+
+// testFrameworkProvider serves testFrameworkResource and an importable
+// version of it.
+type testFrameworkProvider struct{}
+
+func (testFrameworkProvider) Metadata(_ context.Context, _ provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "google"
+}
+
+func (testFrameworkProvider) Schema(context.Context, provider.SchemaRequest, *provider.SchemaResponse) {
+}
+
+func (testFrameworkProvider) Configure(context.Context, provider.ConfigureRequest, *provider.ConfigureResponse) {
+}
+
+func (testFrameworkProvider) DataSources(context.Context) []func() datasource.DataSource {
+	return nil
+}
+
+func (testFrameworkProvider) Resources(context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		func() resource.Resource { return &testFrameworkResource{name: "widget"} },
+		func() resource.Resource {
+			return &testImportableFrameworkResource{testFrameworkResource{name: "gadget"}}
+		},
+	}
+}
+
+type testFrameworkResource struct {
+	name string
+}
+
+func (r *testFrameworkResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.name
+}
+
+func (r *testFrameworkResource) Schema(context.Context, resource.SchemaRequest, *resource.SchemaResponse) {
+}
+
+func (r *testFrameworkResource) Create(context.Context, resource.CreateRequest, *resource.CreateResponse) {
+}
+
+func (r *testFrameworkResource) Read(context.Context, resource.ReadRequest, *resource.ReadResponse) {}
+
+func (r *testFrameworkResource) Update(context.Context, resource.UpdateRequest, *resource.UpdateResponse) {
+}
+
+func (r *testFrameworkResource) Delete(context.Context, resource.DeleteRequest, *resource.DeleteResponse) {
+}
+
+type testImportableFrameworkResource struct {
+	testFrameworkResource
+}
+
+func (r *testImportableFrameworkResource) ImportState(context.Context, resource.ImportStateRequest, *resource.ImportStateResponse) {
+}
+
+func TestFrameworkResourceImportSupport(t *testing.T) {
+	expected := map[string]bool{"google_widget": false, "google_gadget": true}
+	if diff := cmp.Diff(expected, frameworkResourceImportSupport(context.Background(), testFrameworkProvider{})); diff != "" {
+		t.Errorf("import support not equal (-want, +got):\n%s", diff)
+	}
+}
+
+func TestComputeProviderDiffImplementationChanged(t *testing.T) {
+	oldSchemas := ProviderSchemas{
+		Resources: map[string]*schema.Resource{
+			"google_widget": {Schema: map[string]*schema.Schema{"size": {Type: schema.TypeInt}}},
+			"google_gadget": {Schema: map[string]*schema.Schema{"size": {Type: schema.TypeInt}}},
+		},
+	}
+	newSchemas := ProviderSchemas{
+		Resources: map[string]*schema.Resource{
+			"google_widget": {Schema: map[string]*schema.Schema{"size": {Type: schema.TypeFloat}}},
+			"google_gadget": {Schema: map[string]*schema.Schema{"size": {Type: schema.TypeFloat}}},
+		},
+		FrameworkResources: map[string]bool{"google_widget": true},
+	}
+
+	changes := ComputeProviderDiff(oldSchemas, newSchemas)
+	if !changes.Resources["google_widget"].IsImplementationChanged() {
+		t.Errorf("expected google_widget to have moved to the plugin framework")
+	}
+	if changes.Resources["google_gadget"].IsImplementationChanged() {
+		t.Errorf("expected google_gadget to stay an SDK resource")
+	}
+}
+
+func TestSchemaFromProtocolBlock(t *testing.T) {
+	cases := map[string]struct {
+		block          *tfprotov5.SchemaBlock
+		expectedSchema map[string]*schema.Schema
+	}{
+		"nil block": {
+			block:          nil,
+			expectedSchema: map[string]*schema.Schema{},
+		},
+		"primitive attributes": {
+			block: &tfprotov5.SchemaBlock{
+				Attributes: []*tfprotov5.SchemaAttribute{
+					{Name: "name", Type: tftypes.String, Required: true, Description: "beep"},
+					{Name: "enabled", Type: tftypes.Bool, Optional: true, Computed: true},
+					{Name: "size", Type: tftypes.Number, Computed: true, Deprecated: true},
+					{Name: "token", Type: tftypes.String, Optional: true, Sensitive: true, WriteOnly: true},
+				},
+			},
+			expectedSchema: map[string]*schema.Schema{
+				"name":    {Type: schema.TypeString, Required: true, Description: "beep"},
+				"enabled": {Type: schema.TypeBool, Optional: true, Computed: true},
+				"size":    {Type: schema.TypeFloat, Computed: true, Deprecated: protocolDeprecationMessage},
+				"token":   {Type: schema.TypeString, Optional: true, Sensitive: true, WriteOnly: true},
+			},
+		},
+		"collection attributes": {
+			block: &tfprotov5.SchemaBlock{
+				Attributes: []*tfprotov5.SchemaAttribute{
+					{Name: "tags", Type: tftypes.Set{ElementType: tftypes.String}, Optional: true},
+					{Name: "labels", Type: tftypes.Map{ElementType: tftypes.String}, Optional: true},
+					{Name: "endpoints", Type: tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"uri": tftypes.String}}}, Computed: true},
+					{Name: "settings", Type: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"tier": tftypes.String}}, Optional: true},
+				},
+			},
+			expectedSchema: map[string]*schema.Schema{
+				"tags":   {Type: schema.TypeSet, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+				"labels": {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+				"endpoints": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"uri": {Type: schema.TypeString},
+						},
+					},
+				},
+				"settings": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"tier": {Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+		"nested blocks": {
+			block: &tfprotov5.SchemaBlock{
+				BlockTypes: []*tfprotov5.SchemaNestedBlock{
+					{
+						TypeName: "rule",
+						Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+						MinItems: 1,
+						Block: &tfprotov5.SchemaBlock{
+							Attributes: []*tfprotov5.SchemaAttribute{
+								{Name: "action", Type: tftypes.String, Required: true},
+							},
+						},
+					},
+					{
+						TypeName: "timeouts",
+						Nesting:  tfprotov5.SchemaNestedBlockNestingModeSingle,
+						Block: &tfprotov5.SchemaBlock{
+							Attributes: []*tfprotov5.SchemaAttribute{
+								{Name: "create", Type: tftypes.String, Optional: true},
+							},
+						},
+					},
+				},
+			},
+			expectedSchema: map[string]*schema.Schema{
+				"rule": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"action": {Type: schema.TypeString, Required: true},
+						},
+					},
+				},
+				"timeouts": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"create": {Type: schema.TypeString, Optional: true},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		tc := tc
+		t.Run(tn, func(t *testing.T) {
+			t.Parallel()
+			if diff := cmp.Diff(tc.expectedSchema, schemaFromProtocolBlock(tc.block)); diff != "" {
+				t.Errorf("schema not equal (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}